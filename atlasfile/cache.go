@@ -19,10 +19,10 @@ type cachedFile struct {
 	Version  string    `json:"version"`
 }
 
-func cacheAtlasfile(ctx context.Context, logger logrus.FieldLogger, version, atlasDirPath string, atlasfile *Atlasfile) error {
+func cacheAtlasfile(ctx context.Context, logger logrus.FieldLogger, evalContext EvalContext, atlasDirPath string, atlasfile *Atlasfile) error {
 	cacheFile := filepath.Join(atlasDirPath, "cache.json")
 
-	hash, err := computeAtlasfileHash(atlasDirPath)
+	hash, err := computeAtlasfileHash(atlasDirPath, evalContext)
 	if err != nil {
 		return fmt.Errorf("could not compute hash: %w", err)
 	}
@@ -31,7 +31,7 @@ func cacheAtlasfile(ctx context.Context, logger logrus.FieldLogger, version, atl
 		File:     *atlasfile,
 		CachedAt: time.Now().Format(time.RFC3339),
 		Hash:     hash,
-		Version:  version,
+		Version:  evalContext.Version,
 	}
 
 	fileBytes, err := json.Marshal(cachedFile)
@@ -47,7 +47,7 @@ func cacheAtlasfile(ctx context.Context, logger logrus.FieldLogger, version, atl
 	return nil
 }
 
-func getCachedAtlasfile(ctx context.Context, logger logrus.FieldLogger, version string, atlasDirPath string) (*Atlasfile, error) {
+func getCachedAtlasfile(ctx context.Context, logger logrus.FieldLogger, evalContext EvalContext, atlasDirPath string) (*Atlasfile, error) {
 	cacheFile := filepath.Join(atlasDirPath, "cache.json")
 
 	if !helper.FileExists(cacheFile) {
@@ -65,7 +65,7 @@ func getCachedAtlasfile(ctx context.Context, logger logrus.FieldLogger, version
 		return nil, fmt.Errorf("could not unmarshal cache file: %w", err)
 	}
 
-	shouldInvalidate, err := shouldInvalidateAtlasfile(ctx, logger, atlasDirPath, *cachedFile, version)
+	shouldInvalidate, err := shouldInvalidateAtlasfile(ctx, logger, atlasDirPath, *cachedFile, evalContext)
 	if err != nil {
 		return nil, fmt.Errorf("could not check if cache is valid: %w", err)
 	}
@@ -88,12 +88,12 @@ func getCachedAtlasfile(ctx context.Context, logger logrus.FieldLogger, version
 	return &cachedFile.File, nil
 }
 
-func shouldInvalidateAtlasfile(ctx context.Context, logger logrus.FieldLogger, dirPath string, file cachedFile, version string) (bool, error) {
+func shouldInvalidateAtlasfile(ctx context.Context, logger logrus.FieldLogger, dirPath string, file cachedFile, evalContext EvalContext) (bool, error) {
 	// Check if cache was produced by older version
-	if file.Version != version {
+	if file.Version != evalContext.Version {
 		logger.WithFields(logrus.Fields{
 			"cachedVersion":  file.Version,
-			"currentVersion": version,
+			"currentVersion": evalContext.Version,
 		}).Debugln("mismatch in version, invalidating cache")
 		return true, nil
 	}
@@ -110,7 +110,7 @@ func shouldInvalidateAtlasfile(ctx context.Context, logger logrus.FieldLogger, d
 		return true, nil
 	}
 
-	currentHash, err := computeAtlasfileHash(dirPath)
+	currentHash, err := computeAtlasfileHash(dirPath, evalContext)
 	if err != nil {
 		return false, fmt.Errorf("could not compute hash: %w", err)
 	}
@@ -126,10 +126,15 @@ func shouldInvalidateAtlasfile(ctx context.Context, logger logrus.FieldLogger, d
 	return false, nil
 }
 
-func computeAtlasfileHash(dir string) (string, error) {
-	var hashBytes []byte
+// computeAtlasfileHash hashes all relevant files in dir as well as the evalContext, as providers may return
+// different Atlasfiles depending on the requested stacks, profile and variables.
+func computeAtlasfileHash(dir string, evalContext EvalContext) (string, error) {
+	hashBytes, err := json.Marshal(evalContext)
+	if err != nil {
+		return "", fmt.Errorf("could not marshal eval context: %w", err)
+	}
 
-	err := filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+	err = filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
 		if d.IsDir() {
 			return nil
 		}
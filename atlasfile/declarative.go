@@ -0,0 +1,222 @@
+package atlasfile
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"github.com/brunoscheufler/atlas/helper"
+	"github.com/pelletier/go-toml/v2"
+	"gopkg.in/yaml.v3"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// declarativeExtensions lists extensions of Atlasfiles that are parsed directly instead of being evaluated by a provider
+var declarativeExtensions = []string{".toml", ".yaml", ".yml", ".json"}
+
+// codeExtensions lists extensions of Atlasfiles that are evaluated by a provider
+var codeExtensions = []string{".go", ".ts"}
+
+// findDeclarativeAtlasFile returns the path of the declarative Atlasfile in atlasDirPath or an empty string if none exists
+func findDeclarativeAtlasFile(atlasDirPath string) (string, error) {
+	var found []string
+
+	for _, ext := range declarativeExtensions {
+		for _, name := range []string{"Atlasfile" + ext, "Atlasfile.root" + ext} {
+			path := filepath.Join(atlasDirPath, name)
+			if helper.FileExists(path) {
+				found = append(found, path)
+			}
+		}
+	}
+
+	if len(found) > 1 {
+		return "", fmt.Errorf("found multiple declarative Atlasfiles in %s: %s", atlasDirPath, strings.Join(found, ", "))
+	}
+
+	if len(found) == 0 {
+		return "", nil
+	}
+
+	return found[0], nil
+}
+
+func readDeclarativeAtlasFile(path string) (*Atlasfile, error) {
+	fileBytes, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not read %s: %w", path, err)
+	}
+
+	switch filepath.Ext(path) {
+	case ".toml":
+		return decodeTomlAtlasFile(path, fileBytes)
+	case ".yaml", ".yml", ".json":
+		// JSON is a subset of YAML, so both are decoded the same way to get consistent diagnostics
+		return decodeYamlAtlasFile(path, fileBytes)
+	default:
+		return nil, fmt.Errorf("unsupported Atlasfile format %s", filepath.Ext(path))
+	}
+}
+
+func decodeTomlAtlasFile(path string, fileBytes []byte) (*Atlasfile, error) {
+	var file Atlasfile
+
+	err := toml.NewDecoder(bytes.NewReader(fileBytes)).DisallowUnknownFields().Decode(&file)
+	if err == nil {
+		return &file, nil
+	}
+
+	var strictErr *toml.StrictMissingError
+	if errors.As(err, &strictErr) {
+		diagnostics := make(Diagnostics, len(strictErr.Errors))
+		for i := range strictErr.Errors {
+			decodeErr := strictErr.Errors[i]
+			line, column := decodeErr.Position()
+			diagnostics[i] = Diagnostic{
+				File:    path,
+				Line:    line,
+				Column:  column,
+				Message: fmt.Sprintf("unknown field %q", strings.Join(decodeErr.Key(), ".")),
+			}
+		}
+		return nil, diagnostics
+	}
+
+	var decodeErr *toml.DecodeError
+	if errors.As(err, &decodeErr) {
+		line, column := decodeErr.Position()
+		return nil, Diagnostics{{File: path, Line: line, Column: column, Message: decodeErr.Error()}}
+	}
+
+	return nil, Diagnostics{{File: path, Message: err.Error()}}
+}
+
+var yamlLinePattern = regexp.MustCompile(`^(?:yaml: )?line (\d+): (.*)$`)
+
+// yamlDiagnostic converts yaml.v3 messages like "line 3: cannot unmarshal ..." into a Diagnostic
+func yamlDiagnostic(path, message string) Diagnostic {
+	match := yamlLinePattern.FindStringSubmatch(message)
+	if match == nil {
+		return Diagnostic{File: path, Message: message}
+	}
+
+	line, _ := strconv.Atoi(match[1])
+	return Diagnostic{File: path, Line: line, Message: match[2]}
+}
+
+func decodeYamlAtlasFile(path string, fileBytes []byte) (*Atlasfile, error) {
+	var file Atlasfile
+
+	var document yaml.Node
+	err := yaml.Unmarshal(fileBytes, &document)
+	if err != nil {
+		return nil, Diagnostics{yamlDiagnostic(path, err.Error())}
+	}
+
+	// Empty document
+	if len(document.Content) == 0 {
+		return &file, nil
+	}
+
+	diagnostics := checkYamlNode(path, document.Content[0], reflect.TypeOf(file))
+
+	err = document.Content[0].Decode(&file)
+	if err != nil {
+		var typeErr *yaml.TypeError
+		if !errors.As(err, &typeErr) {
+			return nil, Diagnostics{yamlDiagnostic(path, err.Error())}
+		}
+
+		for _, message := range typeErr.Errors {
+			diagnostics = append(diagnostics, yamlDiagnostic(path, message))
+		}
+	}
+
+	if len(diagnostics) > 0 {
+		return nil, diagnostics
+	}
+
+	return &file, nil
+}
+
+// checkYamlNode walks node alongside t and reports every key that has no corresponding field
+func checkYamlNode(path string, node *yaml.Node, t reflect.Type) Diagnostics {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	if node.Kind == yaml.AliasNode && node.Alias != nil {
+		node = node.Alias
+	}
+
+	var diagnostics Diagnostics
+
+	switch t.Kind() {
+	case reflect.Struct:
+		// Mismatching kinds are reported when decoding
+		if node.Kind != yaml.MappingNode {
+			return nil
+		}
+
+		fields := yamlFields(t)
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+
+			field, ok := fields[key.Value]
+			if !ok {
+				diagnostics = append(diagnostics, Diagnostic{
+					File:    path,
+					Line:    key.Line,
+					Column:  key.Column,
+					Message: fmt.Sprintf("unknown field %q in %s", key.Value, t.Name()),
+				})
+				continue
+			}
+
+			diagnostics = append(diagnostics, checkYamlNode(path, value, field.Type)...)
+		}
+	case reflect.Slice:
+		if node.Kind != yaml.SequenceNode {
+			return nil
+		}
+
+		for _, item := range node.Content {
+			diagnostics = append(diagnostics, checkYamlNode(path, item, t.Elem())...)
+		}
+	case reflect.Map:
+		if node.Kind != yaml.MappingNode {
+			return nil
+		}
+
+		for i := 1; i < len(node.Content); i += 2 {
+			diagnostics = append(diagnostics, checkYamlNode(path, node.Content[i], t.Elem())...)
+		}
+	}
+
+	return diagnostics
+}
+
+// yamlFields returns all exported fields of t by their yaml key
+func yamlFields(t reflect.Type) map[string]reflect.StructField {
+	fields := make(map[string]reflect.StructField)
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name, _, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+		if name == "" {
+			name = strings.ToLower(field.Name)
+		}
+
+		fields[name] = field
+	}
+
+	return fields
+}
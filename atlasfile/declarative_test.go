@@ -0,0 +1,105 @@
+package atlasfile
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestDecodeDeclarativeAtlasFile(t *testing.T) {
+	expected := &Atlasfile{
+		Services: []ServiceConfig{
+			{
+				Name:        "db",
+				Image:       "postgres:14",
+				Ports:       []PortRequest{{ContainerPort: 5432, Protocol: "tcp"}},
+				Environment: map[string]string{"POSTGRES_PASSWORD": "postgres"},
+			},
+		},
+		Stacks: []StackConfig{
+			{
+				Name: "local",
+				Services: []StackService{
+					{Name: "db", ExposePorts: []PortExpose{{HostPort: 5432, ContainerPort: 5432}}},
+				},
+			},
+		},
+	}
+
+	tomlFile, err := decodeTomlAtlasFile("Atlasfile.toml", []byte(`
+[[services]]
+name = "db"
+image = "postgres:14"
+port_requests = [{ containerPort = 5432, protocol = "tcp" }]
+environment = { POSTGRES_PASSWORD = "postgres" }
+
+[[stacks]]
+name = "local"
+services = [{ name = "db", exposePorts = [{ hostPort = 5432, containerPort = 5432 }] }]
+`))
+	assert.NoError(t, err)
+	assert.Equal(t, expected, tomlFile)
+
+	yamlFile, err := decodeYamlAtlasFile("Atlasfile.yaml", []byte(`
+services:
+  - name: db
+    image: postgres:14
+    port_requests:
+      - containerPort: 5432
+        protocol: tcp
+    environment:
+      POSTGRES_PASSWORD: postgres
+stacks:
+  - name: local
+    services:
+      - name: db
+        exposePorts:
+          - hostPort: 5432
+            containerPort: 5432
+`))
+	assert.NoError(t, err)
+	assert.Equal(t, expected, yamlFile)
+
+	jsonFile, err := decodeYamlAtlasFile("Atlasfile.json", []byte(`{
+	"services": [{
+		"name": "db",
+		"image": "postgres:14",
+		"port_requests": [{"containerPort": 5432, "protocol": "tcp"}],
+		"environment": {"POSTGRES_PASSWORD": "postgres"}
+	}],
+	"stacks": [{"name": "local", "services": [{"name": "db", "exposePorts": [{"hostPort": 5432, "containerPort": 5432}]}]}]
+}`))
+	assert.NoError(t, err)
+	assert.Equal(t, expected, jsonFile)
+}
+
+func TestDecodeDeclarativeAtlasFileUnknownFields(t *testing.T) {
+	_, err := decodeTomlAtlasFile("Atlasfile.toml", []byte(`
+[[services]]
+name = "db"
+imag = "postgres:14"
+`))
+
+	var diagnostics Diagnostics
+	assert.True(t, errors.As(err, &diagnostics))
+	assert.Equal(t, Diagnostics{
+		{File: "Atlasfile.toml", Line: 4, Column: 1, Message: `unknown field "services.imag"`},
+	}, diagnostics)
+
+	_, err = decodeYamlAtlasFile("Atlasfile.yaml", []byte(`
+services:
+  - name: db
+    imag: postgres:14
+    port_requests:
+      - containerPort: abc
+stacks:
+  - nam: local
+`))
+
+	assert.True(t, errors.As(err, &diagnostics))
+	assert.Equal(t, Diagnostics{
+		{File: "Atlasfile.yaml", Line: 4, Column: 5, Message: `unknown field "imag" in ServiceConfig`},
+		{File: "Atlasfile.yaml", Line: 8, Column: 5, Message: `unknown field "nam" in StackConfig`},
+		{File: "Atlasfile.yaml", Line: 6, Message: "cannot unmarshal !!str `abc` into int"},
+	}, diagnostics)
+}
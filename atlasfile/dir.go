@@ -2,21 +2,13 @@ package atlasfile
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
-	"github.com/brunoscheufler/atlas/exec"
 	"github.com/brunoscheufler/atlas/helper"
-	"github.com/brunoscheufler/atlas/protobuf"
-	"github.com/cenkalti/backoff/v4"
 	"github.com/sirupsen/logrus"
 	"golang.org/x/sync/errgroup"
-	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
 	"os"
 	"path/filepath"
 	"strings"
-	"syscall"
-	"time"
 )
 
 const JumpUpLimit = 5
@@ -32,7 +24,7 @@ func FindRootDir(cwd string) (string, error) {
 		// Check in cwd if .atlas exists as Atlasfile.root.*
 		atlasDirPath := filepath.Join(cwd, ".atlas")
 
-		if helper.FileExists(filepath.Join(atlasDirPath, "Atlasfile.root.go")) || helper.FileExists(filepath.Join(atlasDirPath, "Atlasfile.root.ts")) {
+		if hasRootAtlasFile(atlasDirPath) {
 			return cwd, nil
 		}
 
@@ -42,6 +34,17 @@ func FindRootDir(cwd string) (string, error) {
 	}
 }
 
+func hasRootAtlasFile(atlasDirPath string) bool {
+	extensions := append(append([]string{}, codeExtensions...), declarativeExtensions...)
+	for _, ext := range extensions {
+		if helper.FileExists(filepath.Join(atlasDirPath, "Atlasfile.root"+ext)) {
+			return true
+		}
+	}
+
+	return false
+}
+
 func FindAtlasDirectories(dir string) ([]string, error) {
 	files := make([]string, 0)
 
@@ -86,7 +89,9 @@ func FindAtlasDirectories(dir string) ([]string, error) {
 	return files, nil
 }
 
-func Collect(ctx context.Context, logger logrus.FieldLogger, version, cwd string) (*Atlasfile, error) {
+func Collect(ctx context.Context, logger logrus.FieldLogger, evalContext EvalContext) (*Atlasfile, error) {
+	cwd := evalContext.RootDir
+
 	// Find all .atlas directories with glob
 	paths, err := FindAtlasDirectories(cwd)
 	if err != nil {
@@ -110,7 +115,7 @@ func Collect(ctx context.Context, logger logrus.FieldLogger, version, cwd string
 		g.Go(func() error {
 			logger.Infoln(fmt.Sprintf("Reading Atlasfile %s", relpath))
 
-			file, err := readAtlasFile(ctx, logger, version, path)
+			file, err := readAtlasFile(ctx, logger, evalContext, path)
 			if err != nil {
 				return fmt.Errorf("could not read .atlas file: %w", err)
 			}
@@ -123,151 +128,70 @@ func Collect(ctx context.Context, logger logrus.FieldLogger, version, cwd string
 		return nil, err
 	}
 
-	return mergeAtlasFiles(collectedFiles), nil
-}
-
-// TODO Support non-code/Toml Atlasfile
-func readAtlasFile(ctx context.Context, logger logrus.FieldLogger, version, atlasDirPath string) (*Atlasfile, error) {
-	cachedFile, err := getCachedAtlasfile(ctx, logger, version, atlasDirPath)
-	if err != nil {
-		return nil, fmt.Errorf("could not read cached Atlasfile: %w", err)
-	}
-
-	if cachedFile != nil {
-		cachedFile.dirpath = atlasDirPath
-		return cachedFile, nil
-	}
-
-	var file *Atlasfile
-
-	// Check if go.mod exists
-	if helper.FileExists(filepath.Join(atlasDirPath, "go.mod")) {
-		file, err = readGoAtlasFile(ctx, logger, atlasDirPath)
-	}
+	mergedFile := MergeAtlasFiles(collectedFiles)
 
-	// Check if package.json exists
-	if helper.FileExists(filepath.Join(atlasDirPath, "package.json")) {
-		file, err = readTypeScriptAtlasFile(ctx, atlasDirPath)
+	diagnostics := mergedFile.Validate(cwd)
+	if len(diagnostics) > 0 {
+		return nil, fmt.Errorf("invalid Atlasfiles:\n%w", diagnostics)
 	}
 
-	if err != nil {
-		return nil, fmt.Errorf("could not read Atlasfile: %w", err)
-	}
-
-	if file == nil {
-		return nil, fmt.Errorf("missing go.mod or package.json, cannot infer language to use")
-	}
+	return mergedFile, nil
+}
 
-	err = cacheAtlasfile(ctx, logger, version, atlasDirPath, file)
+func readAtlasFile(ctx context.Context, logger logrus.FieldLogger, evalContext EvalContext, atlasDirPath string) (*Atlasfile, error) {
+	// Declarative Atlasfiles are cheap to parse, so they are neither evaluated nor cached
+	declarativePath, err := findDeclarativeAtlasFile(atlasDirPath)
 	if err != nil {
-		return nil, fmt.Errorf("could not cache Atlasfile: %w", err)
+		return nil, err
 	}
 
-	file.dirpath = atlasDirPath
+	if declarativePath != "" {
+		file, err := readDeclarativeAtlasFile(declarativePath)
+		if err != nil {
+			return nil, fmt.Errorf("could not read Atlasfile: %w", err)
+		}
 
-	return file, nil
-}
+		file.dirpath = atlasDirPath
 
-func readGoAtlasFile(ctx context.Context, logger logrus.FieldLogger, atlasDirPath string) (*Atlasfile, error) {
-	// Check if building the file works
-	err := exec.RunCommand(ctx, logger, "go build -o /dev/null .", exec.RunCommandOptions{Cwd: atlasDirPath, LogVisible: true, LogPrefix: atlasDirPath})
-	if err != nil {
-		return nil, fmt.Errorf("could not build atlas file (%s): %w", atlasDirPath, err)
+		return file, nil
 	}
 
-	port, err := helper.FreePort()
+	cachedFile, err := getCachedAtlasfile(ctx, logger, evalContext, atlasDirPath)
 	if err != nil {
-		return nil, fmt.Errorf("could not find free port: %w", err)
+		return nil, fmt.Errorf("could not read cached Atlasfile: %w", err)
 	}
 
-	// Start process in background with go run . in the directory
-	cmd, err := exec.StartCommand(ctx, logger, "go run .", atlasDirPath, []string{fmt.Sprintf("PORT=%d", port)})
-
-	backOff := &backoff.ExponentialBackOff{
-		InitialInterval:     time.Millisecond * 10,
-		MaxInterval:         time.Second * 3,
-		RandomizationFactor: backoff.DefaultRandomizationFactor,
-		Multiplier:          5,
-		MaxElapsedTime:      0,
-		Stop:                backoff.Stop,
-		Clock:               backoff.SystemClock,
+	if cachedFile != nil {
+		cachedFile.dirpath = atlasDirPath
+		return cachedFile, nil
 	}
-	backOff.Reset()
-
-	var client protobuf.AtlasfileClient
-	var conn *grpc.ClientConn
-
-	// Wait until started up
-	attempts := 0
-	for {
-		select {
-		// Either the ctx is canceled
-		case <-ctx.Done():
-			return nil, fmt.Errorf("could not connect to atlasfile, context canceled")
-
-			// Or the backOff elapses
-		case <-time.After(backOff.NextBackOff()):
-			// after which we might have to return as we reached the max num of attempts
-			if attempts > 10 {
-				return nil, fmt.Errorf("could not connect to atlasfile: %w", err)
-			}
-
-			// Connect to gRPC endpoint
-			conn, err = grpc.DialContext(ctx, fmt.Sprintf("localhost:%d", port), grpc.WithTransportCredentials(insecure.NewCredentials()))
-			if err != nil {
-				attempts++
-				continue
-			}
 
-			client = protobuf.NewAtlasfileClient(conn)
-
-			// try pinging the endpoint
-			_, err = client.Ping(ctx, &protobuf.PingRequest{})
-			if err != nil {
-				attempts++
-				continue
-			}
-		}
-
-		break
+	provider, err := resolveProvider(logger, atlasDirPath)
+	if err != nil {
+		return nil, fmt.Errorf("could not resolve Atlasfile provider: %w", err)
 	}
 
-	// Send request to get atlasfile
-	res, err := client.Eval(ctx, &protobuf.EvalRequest{})
-	if err != nil {
-		return nil, fmt.Errorf("could not eval atlasfile: %w", err)
+	if provider == nil {
+		return nil, fmt.Errorf("missing Atlasfile.toml, Atlasfile.yaml, Atlasfile.json, %s, go.mod or package.json, cannot infer language to use", ProviderConfigFileName)
 	}
 
-	var atlasfile Atlasfile
-	err = json.Unmarshal([]byte(res.Output), &atlasfile)
+	file, err := readProviderAtlasFile(ctx, logger, evalContext, atlasDirPath, provider)
 	if err != nil {
-		return nil, fmt.Errorf("could not parse atlasfile: %w", err)
+		return nil, fmt.Errorf("could not read Atlasfile: %w", err)
 	}
 
-	_ = conn.Close()
-
-	// Shut down process
-	err = cmd.Process.Signal(syscall.SIGTERM)
+	err = cacheAtlasfile(ctx, logger, evalContext, atlasDirPath, file)
 	if err != nil {
-		return nil, fmt.Errorf("could not shut down atlasfile provider: %w", err)
+		return nil, fmt.Errorf("could not cache Atlasfile: %w", err)
 	}
 
-	go func() {
-		select {
-		case <-ctx.Done():
-		case <-time.After(time.Second * 5):
-			_ = cmd.Process.Signal(syscall.SIGKILL)
-		}
-	}()
-
-	return &atlasfile, nil
-}
+	file.dirpath = atlasDirPath
 
-func readTypeScriptAtlasFile(ctx context.Context, atlasDirPath string) (*Atlasfile, error) {
-	return nil, fmt.Errorf("unsupported")
+	return file, nil
 }
 
-func mergeAtlasFiles(files []Atlasfile) *Atlasfile {
+// MergeAtlasFiles combines multiple Atlasfiles into one, moving artifacts declared inline by services into the top-level artifacts
+func MergeAtlasFiles(files []Atlasfile) *Atlasfile {
 	final := &Atlasfile{
 		Artifacts: make([]ArtifactConfig, 0),
 		Services:  make([]ServiceConfig, 0),
@@ -284,7 +208,7 @@ func mergeAtlasFiles(files []Atlasfile) *Atlasfile {
 			service.dirpath = file.dirpath
 			// Move artifact from service scope into artifacts
 			if service.Artifact != nil && service.Artifact.Artifact != nil {
-				// TODO make sure no artifact with same name exists
+				// Duplicate artifact names are reported by Validate
 				svcArtifact := *service.Artifact.Artifact
 				svcArtifact.dirpath = file.dirpath
 				final.Artifacts = append(final.Artifacts, svcArtifact)
@@ -0,0 +1,89 @@
+package atlasfile
+
+import (
+	"fmt"
+	"github.com/brunoscheufler/atlas/protobuf"
+	"strings"
+)
+
+// EvalOptions are supplied by the user to parameterize evaluation of Atlasfiles
+type EvalOptions struct {
+	Profile string
+	Vars    map[string]string
+}
+
+// EvalContext describes the invocation an Atlasfile is evaluated for and is passed to all Atlasfile providers
+type EvalContext struct {
+	RootDir string            `json:"rootDir"`
+	Stacks  []string          `json:"stacks"`
+	Profile string            `json:"profile"`
+	Vars    map[string]string `json:"vars"`
+	Version string            `json:"version"`
+}
+
+func NewEvalContext(version, rootDir string, stacks []string, options EvalOptions) EvalContext {
+	return EvalContext{
+		RootDir: rootDir,
+		Stacks:  stacks,
+		Profile: options.Profile,
+		Vars:    options.Vars,
+		Version: version,
+	}
+}
+
+// Var returns the value of a variable supplied using --var or fallback if the variable was not set
+func (e EvalContext) Var(key, fallback string) string {
+	if value, ok := e.Vars[key]; ok {
+		return value
+	}
+	return fallback
+}
+
+// HasStack returns true if stackName was requested or no stacks were requested
+func (e EvalContext) HasStack(stackName string) bool {
+	if len(e.Stacks) == 0 {
+		return true
+	}
+
+	for _, stack := range e.Stacks {
+		if stack == stackName {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (e EvalContext) toRequest() *protobuf.EvalRequest {
+	return &protobuf.EvalRequest{
+		RootDir: e.RootDir,
+		Stacks:  e.Stacks,
+		Profile: e.Profile,
+		Vars:    e.Vars,
+		Version: e.Version,
+	}
+}
+
+// EvalContextFromRequest is used by SDKs to retrieve the EvalContext sent by the CLI
+func EvalContextFromRequest(req *protobuf.EvalRequest) EvalContext {
+	return EvalContext{
+		RootDir: req.GetRootDir(),
+		Stacks:  req.GetStacks(),
+		Profile: req.GetProfile(),
+		Vars:    req.GetVars(),
+		Version: req.GetVersion(),
+	}
+}
+
+// ParseVars parses variables in key=value format
+func ParseVars(pairs []string) (map[string]string, error) {
+	vars := make(map[string]string, len(pairs))
+	for _, pair := range pairs {
+		key, value, ok := strings.Cut(pair, "=")
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid variable %q, expected key=value", pair)
+		}
+		vars[key] = value
+	}
+	return vars, nil
+}
@@ -0,0 +1,34 @@
+package atlasfile
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestParseVars(t *testing.T) {
+	vars, err := ParseVars([]string{"db=shared", "url=postgres://host/db?sslmode=disable", "empty="})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"db":    "shared",
+		"url":   "postgres://host/db?sslmode=disable",
+		"empty": "",
+	}, vars)
+
+	_, err = ParseVars([]string{"missing-value"})
+	assert.Error(t, err)
+}
+
+func TestComputeAtlasfileHashIncludesEvalContext(t *testing.T) {
+	dir := t.TempDir()
+
+	local, err := computeAtlasfileHash(dir, EvalContext{Version: "dev", Profile: "local"})
+	assert.NoError(t, err)
+
+	staging, err := computeAtlasfileHash(dir, EvalContext{Version: "dev", Profile: "staging"})
+	assert.NoError(t, err)
+	assert.NotEqual(t, local, staging)
+
+	withVars, err := computeAtlasfileHash(dir, EvalContext{Version: "dev", Profile: "local", Vars: map[string]string{"db": "shared"}})
+	assert.NoError(t, err)
+	assert.NotEqual(t, local, withVars)
+}
@@ -2,7 +2,9 @@ package atlasfile
 
 import (
 	"fmt"
+	"github.com/brunoscheufler/atlas/helper"
 	"path/filepath"
+	"strings"
 )
 
 func (a *Atlasfile) GetService(name string) *ServiceConfig {
@@ -63,6 +65,29 @@ func (s *ServiceConfig) GetDirpath() string {
 	return s.dirpath
 }
 
+// CacheToInline is the only supported cache export, which embeds the build cache in the built image
+const CacheToInline = "type=inline"
+
+// CacheFromImage returns the image to import the build cache from, given either as image reference or as
+// type=registry,ref=<image>. It returns false for other caches, which cannot be imported through the Engine API.
+func CacheFromImage(cache string) (string, bool) {
+	if !strings.Contains(cache, "=") {
+		return cache, cache != ""
+	}
+
+	attrs := make(map[string]string)
+	for _, field := range strings.Split(cache, ",") {
+		key, value, _ := strings.Cut(field, "=")
+		attrs[key] = value
+	}
+
+	if attrs["type"] != "registry" || attrs["ref"] == "" {
+		return "", false
+	}
+
+	return attrs["ref"], true
+}
+
 func BuildImageName(artifact *ArtifactConfig) string {
 	imageName := artifact.Build.ImageName
 	if imageName == "" {
@@ -77,6 +102,11 @@ func BuildImageName(artifact *ArtifactConfig) string {
 	return fmt.Sprintf("%s:%s", imageName, tagName)
 }
 
+// IsPersistent returns true if the volume is kept across atlas up and atlas down
+func (c *VolumeConfig) IsPersistent() bool {
+	return c.IsVolume && c.Lifecycle == VolumeLifecyclePersistent
+}
+
 func (c *VolumeConfig) GetVolumeNameOrHostPath(cwd string, physicalVolName string) string {
 	if c.IsVolume {
 		return physicalVolName
@@ -150,3 +180,18 @@ func (a *Atlasfile) GetServiceImage(service *ServiceConfig) (string, error) {
 
 	return BuildImageName(artifact), nil
 }
+
+// Test returns the test run by Docker in the container to check its health. Commands are executed directly, only
+// the generated HTTP and TCP probes use the shell of the container.
+func (h *Healthcheck) Test() []string {
+	switch {
+	case len(h.Command) > 0:
+		return append([]string{"CMD"}, h.Command...)
+	case h.HTTPGet != "":
+		return []string{"CMD-SHELL", fmt.Sprintf("wget -q -O /dev/null %[1]s || curl -fsS -o /dev/null %[1]s", helper.ShellQuote(h.HTTPGet))}
+	case h.TCPPort != 0:
+		return []string{"CMD-SHELL", fmt.Sprintf("nc -z 127.0.0.1 %[1]d || bash -c 'exec 3<>/dev/tcp/127.0.0.1/%[1]d'", h.TCPPort)}
+	}
+
+	return nil
+}
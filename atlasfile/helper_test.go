@@ -0,0 +1,27 @@
+package atlasfile
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestHealthcheckTest(t *testing.T) {
+	assert.Equal(t, []string{"CMD", "pg_isready", "-U", "postgres"}, (&Healthcheck{Command: []string{"pg_isready", "-U", "postgres"}}).Test())
+
+	// Arguments are passed as they are, without being split or interpreted by a shell
+	assert.Equal(t, []string{"CMD", "sh", "-c", "test -f /tmp/ready && echo ok"}, (&Healthcheck{Command: []string{"sh", "-c", "test -f /tmp/ready && echo ok"}}).Test())
+
+	assert.Equal(t, []string{"CMD-SHELL", "wget -q -O /dev/null 'http://localhost:8080/health' || curl -fsS -o /dev/null 'http://localhost:8080/health'"}, (&Healthcheck{HTTPGet: "http://localhost:8080/health"}).Test())
+	assert.Equal(t, []string{"CMD-SHELL", "wget -q -O /dev/null 'http://localhost:8080/health?a=1&b=2' || curl -fsS -o /dev/null 'http://localhost:8080/health?a=1&b=2'"}, (&Healthcheck{HTTPGet: "http://localhost:8080/health?a=1&b=2"}).Test())
+	assert.Equal(t, []string{"CMD-SHELL", "nc -z 127.0.0.1 6379 || bash -c 'exec 3<>/dev/tcp/127.0.0.1/6379'"}, (&Healthcheck{TCPPort: 6379}).Test())
+}
+
+func TestVolumeIsPersistent(t *testing.T) {
+	// Volumes are ephemeral unless persistence is requested
+	assert.False(t, (&VolumeConfig{IsVolume: true, HostPathOrVolumeName: "data"}).IsPersistent())
+	assert.False(t, (&VolumeConfig{IsVolume: true, HostPathOrVolumeName: "data", Lifecycle: VolumeLifecycleEphemeral}).IsPersistent())
+	assert.True(t, (&VolumeConfig{IsVolume: true, HostPathOrVolumeName: "data", Lifecycle: VolumeLifecyclePersistent}).IsPersistent())
+
+	// Host paths are never managed as volumes
+	assert.False(t, (&VolumeConfig{HostPathOrVolumeName: "./data", Lifecycle: VolumeLifecyclePersistent}).IsPersistent())
+}
@@ -0,0 +1,165 @@
+package atlasfile
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/brunoscheufler/atlas/helper"
+	"github.com/sirupsen/logrus"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// TypeScriptSdkPackage is the npm package name of the TypeScript SDK
+const TypeScriptSdkPackage = "@brunoscheufler/atlas-sdk"
+
+// nodeProviderScript is the package.json script used to launch the provider, if defined
+const nodeProviderScript = "atlasfile"
+
+type NodePackageManager struct {
+	Name string
+
+	// Lockfile is the file name used to detect the package manager
+	Lockfile string
+
+	// Commands are programs followed by their arguments
+	InstallCommand []string
+	RunCommand     []string
+	ExecCommand    []string
+	UpgradeCommand []string
+}
+
+var nodePackageManagers = []NodePackageManager{
+	{
+		Name:           "pnpm",
+		Lockfile:       "pnpm-lock.yaml",
+		InstallCommand: []string{"pnpm", "install"},
+		RunCommand:     []string{"pnpm", "run", "--silent"},
+		ExecCommand:    []string{"pnpm", "exec"},
+		UpgradeCommand: []string{"pnpm", "update", "--latest"},
+	},
+	{
+		Name:           "yarn",
+		Lockfile:       "yarn.lock",
+		InstallCommand: []string{"yarn", "install"},
+		RunCommand:     []string{"yarn", "run", "--silent"},
+		ExecCommand:    []string{"yarn", "run"},
+		UpgradeCommand: []string{"yarn", "upgrade", "--latest"},
+	},
+	{
+		Name:           "npm",
+		Lockfile:       "package-lock.json",
+		InstallCommand: []string{"npm", "install"},
+		RunCommand:     []string{"npm", "run", "--silent"},
+		ExecCommand:    []string{"npx", "--no-install"},
+		UpgradeCommand: []string{"npm", "install", "--save"},
+	},
+}
+
+// yarnBerry replaces yarn for Yarn 2 and later, which removed yarn upgrade and the --silent flag of yarn run
+var yarnBerry = NodePackageManager{
+	Name:           "yarn",
+	Lockfile:       "yarn.lock",
+	InstallCommand: []string{"yarn", "install"},
+	RunCommand:     []string{"yarn", "run"},
+	ExecCommand:    []string{"yarn", "run"},
+	UpgradeCommand: []string{"yarn", "up"},
+}
+
+// isYarnBerry returns true if the project in dir uses Yarn 2 or later, which is configured in .yarnrc.yml and usually
+// pinned in the packageManager field of package.json
+func isYarnBerry(dir string) bool {
+	if helper.FileExists(filepath.Join(dir, ".yarnrc.yml")) {
+		return true
+	}
+
+	fileBytes, err := os.ReadFile(filepath.Join(dir, "package.json"))
+	if err != nil {
+		return false
+	}
+
+	var pkg packageJson
+	if err := json.Unmarshal(fileBytes, &pkg); err != nil {
+		return false
+	}
+
+	return strings.HasPrefix(pkg.PackageManager, "yarn@") && !strings.HasPrefix(pkg.PackageManager, "yarn@1.")
+}
+
+// DetectNodePackageManager searches dir and its parent directories (to support workspaces) for a known lockfile,
+// falling back to npm if none was found.
+func DetectNodePackageManager(dir string) NodePackageManager {
+	for {
+		for _, pm := range nodePackageManagers {
+			if !helper.FileExists(filepath.Join(dir, pm.Lockfile)) {
+				continue
+			}
+
+			if pm.Name == yarnBerry.Name && isYarnBerry(dir) {
+				return yarnBerry
+			}
+
+			return pm
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			break
+		}
+		dir = parent
+	}
+
+	return nodePackageManagers[len(nodePackageManagers)-1]
+}
+
+type packageJson struct {
+	Scripts        map[string]string `json:"scripts"`
+	PackageManager string            `json:"packageManager"`
+}
+
+// nodeProviderCommand returns the command to launch the provider, which is either the atlasfile script
+// or the Atlasfile entrypoint run with ts-node
+func nodeProviderCommand(atlasDirPath string, pm NodePackageManager) ([]string, error) {
+	fileBytes, err := os.ReadFile(filepath.Join(atlasDirPath, "package.json"))
+	if err != nil {
+		return nil, fmt.Errorf("could not read package.json: %w", err)
+	}
+
+	var pkg packageJson
+	err = json.Unmarshal(fileBytes, &pkg)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse package.json: %w", err)
+	}
+
+	if _, ok := pkg.Scripts[nodeProviderScript]; ok {
+		return append(append([]string{}, pm.RunCommand...), nodeProviderScript), nil
+	}
+
+	for _, name := range []string{"Atlasfile.ts", "Atlasfile.root.ts"} {
+		if helper.FileExists(filepath.Join(atlasDirPath, name)) {
+			return append(append([]string{}, pm.ExecCommand...), "ts-node", name), nil
+		}
+	}
+
+	return nil, fmt.Errorf("missing %q script in package.json or Atlasfile.ts", nodeProviderScript)
+}
+
+// nodeProviderConfig installs dependencies using the detected package manager before launching the provider
+func nodeProviderConfig(logger logrus.FieldLogger, atlasDirPath string) (*ProviderConfig, error) {
+	pm := DetectNodePackageManager(atlasDirPath)
+
+	logger.WithFields(logrus.Fields{
+		"atlasDirPath":   atlasDirPath,
+		"packageManager": pm.Name,
+	}).Debugln("detected package manager")
+
+	command, err := nodeProviderCommand(atlasDirPath, pm)
+	if err != nil {
+		return nil, fmt.Errorf("could not determine provider command (%s): %w", atlasDirPath, err)
+	}
+
+	return &ProviderConfig{
+		Setup:   pm.InstallCommand,
+		Command: command,
+	}, nil
+}
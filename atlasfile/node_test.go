@@ -0,0 +1,45 @@
+package atlasfile
+
+import (
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDetectNodePackageManager(t *testing.T) {
+	root := t.TempDir()
+	atlasDir := filepath.Join(root, "services", "web", ".atlas")
+	assert.NoError(t, os.MkdirAll(atlasDir, 0755))
+
+	assert.Equal(t, "npm", DetectNodePackageManager(atlasDir).Name)
+
+	// Workspace lockfiles are found in parent directories
+	assert.NoError(t, os.WriteFile(filepath.Join(root, "pnpm-lock.yaml"), nil, 0644))
+	assert.Equal(t, "pnpm", DetectNodePackageManager(atlasDir).Name)
+
+	assert.NoError(t, os.WriteFile(filepath.Join(atlasDir, "yarn.lock"), nil, 0644))
+	assert.Equal(t, "yarn", DetectNodePackageManager(atlasDir).Name)
+}
+
+func TestDetectYarnBerry(t *testing.T) {
+	root := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(root, "yarn.lock"), nil, 0644))
+
+	assert.Equal(t, []string{"yarn", "upgrade", "--latest"}, DetectNodePackageManager(root).UpgradeCommand)
+
+	// Yarn 1 may be pinned as well
+	assert.NoError(t, os.WriteFile(filepath.Join(root, "package.json"), []byte(`{"packageManager": "yarn@1.22.19"}`), 0644))
+	assert.Equal(t, []string{"yarn", "upgrade", "--latest"}, DetectNodePackageManager(root).UpgradeCommand)
+
+	assert.NoError(t, os.WriteFile(filepath.Join(root, "package.json"), []byte(`{"packageManager": "yarn@4.0.2"}`), 0644))
+	berry := DetectNodePackageManager(root)
+	assert.Equal(t, "yarn", berry.Name)
+	assert.Equal(t, []string{"yarn", "up"}, berry.UpgradeCommand)
+	assert.Equal(t, []string{"yarn", "run"}, berry.RunCommand)
+
+	// Projects without packageManager are detected by their configuration
+	assert.NoError(t, os.Remove(filepath.Join(root, "package.json")))
+	assert.NoError(t, os.WriteFile(filepath.Join(root, ".yarnrc.yml"), nil, 0644))
+	assert.Equal(t, []string{"yarn", "up"}, DetectNodePackageManager(root).UpgradeCommand)
+}
@@ -0,0 +1,303 @@
+package atlasfile
+
+import (
+	"github.com/brunoscheufler/atlas/protobuf"
+)
+
+// ProtocolVersion is the version of the provider protocol implemented by the CLI and SDKs.
+// Version 0 returns the Atlasfile as JSON in EvalReply.output, version 1 adds the typed EvalReply.atlasfile.
+const ProtocolVersion = 1
+
+// MinProtocolVersion is the oldest provider protocol version the CLI still supports
+const MinProtocolVersion = 0
+
+// ToProto converts the Atlasfile into its protobuf representation sent by SDKs
+func (a *Atlasfile) ToProto() *protobuf.AtlasfileSpec {
+	spec := &protobuf.AtlasfileSpec{}
+
+	for i := range a.Artifacts {
+		spec.Artifacts = append(spec.Artifacts, a.Artifacts[i].toProto())
+	}
+
+	for i := range a.Services {
+		spec.Services = append(spec.Services, a.Services[i].toProto())
+	}
+
+	for i := range a.Stacks {
+		spec.Stacks = append(spec.Stacks, a.Stacks[i].toProto())
+	}
+
+	return spec
+}
+
+// AtlasfileFromProto converts the protobuf representation returned by providers into an Atlasfile
+func AtlasfileFromProto(spec *protobuf.AtlasfileSpec) *Atlasfile {
+	file := &Atlasfile{}
+
+	for _, artifact := range spec.GetArtifacts() {
+		file.Artifacts = append(file.Artifacts, *artifactFromProto(artifact))
+	}
+
+	for _, service := range spec.GetServices() {
+		file.Services = append(file.Services, serviceFromProto(service))
+	}
+
+	for _, stack := range spec.GetStacks() {
+		file.Stacks = append(file.Stacks, stackFromProto(stack))
+	}
+
+	return file
+}
+
+func (ac *ArtifactConfig) toProto() *protobuf.ArtifactConfig {
+	artifact := &protobuf.ArtifactConfig{
+		Name: ac.Name,
+		Build: &protobuf.BuildOptions{
+			Dockerfile: ac.Build.Dockerfile,
+			Context:    ac.Build.Context,
+			BuildArgs:  ac.Build.BuildArgs,
+			Target:     ac.Build.Target,
+			ImageName:  ac.Build.ImageName,
+			TagName:    ac.Build.TagName,
+			Ssh:        ac.Build.SSH,
+			CacheFrom:  ac.Build.CacheFrom,
+			CacheTo:    ac.Build.CacheTo,
+			Platform:   ac.Build.Platform,
+			Labels:     ac.Build.Labels,
+		},
+		DependsOn: &protobuf.ArtifactDependsOn{
+			Services:  ac.DependsOn.Services,
+			Artifacts: ac.DependsOn.Artifacts,
+		},
+	}
+
+	for _, secret := range ac.Build.Secrets {
+		artifact.Build.Secrets = append(artifact.Build.Secrets, &protobuf.BuildSecret{
+			Id:   secret.Id,
+			File: secret.File,
+			Env:  secret.Env,
+		})
+	}
+
+	return artifact
+}
+
+func artifactFromProto(artifact *protobuf.ArtifactConfig) *ArtifactConfig {
+	if artifact == nil {
+		return nil
+	}
+
+	build := artifact.GetBuild()
+	dependsOn := artifact.GetDependsOn()
+
+	config := &ArtifactConfig{
+		Name: artifact.GetName(),
+		Build: BuildOptions{
+			Dockerfile: build.GetDockerfile(),
+			Context:    build.GetContext(),
+			BuildArgs:  build.GetBuildArgs(),
+			Target:     build.GetTarget(),
+			ImageName:  build.GetImageName(),
+			TagName:    build.GetTagName(),
+			SSH:        build.GetSsh(),
+			CacheFrom:  build.GetCacheFrom(),
+			CacheTo:    build.GetCacheTo(),
+			Platform:   build.GetPlatform(),
+			Labels:     build.GetLabels(),
+		},
+		DependsOn: ArtifactDependsOn{
+			Services:  dependsOn.GetServices(),
+			Artifacts: dependsOn.GetArtifacts(),
+		},
+	}
+
+	for _, secret := range build.GetSecrets() {
+		config.Build.Secrets = append(config.Build.Secrets, BuildSecret{
+			Id:   secret.GetId(),
+			File: secret.GetFile(),
+			Env:  secret.GetEnv(),
+		})
+	}
+
+	return config
+}
+
+func (s *ServiceConfig) toProto() *protobuf.ServiceConfig {
+	service := &protobuf.ServiceConfig{
+		Name:             s.Name,
+		Image:            s.Image,
+		Entrypoint:       s.Entrypoint,
+		Command:          s.Command,
+		Environment:      s.Environment,
+		EnvironmentFiles: s.EnvironmentFiles,
+		Restart:          string(s.Restart),
+		Interactive:      s.Interactive,
+		Tty:              s.TTY,
+	}
+
+	if s.Artifact != nil {
+		service.Artifact = &protobuf.ArtifactRef{Name: s.Artifact.Name}
+		if s.Artifact.Artifact != nil {
+			service.Artifact.Artifact = s.Artifact.Artifact.toProto()
+		}
+	}
+
+	for _, port := range s.Ports {
+		service.Ports = append(service.Ports, &protobuf.PortRequest{
+			ContainerPort: int32(port.ContainerPort),
+			Protocol:      port.Protocol,
+		})
+	}
+
+	for _, volume := range s.Volumes {
+		service.Volumes = append(service.Volumes, &protobuf.VolumeConfig{
+			IsVolume:      volume.IsVolume,
+			HostPath:      volume.HostPathOrVolumeName,
+			ContainerPath: volume.ContainerPath,
+			Lifecycle:     string(volume.Lifecycle),
+		})
+	}
+
+	service.DependsOn = dependenciesToProto(s.DependsOn)
+
+	if s.Healthcheck != nil {
+		service.Healthcheck = &protobuf.Healthcheck{
+			Command:     s.Healthcheck.Command,
+			HttpGet:     s.Healthcheck.HTTPGet,
+			TcpPort:     int32(s.Healthcheck.TCPPort),
+			Interval:    s.Healthcheck.Interval,
+			Timeout:     s.Healthcheck.Timeout,
+			Retries:     int32(s.Healthcheck.Retries),
+			StartPeriod: s.Healthcheck.StartPeriod,
+		}
+	}
+
+	return service
+}
+
+func serviceFromProto(service *protobuf.ServiceConfig) ServiceConfig {
+	config := ServiceConfig{
+		Name:             service.GetName(),
+		Image:            service.GetImage(),
+		Entrypoint:       service.GetEntrypoint(),
+		Command:          service.GetCommand(),
+		Environment:      service.GetEnvironment(),
+		EnvironmentFiles: service.GetEnvironmentFiles(),
+		Restart:          ContainerRestarts(service.GetRestart()),
+		Interactive:      service.GetInteractive(),
+		TTY:              service.GetTty(),
+	}
+
+	if service.GetArtifact() != nil {
+		config.Artifact = &ArtifactRef{
+			Name:     service.GetArtifact().GetName(),
+			Artifact: artifactFromProto(service.GetArtifact().GetArtifact()),
+		}
+	}
+
+	for _, port := range service.GetPorts() {
+		config.Ports = append(config.Ports, PortRequest{
+			ContainerPort: int(port.GetContainerPort()),
+			Protocol:      port.GetProtocol(),
+		})
+	}
+
+	for _, volume := range service.GetVolumes() {
+		config.Volumes = append(config.Volumes, VolumeConfig{
+			IsVolume:             volume.GetIsVolume(),
+			HostPathOrVolumeName: volume.GetHostPath(),
+			ContainerPath:        volume.GetContainerPath(),
+			Lifecycle:            VolumeLifecycle(volume.GetLifecycle()),
+		})
+	}
+
+	config.DependsOn = dependenciesFromProto(service.GetDependsOn())
+
+	if healthcheck := service.GetHealthcheck(); healthcheck != nil {
+		config.Healthcheck = &Healthcheck{
+			Command:     healthcheck.GetCommand(),
+			HTTPGet:     healthcheck.GetHttpGet(),
+			TCPPort:     int(healthcheck.GetTcpPort()),
+			Interval:    healthcheck.GetInterval(),
+			Timeout:     healthcheck.GetTimeout(),
+			Retries:     int(healthcheck.GetRetries()),
+			StartPeriod: healthcheck.GetStartPeriod(),
+		}
+	}
+
+	return config
+}
+
+func (s *StackConfig) toProto() *protobuf.StackConfig {
+	stack := &protobuf.StackConfig{Name: s.Name}
+
+	for _, stackService := range s.Services {
+		service := &protobuf.StackService{
+			Name:              stackService.Name,
+			ServiceName:       stackService.ServiceName,
+			Environment:       stackService.Environment,
+			JoinStackNetworks: stackService.JoinStackNetworks,
+			LocalEnvironment:  stackService.LocalEnvironment,
+			DependsOn:         dependenciesToProto(stackService.DependsOn),
+		}
+
+		for _, expose := range stackService.ExposePorts {
+			service.ExposePorts = append(service.ExposePorts, &protobuf.PortExpose{
+				HostPort:      int32(expose.HostPort),
+				ContainerPort: int32(expose.ContainerPort),
+			})
+		}
+
+		stack.Services = append(stack.Services, service)
+	}
+
+	return stack
+}
+
+func stackFromProto(stack *protobuf.StackConfig) StackConfig {
+	config := StackConfig{Name: stack.GetName()}
+
+	for _, service := range stack.GetServices() {
+		stackService := StackService{
+			Name:              service.GetName(),
+			ServiceName:       service.GetServiceName(),
+			Environment:       service.GetEnvironment(),
+			JoinStackNetworks: service.GetJoinStackNetworks(),
+			LocalEnvironment:  service.GetLocalEnvironment(),
+			DependsOn:         dependenciesFromProto(service.GetDependsOn()),
+		}
+
+		for _, expose := range service.GetExposePorts() {
+			stackService.ExposePorts = append(stackService.ExposePorts, PortExpose{
+				HostPort:      int(expose.GetHostPort()),
+				ContainerPort: int(expose.GetContainerPort()),
+			})
+		}
+
+		config.Services = append(config.Services, stackService)
+	}
+
+	return config
+}
+
+func dependenciesToProto(dependencies []ServiceDependency) []*protobuf.ServiceDependency {
+	var converted []*protobuf.ServiceDependency
+	for _, dependency := range dependencies {
+		converted = append(converted, &protobuf.ServiceDependency{
+			Service:   dependency.Service,
+			Condition: string(dependency.Condition),
+		})
+	}
+	return converted
+}
+
+func dependenciesFromProto(dependencies []*protobuf.ServiceDependency) []ServiceDependency {
+	var converted []ServiceDependency
+	for _, dependency := range dependencies {
+		converted = append(converted, ServiceDependency{
+			Service:   dependency.GetService(),
+			Condition: ServiceDependencyCondition(dependency.GetCondition()),
+		})
+	}
+	return converted
+}
@@ -0,0 +1,83 @@
+package atlasfile
+
+import (
+	"encoding/json"
+	"github.com/brunoscheufler/atlas/protobuf"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestAtlasfileProtoRoundTrip(t *testing.T) {
+	file := &Atlasfile{
+		Artifacts: []ArtifactConfig{
+			{
+				Name: "base",
+				Build: BuildOptions{
+					Dockerfile: "Dockerfile",
+					BuildArgs:  map[string]string{"GO_VERSION": "1.19"},
+					ImageName:  "base",
+					Secrets: []BuildSecret{
+						{Id: "npmrc", File: ".npmrc"},
+						{Id: "token", Env: "GITHUB_TOKEN"},
+					},
+					SSH:       []string{"default"},
+					CacheFrom: []string{"type=registry,ref=ghcr.io/brunoscheufler/base"},
+					CacheTo:   []string{CacheToInline},
+					Platform:  "linux/amd64",
+					Labels:    map[string]string{"org.opencontainers.image.source": "https://github.com/brunoscheufler/atlas"},
+				},
+			},
+		},
+		Services: []ServiceConfig{
+			{
+				Name: "api",
+				Artifact: &ArtifactRef{
+					Artifact: &ArtifactConfig{
+						Name:      "api",
+						DependsOn: ArtifactDependsOn{Artifacts: []string{"base"}},
+					},
+				},
+				Command:     []string{"--server"},
+				Ports:       []PortRequest{{ContainerPort: 8080, Protocol: "tcp"}},
+				Environment: map[string]string{"LOG_LEVEL": "debug"},
+				Volumes:     []VolumeConfig{{IsVolume: true, HostPathOrVolumeName: "data", ContainerPath: "/data", Lifecycle: VolumeLifecycleEphemeral}},
+				Restart:     ContainerRestartsOnFailure,
+				DependsOn:   []ServiceDependency{{Service: "db", Condition: ServiceDependencyHealthy}},
+				Healthcheck: &Healthcheck{HTTPGet: "http://localhost:8080/health", Interval: "5s", Retries: 3, StartPeriod: "10s"},
+			},
+		},
+		Stacks: []StackConfig{
+			{
+				Name: "local",
+				Services: []StackService{
+					{
+						Name:              "api",
+						JoinStackNetworks: []string{"shared"},
+						ExposePorts:       []PortExpose{{HostPort: 8080, ContainerPort: 8080}},
+						LocalEnvironment:  map[string]string{"API_URL": "http://localhost:8080"},
+						DependsOn:         []ServiceDependency{{Service: "migrate", Condition: ServiceDependencyCompleted}},
+					},
+				},
+			},
+		},
+	}
+
+	assert.Equal(t, file, AtlasfileFromProto(file.ToProto()))
+}
+
+func TestDecodeEvalReply(t *testing.T) {
+	file := &Atlasfile{Services: []ServiceConfig{{Name: "db", Image: "postgres:14"}}}
+
+	marshaled, err := json.Marshal(file)
+	assert.NoError(t, err)
+
+	// Protocol version 0 only sends JSON output
+	decoded, err := decodeEvalReply(&protobuf.EvalReply{Output: string(marshaled)}, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, file, decoded)
+
+	// Protocol version 1 prefers the typed Atlasfile
+	decoded, err = decodeEvalReply(&protobuf.EvalReply{Output: "{}", Atlasfile: file.ToProto()}, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, file, decoded)
+}
@@ -0,0 +1,236 @@
+package atlasfile
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/brunoscheufler/atlas/exec"
+	"github.com/brunoscheufler/atlas/helper"
+	"github.com/brunoscheufler/atlas/protobuf"
+	"github.com/cenkalti/backoff/v4"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"os"
+	osexec "os/exec"
+	"path/filepath"
+	"syscall"
+	"time"
+)
+
+// ProviderConfigFileName is the file in a .atlas directory declaring a custom Atlasfile provider
+const ProviderConfigFileName = "provider.json"
+
+// ProviderConfig describes how to launch an Atlasfile provider, which is any process serving the
+// Atlasfile gRPC service defined in sdk.proto on the port passed in the PORT environment variable.
+type ProviderConfig struct {
+	// Setup is run to completion before launching the provider (e.g. to install dependencies or compile). Like
+	// Command, it is a program followed by its arguments, which are passed without a shell.
+	Setup []string `json:"setup"`
+
+	// Command launches the provider
+	Command []string `json:"command"`
+
+	// Env is passed to both Setup and Command in addition to the current environment
+	Env map[string]string `json:"env"`
+}
+
+func (p *ProviderConfig) envList() []string {
+	env := make([]string, 0, len(p.Env))
+	for k, v := range p.Env {
+		env = append(env, fmt.Sprintf("%s=%s", k, v))
+	}
+	return env
+}
+
+// resolveProvider returns the provider declared in provider.json or the built-in provider
+// for Go and TypeScript Atlasfiles, or nil if no provider could be found.
+func resolveProvider(logger logrus.FieldLogger, atlasDirPath string) (*ProviderConfig, error) {
+	if helper.FileExists(filepath.Join(atlasDirPath, ProviderConfigFileName)) {
+		return readProviderConfig(filepath.Join(atlasDirPath, ProviderConfigFileName))
+	}
+
+	// Check if go.mod exists
+	if helper.FileExists(filepath.Join(atlasDirPath, "go.mod")) {
+		return &ProviderConfig{
+			// Check if building the file works
+			Setup:   []string{"go", "build", "-o", os.DevNull, "."},
+			Command: []string{"go", "run", "."},
+		}, nil
+	}
+
+	// Check if package.json exists
+	if helper.FileExists(filepath.Join(atlasDirPath, "package.json")) {
+		return nodeProviderConfig(logger, atlasDirPath)
+	}
+
+	return nil, nil
+}
+
+func readProviderConfig(path string) (*ProviderConfig, error) {
+	fileBytes, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not read %s: %w", path, err)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(fileBytes))
+	decoder.DisallowUnknownFields()
+
+	var config ProviderConfig
+	err = decoder.Decode(&config)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse %s: %w", path, err)
+	}
+
+	if len(config.Command) == 0 {
+		return nil, fmt.Errorf("missing command in %s", path)
+	}
+
+	return &config, nil
+}
+
+// readProviderAtlasFile runs the provider setup, if any, and evaluates the Atlasfile served by the provider
+func readProviderAtlasFile(ctx context.Context, logger logrus.FieldLogger, evalContext EvalContext, atlasDirPath string, provider *ProviderConfig) (*Atlasfile, error) {
+	if len(provider.Setup) > 0 {
+		err := exec.RunProgram(ctx, logger, provider.Setup[0], provider.Setup[1:], exec.RunCommandOptions{Cwd: atlasDirPath, Env: provider.envList(), LogVisible: true, LogPrefix: atlasDirPath})
+		if err != nil {
+			return nil, fmt.Errorf("could not set up atlasfile provider (%s): %w", atlasDirPath, err)
+		}
+	}
+
+	return evalProvider(ctx, logger, evalContext, atlasDirPath, provider)
+}
+
+// evalProvider launches an Atlasfile provider in atlasDirPath, waits until it responds to pings,
+// and evaluates the Atlasfile it serves for evalContext. The provider is shut down afterwards.
+func evalProvider(ctx context.Context, logger logrus.FieldLogger, evalContext EvalContext, atlasDirPath string, provider *ProviderConfig) (*Atlasfile, error) {
+	port, err := helper.FreePort()
+	if err != nil {
+		return nil, fmt.Errorf("could not find free port: %w", err)
+	}
+
+	// Start process in background in the directory
+	env := append(provider.envList(), fmt.Sprintf("PORT=%d", port))
+	cmd, err := exec.StartProgram(ctx, logger, provider.Command[0], provider.Command[1:], atlasDirPath, env)
+	if err != nil {
+		return nil, fmt.Errorf("could not start atlasfile provider: %w", err)
+	}
+
+	// The provider is only needed for evaluating the Atlasfile, so it is shut down on every return
+	defer stopProvider(logger, cmd)
+
+	backOff := &backoff.ExponentialBackOff{
+		InitialInterval:     time.Millisecond * 10,
+		MaxInterval:         time.Second * 3,
+		RandomizationFactor: backoff.DefaultRandomizationFactor,
+		Multiplier:          5,
+		MaxElapsedTime:      0,
+		Stop:                backoff.Stop,
+		Clock:               backoff.SystemClock,
+	}
+	backOff.Reset()
+
+	var client protobuf.AtlasfileClient
+	var conn *grpc.ClientConn
+	var pingReply *protobuf.PingReply
+
+	// Wait until started up
+	attempts := 0
+	for {
+		select {
+		// Either the ctx is canceled
+		case <-ctx.Done():
+			return nil, fmt.Errorf("could not connect to atlasfile, context canceled")
+
+			// Or the backOff elapses
+		case <-time.After(backOff.NextBackOff()):
+			// after which we might have to return as we reached the max num of attempts
+			if attempts > 10 {
+				return nil, fmt.Errorf("could not connect to atlasfile: %w", err)
+			}
+
+			// Connect to gRPC endpoint
+			conn, err = grpc.DialContext(ctx, fmt.Sprintf("localhost:%d", port), grpc.WithTransportCredentials(insecure.NewCredentials()))
+			if err != nil {
+				attempts++
+				continue
+			}
+
+			client = protobuf.NewAtlasfileClient(conn)
+
+			// try pinging the endpoint
+			pingReply, err = client.Ping(ctx, &protobuf.PingRequest{})
+			if err != nil {
+				_ = conn.Close()
+				attempts++
+				continue
+			}
+		}
+
+		break
+	}
+
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	protocolVersion := pingReply.GetProtocolVersion()
+	if protocolVersion > ProtocolVersion {
+		return nil, fmt.Errorf("atlasfile provider uses protocol version %d, but this version of Atlas only supports up to %d, please upgrade Atlas", protocolVersion, ProtocolVersion)
+	}
+
+	if protocolVersion < MinProtocolVersion {
+		return nil, fmt.Errorf("atlasfile provider uses protocol version %d, but this version of Atlas requires at least %d, please run atlas update", protocolVersion, MinProtocolVersion)
+	}
+
+	// Send request to get atlasfile
+	res, err := client.Eval(ctx, evalContext.toRequest())
+	if err != nil {
+		return nil, fmt.Errorf("could not eval atlasfile: %w", err)
+	}
+
+	atlasfile, err := decodeEvalReply(res, protocolVersion)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse atlasfile: %w", err)
+	}
+
+	return atlasfile, nil
+}
+
+// stopProvider asks the provider process to shut down and kills it if it is still running after five seconds
+func stopProvider(logger logrus.FieldLogger, cmd *osexec.Cmd) {
+	err := cmd.Process.Signal(syscall.SIGTERM)
+	if err != nil {
+		logger.WithError(err).Warnln("Could not shut down atlasfile provider")
+	}
+
+	exited := make(chan struct{})
+	go func() {
+		_ = cmd.Wait()
+		close(exited)
+	}()
+
+	go func() {
+		select {
+		case <-exited:
+		case <-time.After(time.Second * 5):
+			_ = cmd.Process.Signal(syscall.SIGKILL)
+		}
+	}()
+}
+
+// decodeEvalReply reads the typed Atlasfile, falling back to the JSON output sent by SDKs implementing protocol version 0
+func decodeEvalReply(res *protobuf.EvalReply, protocolVersion int32) (*Atlasfile, error) {
+	if protocolVersion >= 1 && res.GetAtlasfile() != nil {
+		return AtlasfileFromProto(res.GetAtlasfile()), nil
+	}
+
+	var atlasfile Atlasfile
+	err := json.Unmarshal([]byte(res.GetOutput()), &atlasfile)
+	if err != nil {
+		return nil, err
+	}
+
+	return &atlasfile, nil
+}
@@ -0,0 +1,38 @@
+package atlasfile
+
+import (
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestResolveProvider(t *testing.T) {
+	atlasDir := t.TempDir()
+
+	provider, err := resolveProvider(nil, atlasDir)
+	assert.NoError(t, err)
+	assert.Nil(t, provider)
+
+	assert.NoError(t, os.WriteFile(filepath.Join(atlasDir, "go.mod"), []byte("module atlasfile\n"), 0644))
+
+	provider, err = resolveProvider(nil, atlasDir)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"go", "run", "."}, provider.Command)
+
+	// provider.json takes precedence over built-in providers
+	assert.NoError(t, os.WriteFile(filepath.Join(atlasDir, ProviderConfigFileName), []byte(`{"setup": ["pip", "install", "-r", "requirements.txt"], "command": ["python3", "atlasfile.py"], "env": {"PYTHONUNBUFFERED": "1"}}`), 0644))
+
+	provider, err = resolveProvider(nil, atlasDir)
+	assert.NoError(t, err)
+	assert.Equal(t, &ProviderConfig{
+		Setup:   []string{"pip", "install", "-r", "requirements.txt"},
+		Command: []string{"python3", "atlasfile.py"},
+		Env:     map[string]string{"PYTHONUNBUFFERED": "1"},
+	}, provider)
+
+	assert.NoError(t, os.WriteFile(filepath.Join(atlasDir, ProviderConfigFileName), []byte(`{"cmd": ["python3", "atlasfile.py"]}`), 0644))
+
+	_, err = resolveProvider(nil, atlasDir)
+	assert.Error(t, err)
+}
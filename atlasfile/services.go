@@ -0,0 +1,74 @@
+package atlasfile
+
+import (
+	"fmt"
+	"github.com/brunoscheufler/atlas/graph"
+	"strings"
+)
+
+// GetServiceDependencies returns the dependencies of a service in a stack, combining ServiceConfig.DependsOn
+// with StackService.DependsOn. Missing conditions default to ServiceDependencyStarted.
+func (a *Atlasfile) GetServiceDependencies(stackService *StackService) []ServiceDependency {
+	var dependencies []ServiceDependency
+
+	add := func(dependency ServiceDependency) {
+		if dependency.Condition == "" {
+			dependency.Condition = ServiceDependencyStarted
+		}
+
+		for i := range dependencies {
+			if dependencies[i].Service == dependency.Service {
+				dependencies[i].Condition = dependency.Condition
+				return
+			}
+		}
+
+		dependencies = append(dependencies, dependency)
+	}
+
+	if service := a.GetService(stackService.Name); service != nil {
+		for _, dependency := range service.DependsOn {
+			add(dependency)
+		}
+	}
+
+	for _, dependency := range stackService.DependsOn {
+		add(dependency)
+	}
+
+	return dependencies
+}
+
+// BuildServiceGraph builds graph of services in stack with edges pointing from dependencies to their dependents
+func (a *Atlasfile) BuildServiceGraph(stack *StackConfig) (*graph.Graph[string], error) {
+	serviceGraph := graph.New[string]()
+
+	for _, stackService := range stack.Services {
+		serviceGraph.AddNode(stackService.Name)
+	}
+
+	for i := range stack.Services {
+		stackService := &stack.Services[i]
+
+		for _, dependency := range a.GetServiceDependencies(stackService) {
+			if !serviceGraph.HasNode(dependency.Service) {
+				return nil, fmt.Errorf("service %s depends on %s, which is not part of stack %s", stackService.Name, dependency.Service, stack.Name)
+			}
+
+			if !serviceGraph.HasEdge(dependency.Service, stackService.Name) {
+				serviceGraph.AddEdge(dependency.Service, stackService.Name)
+			}
+		}
+	}
+
+	if cycle := serviceGraph.FindCycle(); cycle != nil {
+		// Edges point to dependents, reverse the cycle to print it as a chain of dependencies
+		for i, j := 0, len(cycle)-1; i < j; i, j = i+1, j-1 {
+			cycle[i], cycle[j] = cycle[j], cycle[i]
+		}
+
+		return nil, fmt.Errorf("services in stack %s depend on each other: %s", stack.Name, strings.Join(cycle, " -> "))
+	}
+
+	return serviceGraph, nil
+}
@@ -0,0 +1,47 @@
+package atlasfile
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestBuildServiceGraph(t *testing.T) {
+	file := &Atlasfile{
+		Services: []ServiceConfig{
+			{Name: "db", Image: "postgres:14"},
+			{Name: "migrate", Image: "migrate", DependsOn: []ServiceDependency{{Service: "db", Condition: ServiceDependencyHealthy}}},
+			{Name: "api", Image: "api", DependsOn: []ServiceDependency{{Service: "db"}}},
+			{Name: "frontend", Image: "frontend"},
+		},
+	}
+
+	stack := &StackConfig{
+		Name: "local",
+		Services: []StackService{
+			{Name: "frontend", DependsOn: []ServiceDependency{{Service: "api"}}},
+			{Name: "api", DependsOn: []ServiceDependency{{Service: "db", Condition: ServiceDependencyHealthy}, {Service: "migrate", Condition: ServiceDependencyCompleted}}},
+			{Name: "migrate"},
+			{Name: "db"},
+		},
+	}
+
+	assert.Equal(t, []ServiceDependency{
+		{Service: "db", Condition: ServiceDependencyHealthy},
+		{Service: "migrate", Condition: ServiceDependencyCompleted},
+	}, file.GetServiceDependencies(&stack.Services[1]))
+
+	serviceGraph, err := file.BuildServiceGraph(stack)
+	assert.NoError(t, err)
+
+	layers, err := serviceGraph.TopologicalSortWithLayers()
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"db"}, {"migrate"}, {"api"}, {"frontend"}}, layers)
+
+	stack.Services[3].DependsOn = []ServiceDependency{{Service: "frontend"}}
+
+	_, err = file.BuildServiceGraph(stack)
+	assert.EqualError(t, err, "services in stack local depend on each other: frontend -> api -> db -> frontend")
+
+	_, err = file.BuildServiceGraph(&StackConfig{Name: "partial", Services: []StackService{{Name: "api"}}})
+	assert.EqualError(t, err, "service api depends on db, which is not part of stack partial")
+}
@@ -1,34 +1,77 @@
 package atlasfile
 
 type BuildOptions struct {
-	Dockerfile string            `json:"dockerfile"`
-	Context    string            `json:"context"`
-	BuildArgs  map[string]string `json:"build_args"`
-	Target     string            `json:"target"`
+	Dockerfile string            `json:"dockerfile" yaml:"dockerfile" toml:"dockerfile"`
+	Context    string            `json:"context" yaml:"context" toml:"context"`
+	BuildArgs  map[string]string `json:"build_args" yaml:"build_args" toml:"build_args"`
+	Target     string            `json:"target" yaml:"target" toml:"target"`
 
-	ImageName string `json:"imageName"`
-	TagName   string `json:"tagName"`
+	// Secrets are mounted into RUN --mount=type=secret instructions without being stored in the image. Use them
+	// instead of build args for credentials, build args end up in the image history.
+	Secrets []BuildSecret `json:"secrets" yaml:"secrets" toml:"secrets"`
+
+	// SSH forwards SSH agent sockets or keys to RUN --mount=type=ssh instructions, e.g. "default" for the local agent
+	SSH []string `json:"ssh" yaml:"ssh" toml:"ssh"`
+
+	// CacheFrom lists images to import the build cache from, either as image reference or as type=registry,ref=<image>
+	CacheFrom []string `json:"cache_from" yaml:"cache_from" toml:"cache_from"`
+
+	// CacheTo exports the build cache. Only type=inline is supported, which embeds the cache in the built image so
+	// that it can be pushed and imported with CacheFrom.
+	CacheTo []string `json:"cache_to" yaml:"cache_to" toml:"cache_to"`
+
+	// Platform is the target platform of the image, e.g. linux/amd64
+	Platform string `json:"platform" yaml:"platform" toml:"platform"`
+
+	// Labels are set on the built image
+	Labels map[string]string `json:"labels" yaml:"labels" toml:"labels"`
+
+	ImageName string `json:"imageName" yaml:"imageName" toml:"imageName"`
+	TagName   string `json:"tagName" yaml:"tagName" toml:"tagName"`
+}
+
+// BuildSecret exposes the contents of a local file or environment variable as build secret. Exactly one of File or
+// Env must be set.
+type BuildSecret struct {
+	Id string `json:"id" yaml:"id" toml:"id"`
+
+	// File is relative to the Atlasfile
+	File string `json:"file" yaml:"file" toml:"file"`
+	Env  string `json:"env" yaml:"env" toml:"env"`
 }
 
 type ArtifactRef struct {
-	Name     string          `json:"name"`
-	Artifact *ArtifactConfig `json:"artifact"`
+	Name     string          `json:"name" yaml:"name" toml:"name"`
+	Artifact *ArtifactConfig `json:"artifact" yaml:"artifact" toml:"artifact"`
 }
 
+type VolumeLifecycle string
+
+const (
+	// VolumeLifecyclePersistent volumes are kept across atlas up and atlas down and only removed by atlas down --volumes or atlas volume rm
+	VolumeLifecyclePersistent VolumeLifecycle = "persistent"
+
+	// VolumeLifecycleEphemeral volumes are removed when their stack is removed
+	VolumeLifecycleEphemeral VolumeLifecycle = "ephemeral"
+)
+
 type VolumeConfig struct {
-	IsVolume             bool   `json:"isVolume"`
-	HostPathOrVolumeName string `json:"hostPath"`
-	ContainerPath        string `json:"containerPath"`
+	IsVolume             bool   `json:"isVolume" yaml:"isVolume" toml:"isVolume"`
+	HostPathOrVolumeName string `json:"hostPath" yaml:"hostPath" toml:"hostPath"`
+	ContainerPath        string `json:"containerPath" yaml:"containerPath" toml:"containerPath"`
+
+	// Lifecycle of volumes defaults to VolumeLifecycleEphemeral, it does not apply to host paths
+	Lifecycle VolumeLifecycle `json:"lifecycle" yaml:"lifecycle" toml:"lifecycle"`
 }
 
 type PortRequest struct {
-	ContainerPort int    `json:"containerPort"`
-	Protocol      string `json:"protocol"`
+	ContainerPort int    `json:"containerPort" yaml:"containerPort" toml:"containerPort"`
+	Protocol      string `json:"protocol" yaml:"protocol" toml:"protocol"`
 }
 
 type PortExpose struct {
-	HostPort      int
-	ContainerPort int
+	HostPort      int `json:"hostPort" yaml:"hostPort" toml:"hostPort"`
+	ContainerPort int `json:"containerPort" yaml:"containerPort" toml:"containerPort"`
 }
 
 type ContainerRestarts string
@@ -40,69 +83,115 @@ const (
 	ContainerRestartsNo            = "no"
 )
 
+type ServiceDependencyCondition string
+
+const (
+	// ServiceDependencyStarted waits until the dependency container is running
+	ServiceDependencyStarted ServiceDependencyCondition = "started"
+
+	// ServiceDependencyHealthy waits until the dependency container reports a healthy status
+	ServiceDependencyHealthy ServiceDependencyCondition = "healthy"
+
+	// ServiceDependencyCompleted waits until the dependency container exited successfully
+	ServiceDependencyCompleted ServiceDependencyCondition = "completed"
+)
+
+type ServiceDependency struct {
+	Service string `json:"service" yaml:"service" toml:"service"`
+
+	// Condition defaults to ServiceDependencyStarted
+	Condition ServiceDependencyCondition `json:"condition" yaml:"condition" toml:"condition"`
+}
+
+// Healthcheck configures how Docker determines whether a service container is healthy. Exactly one
+// of Command, HTTPGet, or TCPPort must be set. Durations use Go duration syntax (e.g. 5s).
+type Healthcheck struct {
+	// Command is executed in the container without a shell and must exit with code 0 if the container is healthy
+	Command []string `json:"command" yaml:"command" toml:"command"`
+
+	// HTTPGet is a URL requested from inside the container using wget or curl (e.g. http://localhost:8080/health)
+	HTTPGet string `json:"http_get" yaml:"http_get" toml:"http_get"`
+
+	// TCPPort is a port inside the container that must accept connections
+	TCPPort int `json:"tcp_port" yaml:"tcp_port" toml:"tcp_port"`
+
+	Interval    string `json:"interval" yaml:"interval" toml:"interval"`
+	Timeout     string `json:"timeout" yaml:"timeout" toml:"timeout"`
+	Retries     int    `json:"retries" yaml:"retries" toml:"retries"`
+	StartPeriod string `json:"start_period" yaml:"start_period" toml:"start_period"`
+}
+
 type ServiceConfig struct {
 	dirpath string
 
-	Name string `json:"name"`
+	Name string `json:"name" yaml:"name" toml:"name"`
+
+	Artifact *ArtifactRef `json:"artifact" yaml:"artifact" toml:"artifact"`
+	Image    string       `json:"image" yaml:"image" toml:"image"`
 
-	Artifact *ArtifactRef `json:"artifact"`
-	Image    string       `json:"image"`
+	Entrypoint []string `json:"entrypoint" yaml:"entrypoint" toml:"entrypoint"`
+	Command    []string `json:"command" yaml:"command" toml:"command"`
 
-	Entrypoint []string `json:"entrypoint"`
-	Command    []string `json:"command"`
+	Ports []PortRequest `json:"port_requests" yaml:"port_requests" toml:"port_requests"`
 
-	Ports []PortRequest `json:"port_requests"`
+	Environment      map[string]string `json:"environment" yaml:"environment" toml:"environment"`
+	EnvironmentFiles []string          `json:"environment_files" yaml:"environment_files" toml:"environment_files"`
 
-	Environment      map[string]string `json:"environment"`
-	EnvironmentFiles []string          `json:"environment_files"`
+	Volumes []VolumeConfig `json:"volumes" yaml:"volumes" toml:"volumes"`
 
-	Volumes []VolumeConfig `json:"volumes"`
+	Restart ContainerRestarts `json:"restart" yaml:"restart" toml:"restart"`
 
-	Restart ContainerRestarts `json:"restart"`
+	Interactive bool `json:"interactive" yaml:"interactive" toml:"interactive"`
+	TTY         bool `json:"tty" yaml:"tty" toml:"tty"`
 
-	Interactive bool `json:"interactive"`
-	TTY         bool `json:"tty"`
+	// DependsOn lists services that must meet their condition before this service is started in any stack
+	DependsOn []ServiceDependency `json:"depends_on" yaml:"depends_on" toml:"depends_on"`
+
+	Healthcheck *Healthcheck `json:"healthcheck" yaml:"healthcheck" toml:"healthcheck"`
 }
 
 type StackService struct {
-	Name        string `json:"name"`
-	ServiceName string `json:"serviceName"`
+	Name        string `json:"name" yaml:"name" toml:"name"`
+	ServiceName string `json:"serviceName" yaml:"serviceName" toml:"serviceName"`
 
 	// Environment overwrites environment variables specified in ServiceConfig.Environment and ServiceConfig.EnvironmentFiles
-	Environment map[string]string `json:"environment"`
+	Environment map[string]string `json:"environment" yaml:"environment" toml:"environment"`
 
-	JoinStackNetworks []string     `json:"joinStackNetworks"`
-	ExposePorts       []PortExpose `json:"exposePorts"`
+	JoinStackNetworks []string     `json:"joinStackNetworks" yaml:"joinStackNetworks" toml:"joinStackNetworks"`
+	ExposePorts       []PortExpose `json:"exposePorts" yaml:"exposePorts" toml:"exposePorts"`
 
 	// LocalEnvironment specifies variables that overwrite Environment, ServiceConfig.Environment and ServiceConfig.EnvironmentFiles
 	// when running atlas env (usually URLs that should be rewritten to localhost when running a service outside of Docker)
-	LocalEnvironment map[string]string `json:"localEnvironment"`
+	LocalEnvironment map[string]string `json:"localEnvironment" yaml:"localEnvironment" toml:"localEnvironment"`
+
+	// DependsOn adds to ServiceConfig.DependsOn for this stack, overriding the condition of dependencies declared in both
+	DependsOn []ServiceDependency `json:"dependsOn" yaml:"dependsOn" toml:"dependsOn"`
 }
 
 type StackConfig struct {
 	dirpath        string
 	containerNames map[string]string
 
-	Name     string         `json:"name"`
-	Services []StackService `json:"services"`
+	Name     string         `json:"name" yaml:"name" toml:"name"`
+	Services []StackService `json:"services" yaml:"services" toml:"services"`
 }
 
 type ArtifactDependsOn struct {
-	Services  []string `json:"services"`
-	Artifacts []string `json:"artifacts"`
+	Services  []string `json:"services" yaml:"services" toml:"services"`
+	Artifacts []string `json:"artifacts" yaml:"artifacts" toml:"artifacts"`
 }
 
 type ArtifactConfig struct {
 	dirpath string
-	Name    string `json:"name"`
+	Name    string `json:"name" yaml:"name" toml:"name"`
 
-	Build     BuildOptions      `json:"build"`
-	DependsOn ArtifactDependsOn `json:"depends_on"`
+	Build     BuildOptions      `json:"build" yaml:"build" toml:"build"`
+	DependsOn ArtifactDependsOn `json:"depends_on" yaml:"depends_on" toml:"depends_on"`
 }
 
 type Atlasfile struct {
 	dirpath   string
-	Artifacts []ArtifactConfig `json:"artifacts"`
-	Services  []ServiceConfig  `json:"services"`
-	Stacks    []StackConfig    `json:"stacks"`
+	Artifacts []ArtifactConfig `json:"artifacts" yaml:"artifacts" toml:"artifacts"`
+	Services  []ServiceConfig  `json:"services" yaml:"services" toml:"services"`
+	Stacks    []StackConfig    `json:"stacks" yaml:"stacks" toml:"stacks"`
 }
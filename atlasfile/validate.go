@@ -0,0 +1,227 @@
+package atlasfile
+
+import (
+	"fmt"
+	"path/filepath"
+	"time"
+)
+
+// Validate checks the merged Atlasfile for conflicting declarations and dangling references and reports
+// every problem found, annotated with the .atlas directory (relative to rootDir) it was declared in.
+func (a *Atlasfile) Validate(rootDir string) Diagnostics {
+	var diagnostics Diagnostics
+
+	relDir := func(dirpath string) string {
+		if relpath, err := filepath.Rel(rootDir, dirpath); err == nil && dirpath != "" {
+			return relpath
+		}
+		return dirpath
+	}
+
+	report := func(dirpath string, format string, args ...any) {
+		diagnostics = append(diagnostics, Diagnostic{File: relDir(dirpath), Message: fmt.Sprintf(format, args...)})
+	}
+
+	artifacts := make(map[string]*ArtifactConfig, len(a.Artifacts))
+	for i, artifact := range a.Artifacts {
+		if artifact.Name == "" {
+			report(artifact.dirpath, "artifact is missing a name")
+			continue
+		}
+
+		if existing, ok := artifacts[artifact.Name]; ok {
+			report(artifact.dirpath, "duplicate artifact %q, already declared in %s", artifact.Name, relDir(existing.dirpath))
+			continue
+		}
+
+		artifacts[artifact.Name] = &a.Artifacts[i]
+	}
+
+	services := make(map[string]*ServiceConfig, len(a.Services))
+	for i, service := range a.Services {
+		if service.Name == "" {
+			report(service.dirpath, "service is missing a name")
+			continue
+		}
+
+		if existing, ok := services[service.Name]; ok {
+			report(service.dirpath, "duplicate service %q, already declared in %s", service.Name, relDir(existing.dirpath))
+			continue
+		}
+
+		services[service.Name] = &a.Services[i]
+	}
+
+	stacks := make(map[string]*StackConfig, len(a.Stacks))
+	for i, stack := range a.Stacks {
+		if stack.Name == "" {
+			report(stack.dirpath, "stack is missing a name")
+			continue
+		}
+
+		if existing, ok := stacks[stack.Name]; ok {
+			report(stack.dirpath, "duplicate stack %q, already declared in %s", stack.Name, relDir(existing.dirpath))
+			continue
+		}
+
+		stacks[stack.Name] = &a.Stacks[i]
+	}
+
+	validateDependency := func(dirpath, dependent string, dependency ServiceDependency) {
+		switch dependency.Condition {
+		case "", ServiceDependencyStarted, ServiceDependencyHealthy:
+		case ServiceDependencyCompleted:
+			// Containers that are restarted after exiting never complete
+			if service, ok := services[dependency.Service]; ok && service.Restart != ContainerRestartsNo && service.Restart != ContainerRestartsOnFailure {
+				report(dirpath, "%s waits for service %q to complete, which requires its restart policy to be %q or %q", dependent, dependency.Service, ContainerRestartsNo, ContainerRestartsOnFailure)
+			}
+		default:
+			report(dirpath, "%s has invalid dependency condition %q for service %q", dependent, dependency.Condition, dependency.Service)
+		}
+
+		if _, ok := services[dependency.Service]; !ok {
+			report(dirpath, "%s depends on unknown service %q", dependent, dependency.Service)
+		}
+	}
+
+	for _, artifact := range a.Artifacts {
+		for _, dependency := range artifact.DependsOn.Artifacts {
+			if _, ok := artifacts[dependency]; !ok {
+				report(artifact.dirpath, "artifact %q depends on unknown artifact %q", artifact.Name, dependency)
+			}
+		}
+
+		for _, dependency := range artifact.DependsOn.Services {
+			if _, ok := services[dependency]; !ok {
+				report(artifact.dirpath, "artifact %q depends on unknown service %q", artifact.Name, dependency)
+			}
+		}
+
+		secrets := make(map[string]struct{})
+		for _, secret := range artifact.Build.Secrets {
+			if secret.Id == "" {
+				report(artifact.dirpath, "artifact %q has build secret without id", artifact.Name)
+				continue
+			}
+
+			if _, ok := secrets[secret.Id]; ok {
+				report(artifact.dirpath, "artifact %q has duplicate build secret %q", artifact.Name, secret.Id)
+			}
+			secrets[secret.Id] = struct{}{}
+
+			if (secret.File == "") == (secret.Env == "") {
+				report(artifact.dirpath, "build secret %q of artifact %q must set exactly one of file or env", secret.Id, artifact.Name)
+			}
+		}
+
+		for _, cache := range artifact.Build.CacheFrom {
+			if _, ok := CacheFromImage(cache); !ok {
+				report(artifact.dirpath, "artifact %q has unsupported cache_from %q, only registry images are supported", artifact.Name, cache)
+			}
+		}
+
+		for _, cache := range artifact.Build.CacheTo {
+			if cache != CacheToInline {
+				report(artifact.dirpath, "artifact %q has unsupported cache_to %q, only %s is supported", artifact.Name, cache, CacheToInline)
+			}
+		}
+	}
+
+	for _, service := range a.Services {
+		hasArtifact := service.Artifact != nil && (service.Artifact.Name != "" || service.Artifact.Artifact != nil)
+
+		switch {
+		case service.Image == "" && !hasArtifact:
+			report(service.dirpath, "service %q has neither image nor artifact", service.Name)
+		case service.Image != "" && hasArtifact:
+			report(service.dirpath, "service %q has both image and artifact", service.Name)
+		case hasArtifact && service.Artifact.Name != "":
+			if _, ok := artifacts[service.Artifact.Name]; !ok {
+				report(service.dirpath, "service %q references unknown artifact %q", service.Name, service.Artifact.Name)
+			}
+		}
+
+		switch service.Restart {
+		case "", ContainerRestartsAlways, ContainerRestartsOnFailure, ContainerRestartsUnlessStopped, ContainerRestartsNo:
+		default:
+			report(service.dirpath, "service %q has invalid restart policy %q", service.Name, service.Restart)
+		}
+
+		for _, volume := range service.Volumes {
+			switch {
+			case volume.Lifecycle == "":
+			case !volume.IsVolume:
+				report(service.dirpath, "service %q sets lifecycle of host path %q, which only applies to volumes", service.Name, volume.HostPathOrVolumeName)
+			case volume.Lifecycle != VolumeLifecyclePersistent && volume.Lifecycle != VolumeLifecycleEphemeral:
+				report(service.dirpath, "service %q has invalid lifecycle %q for volume %q", service.Name, volume.Lifecycle, volume.HostPathOrVolumeName)
+			}
+		}
+
+		for _, dependency := range service.DependsOn {
+			validateDependency(service.dirpath, fmt.Sprintf("service %q", service.Name), dependency)
+		}
+
+		if healthcheck := service.Healthcheck; healthcheck != nil {
+			probes := 0
+			for _, set := range []bool{len(healthcheck.Command) > 0, healthcheck.HTTPGet != "", healthcheck.TCPPort != 0} {
+				if set {
+					probes++
+				}
+			}
+
+			if probes != 1 {
+				report(service.dirpath, "healthcheck of service %q must set exactly one of command, http_get, or tcp_port", service.Name)
+			}
+
+			for _, duration := range [][2]string{{"interval", healthcheck.Interval}, {"timeout", healthcheck.Timeout}, {"start_period", healthcheck.StartPeriod}} {
+				if _, err := time.ParseDuration(duration[1]); duration[1] != "" && err != nil {
+					report(service.dirpath, "healthcheck of service %q has invalid %s %q", service.Name, duration[0], duration[1])
+				}
+			}
+
+			if healthcheck.Retries < 0 {
+				report(service.dirpath, "healthcheck of service %q has negative retries", service.Name)
+			}
+		}
+	}
+
+	for _, stack := range a.Stacks {
+		stackServices := make(map[string]struct{}, len(stack.Services))
+
+		for _, stackService := range stack.Services {
+			if _, ok := stackServices[stackService.Name]; ok {
+				report(stack.dirpath, "stack %q includes service %q more than once", stack.Name, stackService.Name)
+				continue
+			}
+			stackServices[stackService.Name] = struct{}{}
+
+			service, ok := services[stackService.Name]
+			if !ok {
+				report(stack.dirpath, "stack %q references unknown service %q", stack.Name, stackService.Name)
+				continue
+			}
+
+			for _, expose := range stackService.ExposePorts {
+				if GetServicePort(service.Ports, expose.ContainerPort) == nil {
+					report(stack.dirpath, "stack %q exposes port %d of service %q, which is not in its ports", stack.Name, expose.ContainerPort, service.Name)
+				}
+			}
+
+			for _, network := range stackService.JoinStackNetworks {
+				if _, ok := stacks[network]; !ok {
+					report(stack.dirpath, "service %q in stack %q joins network of unknown stack %q", service.Name, stack.Name, network)
+				}
+			}
+
+			for _, dependency := range stackService.DependsOn {
+				validateDependency(stack.dirpath, fmt.Sprintf("service %q in stack %q", service.Name, stack.Name), dependency)
+			}
+		}
+
+		if _, err := a.BuildServiceGraph(&stack); err != nil {
+			report(stack.dirpath, "%s", err.Error())
+		}
+	}
+
+	return diagnostics
+}
@@ -0,0 +1,203 @@
+package atlasfile
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	valid := MergeAtlasFiles([]Atlasfile{
+		{
+			dirpath: "/root/.atlas",
+			Artifacts: []ArtifactConfig{
+				{Name: "base"},
+			},
+			Services: []ServiceConfig{
+				{
+					Name:     "api",
+					Artifact: &ArtifactRef{Artifact: &ArtifactConfig{Name: "api", DependsOn: ArtifactDependsOn{Artifacts: []string{"base"}}}},
+					Ports:    []PortRequest{{ContainerPort: 8080}},
+				},
+				{Name: "db", Image: "postgres:14"},
+			},
+			Stacks: []StackConfig{
+				{Name: "shared", Services: []StackService{{Name: "db"}}},
+				{
+					Name: "local",
+					Services: []StackService{
+						{Name: "api", ExposePorts: []PortExpose{{HostPort: 8080, ContainerPort: 8080}}, JoinStackNetworks: []string{"shared"}},
+					},
+				},
+			},
+		},
+	})
+	assert.Empty(t, valid.Validate("/root"))
+
+	invalid := MergeAtlasFiles([]Atlasfile{
+		{
+			dirpath:   "/root/.atlas",
+			Artifacts: []ArtifactConfig{{Name: "api"}},
+			Services: []ServiceConfig{
+				{Name: "db", Image: "postgres:14"},
+				{Name: "worker", Artifact: &ArtifactRef{Name: "missing"}},
+			},
+		},
+		{
+			dirpath: "/root/api/.atlas",
+			Services: []ServiceConfig{
+				{Name: "api", Artifact: &ArtifactRef{Artifact: &ArtifactConfig{Name: "api"}}},
+				{Name: "db"},
+			},
+			Stacks: []StackConfig{
+				{
+					Name: "local",
+					Services: []StackService{
+						{Name: "api", ExposePorts: []PortExpose{{HostPort: 8080, ContainerPort: 8080}}, JoinStackNetworks: []string{"shared"}},
+						{Name: "cache"},
+					},
+				},
+			},
+		},
+	})
+	assert.Equal(t, Diagnostics{
+		{File: "api/.atlas", Message: `duplicate artifact "api", already declared in .atlas`},
+		{File: "api/.atlas", Message: `duplicate service "db", already declared in .atlas`},
+		{File: ".atlas", Message: `service "worker" references unknown artifact "missing"`},
+		{File: "api/.atlas", Message: `service "db" has neither image nor artifact`},
+		{File: "api/.atlas", Message: `stack "local" exposes port 8080 of service "api", which is not in its ports`},
+		{File: "api/.atlas", Message: `service "api" in stack "local" joins network of unknown stack "shared"`},
+		{File: "api/.atlas", Message: `stack "local" references unknown service "cache"`},
+	}, invalid.Validate("/root"))
+}
+
+func TestValidateServiceDependencies(t *testing.T) {
+	file := MergeAtlasFiles([]Atlasfile{
+		{
+			dirpath: "/root/.atlas",
+			Services: []ServiceConfig{
+				{Name: "db", Image: "postgres:14"},
+				{Name: "migrate", Image: "migrate", DependsOn: []ServiceDependency{{Service: "db", Condition: "ready"}}},
+				{Name: "api", Image: "api", DependsOn: []ServiceDependency{{Service: "migrate", Condition: ServiceDependencyCompleted}}},
+			},
+			Stacks: []StackConfig{
+				{
+					Name: "local",
+					Services: []StackService{
+						{Name: "db", DependsOn: []ServiceDependency{{Service: "api"}}},
+						{Name: "migrate"},
+						{Name: "api"},
+					},
+				},
+			},
+		},
+	})
+
+	assert.Equal(t, Diagnostics{
+		{File: ".atlas", Message: `service "migrate" has invalid dependency condition "ready" for service "db"`},
+		{File: ".atlas", Message: `service "api" waits for service "migrate" to complete, which requires its restart policy to be "no" or "on-failure"`},
+		{File: ".atlas", Message: `services in stack local depend on each other: db -> api -> migrate -> db`},
+	}, file.Validate("/root"))
+}
+
+func TestValidateHealthcheck(t *testing.T) {
+	file := MergeAtlasFiles([]Atlasfile{
+		{
+			dirpath: "/root/.atlas",
+			Services: []ServiceConfig{
+				{Name: "db", Image: "postgres:14", Healthcheck: &Healthcheck{Command: []string{"pg_isready"}, Interval: "2s", StartPeriod: "10s"}},
+				{Name: "api", Image: "api", Healthcheck: &Healthcheck{HTTPGet: "http://localhost/health", TCPPort: 8080, Timeout: "5"}},
+				{Name: "cache", Image: "redis", Healthcheck: &Healthcheck{Retries: -1}},
+			},
+		},
+	})
+
+	assert.Equal(t, Diagnostics{
+		{File: ".atlas", Message: `healthcheck of service "api" must set exactly one of command, http_get, or tcp_port`},
+		{File: ".atlas", Message: `healthcheck of service "api" has invalid timeout "5"`},
+		{File: ".atlas", Message: `healthcheck of service "cache" must set exactly one of command, http_get, or tcp_port`},
+		{File: ".atlas", Message: `healthcheck of service "cache" has negative retries`},
+	}, file.Validate("/root"))
+}
+
+func TestValidateVolumeLifecycle(t *testing.T) {
+	file := MergeAtlasFiles([]Atlasfile{
+		{
+			dirpath: "/root/.atlas",
+			Services: []ServiceConfig{
+				{
+					Name:  "db",
+					Image: "postgres:14",
+					Volumes: []VolumeConfig{
+						{IsVolume: true, HostPathOrVolumeName: "data", ContainerPath: "/var/lib/postgresql/data"},
+						{IsVolume: true, HostPathOrVolumeName: "tmp", ContainerPath: "/tmp", Lifecycle: VolumeLifecycleEphemeral},
+						{IsVolume: true, HostPathOrVolumeName: "wal", ContainerPath: "/wal", Lifecycle: "forever"},
+						{HostPathOrVolumeName: "./init", ContainerPath: "/docker-entrypoint-initdb.d", Lifecycle: VolumeLifecyclePersistent},
+					},
+				},
+			},
+		},
+	})
+
+	assert.Equal(t, Diagnostics{
+		{File: ".atlas", Message: `service "db" has invalid lifecycle "forever" for volume "wal"`},
+		{File: ".atlas", Message: `service "db" sets lifecycle of host path "./init", which only applies to volumes`},
+	}, file.Validate("/root"))
+}
+
+func TestValidateBuildSecrets(t *testing.T) {
+	file := MergeAtlasFiles([]Atlasfile{
+		{
+			dirpath: "/root/.atlas",
+			Artifacts: []ArtifactConfig{
+				{
+					Name: "api",
+					Build: BuildOptions{
+						Secrets: []BuildSecret{
+							{Id: "npmrc", File: ".npmrc"},
+							{Id: "token", Env: "GITHUB_TOKEN"},
+							{Id: "token", Env: "GITLAB_TOKEN"},
+							{Id: "key", File: "id_rsa", Env: "SSH_KEY"},
+							{Id: "cert"},
+							{File: "cert.pem"},
+						},
+					},
+				},
+			},
+		},
+	})
+
+	assert.Equal(t, Diagnostics{
+		{File: ".atlas", Message: `artifact "api" has duplicate build secret "token"`},
+		{File: ".atlas", Message: `build secret "key" of artifact "api" must set exactly one of file or env`},
+		{File: ".atlas", Message: `build secret "cert" of artifact "api" must set exactly one of file or env`},
+		{File: ".atlas", Message: `artifact "api" has build secret without id`},
+	}, file.Validate("/root"))
+}
+
+func TestValidateBuildCache(t *testing.T) {
+	file := MergeAtlasFiles([]Atlasfile{
+		{
+			dirpath: "/root/.atlas",
+			Artifacts: []ArtifactConfig{
+				{
+					Name: "api",
+					Build: BuildOptions{
+						CacheFrom: []string{
+							"example.com/api:cache",
+							"type=registry,ref=example.com/base:cache",
+							"type=local,src=.cache",
+							"type=registry",
+						},
+						CacheTo: []string{CacheToInline, "type=local,dest=.cache"},
+					},
+				},
+			},
+		},
+	})
+
+	assert.Equal(t, Diagnostics{
+		{File: ".atlas", Message: `artifact "api" has unsupported cache_from "type=local,src=.cache", only registry images are supported`},
+		{File: ".atlas", Message: `artifact "api" has unsupported cache_from "type=registry", only registry images are supported`},
+		{File: ".atlas", Message: `artifact "api" has unsupported cache_to "type=local,dest=.cache", only type=inline is supported`},
+	}, file.Validate("/root"))
+}
@@ -16,5 +16,5 @@ protoc \
   --go-grpc_out=./protobuf --go-grpc_opt=paths=source_relative \
   sdk.proto
 
-# store TypeScript code in sdk/atlas-sdk-ts/src/protobuf
+# store TypeScript code in sdk/atlas-sdk-ts/src/sdk.ts
 protoc --ts_out=./sdk/atlas-sdk-ts/src --ts_opt=unary_rpc_promise=true --plugin=protoc-gen-ts=./node_modules/.bin/protoc-gen-ts sdk.proto
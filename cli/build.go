@@ -8,6 +8,7 @@ import (
 
 func prepareBuildCmd(rootCmd *cobra.Command) {
 	var stacks []string
+	var flags evalFlags
 
 	var buildCmd = &cobra.Command{
 		Use:   "build",
@@ -20,7 +21,13 @@ func prepareBuildCmd(rootCmd *cobra.Command) {
 				os.Exit(1)
 			}
 
-			err = atlas.Build(cmd.Context(), logger, version, cwd, stacks)
+			evalOptions, err := flags.options()
+			if err != nil {
+				cmd.PrintErrf("invalid flags: %s", err.Error())
+				os.Exit(1)
+			}
+
+			err = atlas.Build(cmd.Context(), logger, version, cwd, stacks, evalOptions)
 			if err != nil {
 				cmd.PrintErrf("could not build stacks: %s", err.Error())
 				os.Exit(1)
@@ -30,5 +37,6 @@ func prepareBuildCmd(rootCmd *cobra.Command) {
 
 	buildCmd.Flags().StringArrayVarP(&stacks, "stacks", "s", nil, "Stack names")
 	_ = buildCmd.MarkFlagRequired("stacks")
+	flags.register(buildCmd)
 	rootCmd.AddCommand(buildCmd)
 }
@@ -7,12 +7,12 @@ import (
 )
 
 func prepareDownCmd(rootCmd *cobra.Command) {
-	var all bool
+	var options atlas.DownOptions
 	var stacks []string
 
 	var downCmd = &cobra.Command{
 		Use:   "down",
-		Short: "Stop running service containers and remove volumes and networks for specified or all stacks",
+		Short: "Stop running service containers and remove ephemeral volumes and networks for specified or all stacks",
 		Run: func(cmd *cobra.Command, args []string) {
 			logger := createLogger()
 			cwd, err := os.Getwd()
@@ -21,7 +21,7 @@ func prepareDownCmd(rootCmd *cobra.Command) {
 				os.Exit(1)
 			}
 
-			err = atlas.Down(cmd.Context(), logger, cwd, version, stacks, all)
+			err = atlas.Down(cmd.Context(), logger, cwd, version, stacks, options)
 			if err != nil {
 				cmd.PrintErrf("could not up stack: %s", err.Error())
 				os.Exit(1)
@@ -30,7 +30,10 @@ func prepareDownCmd(rootCmd *cobra.Command) {
 	}
 
 	downCmd.Flags().StringArrayVarP(&stacks, "stacks", "s", []string{}, "Stack names")
-	downCmd.Flags().BoolVarP(&all, "all", "a", false, "Clean up all containers and networks")
+	downCmd.Flags().BoolVarP(&options.All, "all", "a", false, "Clean up all containers, volumes, and networks of the current workspace")
+	downCmd.Flags().BoolVar(&options.Global, "global", false, "Clean up all Atlas containers, volumes, and networks on this machine, across all workspaces")
+	downCmd.Flags().BoolVar(&options.Volumes, "volumes", false, "Also remove persistent volumes")
+	downCmd.Flags().BoolVarP(&options.Yes, "yes", "y", false, "Skip confirmation when cleaning up all resources")
 
 	rootCmd.AddCommand(downCmd)
 }
@@ -8,6 +8,7 @@ import (
 
 func prepareEnvCmd(rootCmd *cobra.Command) {
 	var stack string
+	var flags evalFlags
 
 	var envCmd = &cobra.Command{
 		Use:   "env",
@@ -22,7 +23,13 @@ func prepareEnvCmd(rootCmd *cobra.Command) {
 				os.Exit(1)
 			}
 
-			err = atlas.Env(cmd.Context(), logger, version, cwd, stack, args[0])
+			evalOptions, err := flags.options()
+			if err != nil {
+				cmd.PrintErrf("invalid flags: %s", err.Error())
+				os.Exit(1)
+			}
+
+			err = atlas.Env(cmd.Context(), logger, version, cwd, stack, args[0], evalOptions)
 			if err != nil {
 				cmd.PrintErrf("could not sync stack env: %s", err.Error())
 				os.Exit(1)
@@ -32,6 +39,7 @@ func prepareEnvCmd(rootCmd *cobra.Command) {
 
 	envCmd.Flags().StringVarP(&stack, "stack", "s", "", "Stack name (required)")
 	_ = envCmd.MarkFlagRequired("stack")
+	flags.register(envCmd)
 
 	rootCmd.AddCommand(envCmd)
 }
@@ -0,0 +1,29 @@
+package main
+
+import (
+	"github.com/brunoscheufler/atlas/atlasfile"
+	"github.com/spf13/cobra"
+)
+
+// evalFlags holds flags that parameterize Atlasfile evaluation
+type evalFlags struct {
+	profile string
+	vars    []string
+}
+
+func (f *evalFlags) register(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&f.profile, "profile", "", "Profile passed to Atlasfiles")
+	cmd.Flags().StringArrayVar(&f.vars, "var", nil, "Variable passed to Atlasfiles (key=value)")
+}
+
+func (f *evalFlags) options() (atlasfile.EvalOptions, error) {
+	vars, err := atlasfile.ParseVars(f.vars)
+	if err != nil {
+		return atlasfile.EvalOptions{}, err
+	}
+
+	return atlasfile.EvalOptions{
+		Profile: f.profile,
+		Vars:    vars,
+	}, nil
+}
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"fmt"
+	atlas "github.com/brunoscheufler/atlas/core"
+	"github.com/spf13/cobra"
+	"os"
+	"strings"
+)
+
+// parseServiceRef accepts a service name together with the --stack flag, or a stack/service reference
+func parseServiceRef(stack, ref string) (string, string, error) {
+	if stackName, serviceName, ok := strings.Cut(ref, "/"); ok {
+		if stack != "" && stack != stackName {
+			return "", "", fmt.Errorf("stack %s in %s conflicts with --stack %s", stackName, ref, stack)
+		}
+		return stackName, serviceName, nil
+	}
+
+	if stack == "" {
+		return "", "", fmt.Errorf("missing stack, use --stack or stack/service")
+	}
+
+	return stack, ref, nil
+}
+
+// splitExecArgs returns the service reference and the command of atlas exec. Flag parsing stops at the service, so a
+// separating -- is kept in the arguments and removed here.
+func splitExecArgs(args []string) (string, []string, error) {
+	if len(args) == 0 {
+		return "", nil, fmt.Errorf("missing service")
+	}
+
+	command := args[1:]
+	if len(command) > 0 && command[0] == "--" {
+		command = command[1:]
+	}
+
+	if len(command) == 0 {
+		return "", nil, fmt.Errorf("missing command")
+	}
+
+	return args[0], command, nil
+}
+
+func prepareExecCmd(rootCmd *cobra.Command) {
+	var stack string
+
+	var execCmd = &cobra.Command{
+		Use:   "exec [stack/]service -- command [args...]",
+		Short: "Run a command in a service container",
+		Args:  cobra.MinimumNArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			logger := createLogger()
+
+			cwd, err := os.Getwd()
+			if err != nil {
+				cmd.PrintErrf("could not create logger: %s", err.Error())
+				os.Exit(1)
+			}
+
+			ref, command, err := splitExecArgs(args)
+			if err != nil {
+				cmd.PrintErrf("invalid arguments: %s", err.Error())
+				os.Exit(1)
+			}
+
+			stackName, serviceName, err := parseServiceRef(stack, ref)
+			if err != nil {
+				cmd.PrintErrf("invalid service: %s", err.Error())
+				os.Exit(1)
+			}
+
+			exitCode, err := atlas.Exec(cmd.Context(), logger, version, cwd, stackName, serviceName, command)
+			if err != nil {
+				cmd.PrintErrf("could not exec in service: %s", err.Error())
+				os.Exit(1)
+			}
+
+			os.Exit(exitCode)
+		},
+	}
+
+	execCmd.Flags().StringVarP(&stack, "stack", "s", "", "Stack name")
+
+	// Pass flags after the service to the command
+	execCmd.Flags().SetInterspersed(false)
+
+	rootCmd.AddCommand(execCmd)
+}
+
+func prepareShellCmd(rootCmd *cobra.Command) {
+	var stack string
+
+	var shellCmd = &cobra.Command{
+		Use:   "shell [stack/]service",
+		Short: "Open a shell in a service container",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			logger := createLogger()
+
+			cwd, err := os.Getwd()
+			if err != nil {
+				cmd.PrintErrf("could not create logger: %s", err.Error())
+				os.Exit(1)
+			}
+
+			stackName, serviceName, err := parseServiceRef(stack, args[0])
+			if err != nil {
+				cmd.PrintErrf("invalid service: %s", err.Error())
+				os.Exit(1)
+			}
+
+			exitCode, err := atlas.Shell(cmd.Context(), logger, version, cwd, stackName, serviceName)
+			if err != nil {
+				cmd.PrintErrf("could not open shell in service: %s", err.Error())
+				os.Exit(1)
+			}
+
+			os.Exit(exitCode)
+		},
+	}
+
+	shellCmd.Flags().StringVarP(&stack, "stack", "s", "", "Stack name")
+
+	rootCmd.AddCommand(shellCmd)
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestParseServiceRef(t *testing.T) {
+	stack, service, err := parseServiceRef("", "local/db")
+	assert.NoError(t, err)
+	assert.Equal(t, "local", stack)
+	assert.Equal(t, "db", service)
+
+	stack, service, err = parseServiceRef("local", "db")
+	assert.NoError(t, err)
+	assert.Equal(t, "local", stack)
+	assert.Equal(t, "db", service)
+
+	_, _, err = parseServiceRef("local", "ci/db")
+	assert.ErrorContains(t, err, "conflicts with --stack local")
+
+	_, _, err = parseServiceRef("", "db")
+	assert.ErrorContains(t, err, "missing stack")
+}
+
+func TestExecArgs(t *testing.T) {
+	rootCmd := &cobra.Command{Use: "atlas"}
+	prepareExecCmd(rootCmd)
+
+	execCmd, _, err := rootCmd.Find([]string{"exec"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	parse := func(args ...string) (string, string, []string, error) {
+		execCmd.Flags().Set("stack", "")
+
+		err := execCmd.ParseFlags(args)
+		if err != nil {
+			return "", "", nil, err
+		}
+
+		stack, err := execCmd.Flags().GetString("stack")
+		if err != nil {
+			return "", "", nil, err
+		}
+
+		ref, command, err := splitExecArgs(execCmd.Flags().Args())
+		return stack, ref, command, err
+	}
+
+	stack, ref, command, err := parse("-s", "my-stack", "my-service", "--", "ls", "-la")
+	assert.NoError(t, err)
+	assert.Equal(t, "my-stack", stack)
+	assert.Equal(t, "my-service", ref)
+	assert.Equal(t, []string{"ls", "-la"}, command)
+
+	// Flags after the service belong to the command
+	_, ref, command, err = parse("my-stack/db", "psql", "-U", "postgres")
+	assert.NoError(t, err)
+	assert.Equal(t, "my-stack/db", ref)
+	assert.Equal(t, []string{"psql", "-U", "postgres"}, command)
+
+	// Only the separating -- is removed
+	_, _, command, err = parse("my-stack/db", "--", "sh", "-c", "echo --")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"sh", "-c", "echo --"}, command)
+
+	_, _, command, err = parse("my-stack/db", "echo", "--")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"echo", "--"}, command)
+
+	_, _, _, err = parse("my-stack/db", "--")
+	assert.ErrorContains(t, err, "missing command")
+}
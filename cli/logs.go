@@ -0,0 +1,40 @@
+package main
+
+import (
+	atlas "github.com/brunoscheufler/atlas/core"
+	"github.com/brunoscheufler/atlas/docker"
+	"github.com/spf13/cobra"
+	"os"
+)
+
+func prepareLogsCmd(rootCmd *cobra.Command) {
+	var stacks []string
+	var options docker.LogsOptions
+
+	var logsCmd = &cobra.Command{
+		Use:   "logs [service...]",
+		Short: "Show logs of services",
+		Run: func(cmd *cobra.Command, args []string) {
+			logger := createLogger()
+
+			cwd, err := os.Getwd()
+			if err != nil {
+				cmd.PrintErrf("could not create logger: %s", err.Error())
+				os.Exit(1)
+			}
+
+			err = atlas.Logs(cmd.Context(), logger, version, cwd, stacks, args, options)
+			if err != nil {
+				cmd.PrintErrf("could not show logs: %s", err.Error())
+				os.Exit(1)
+			}
+		},
+	}
+
+	logsCmd.Flags().StringArrayVarP(&stacks, "stack", "s", nil, "Stack name")
+	logsCmd.Flags().BoolVarP(&options.Follow, "follow", "f", false, "Follow log output")
+	logsCmd.Flags().StringVar(&options.Since, "since", "", "Show logs since timestamp or relative duration (e.g. 10m)")
+	logsCmd.Flags().StringVar(&options.Tail, "tail", "all", "Number of lines to show from the end of the logs")
+
+	rootCmd.AddCommand(logsCmd)
+}
@@ -3,6 +3,7 @@ package main
 import (
 	"context"
 	"fmt"
+	"github.com/brunoscheufler/atlas/docker"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 	"os"
@@ -17,6 +18,14 @@ var rootCmd = &cobra.Command{
 	Run: func(cmd *cobra.Command, args []string) {
 		// Do Stuff Here
 	},
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		runtime, err := cmd.Flags().GetString("runtime")
+		if err != nil {
+			return err
+		}
+
+		return docker.SelectRuntime(runtime)
+	},
 }
 
 func createLogger() logrus.FieldLogger {
@@ -40,6 +49,8 @@ func createLogger() logrus.FieldLogger {
 func main() {
 	ctx := context.Background()
 
+	rootCmd.PersistentFlags().String("runtime", os.Getenv("ATLAS_RUNTIME"), "Container runtime to use (docker or podman), detected if empty (env: ATLAS_RUNTIME)")
+
 	prepareUpCmd(rootCmd)
 	prepareDownCmd(rootCmd)
 	prepareBuildCmd(rootCmd)
@@ -47,6 +58,13 @@ func main() {
 	preparePsCmd(rootCmd)
 	prepareStartCmd(rootCmd)
 	prepareStopCmd(rootCmd)
+	prepareValidateCmd(rootCmd)
+	prepareLogsCmd(rootCmd)
+	prepareExecCmd(rootCmd)
+	prepareShellCmd(rootCmd)
+	prepareRestartCmd(rootCmd)
+	preparePlanCmd(rootCmd)
+	prepareVolumeCmd(rootCmd)
 	rootCmd.AddCommand(listCmd)
 	rootCmd.AddCommand(updateCmd)
 	rootCmd.AddCommand(versionCmd)
@@ -0,0 +1,44 @@
+package main
+
+import (
+	atlas "github.com/brunoscheufler/atlas/core"
+	"github.com/spf13/cobra"
+	"os"
+)
+
+func preparePlanCmd(rootCmd *cobra.Command) {
+	var stacks []string
+	var flags evalFlags
+	var jsonOutput bool
+
+	var planCmd = &cobra.Command{
+		Use:   "plan [service...]",
+		Short: "Show the artifacts, networks, volumes, and containers atlas up would build, create, recreate, or remove",
+		Args:  cobra.ArbitraryArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			logger := createLogger()
+			cwd, err := os.Getwd()
+			if err != nil {
+				cmd.PrintErrf("could not get current directory: %s", err.Error())
+				os.Exit(1)
+			}
+
+			evalOptions, err := flags.options()
+			if err != nil {
+				cmd.PrintErrf("invalid flags: %s", err.Error())
+				os.Exit(1)
+			}
+
+			err = atlas.Plan(cmd.Context(), logger, version, cwd, stacks, evalOptions, args, jsonOutput)
+			if err != nil {
+				cmd.PrintErrf("could not plan stack: %s", err.Error())
+				os.Exit(1)
+			}
+		},
+	}
+
+	planCmd.Flags().StringArrayVarP(&stacks, "stack", "s", nil, "Stack name")
+	planCmd.Flags().BoolVar(&jsonOutput, "json", false, "Print the plan as JSON")
+	flags.register(planCmd)
+	rootCmd.AddCommand(planCmd)
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	atlas "github.com/brunoscheufler/atlas/core"
+	"github.com/spf13/cobra"
+	"os"
+)
+
+func prepareRestartCmd(rootCmd *cobra.Command) {
+	var stack string
+	var flags evalFlags
+	var options atlas.UpOptions
+
+	var restartCmd = &cobra.Command{
+		Use:   "restart service...",
+		Short: "Rebuild and recreate services, keeping the rest of the stack running",
+		Args:  cobra.MinimumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			logger := createLogger()
+
+			cwd, err := os.Getwd()
+			if err != nil {
+				cmd.PrintErrf("could not create logger: %s", err.Error())
+				os.Exit(1)
+			}
+
+			evalOptions, err := flags.options()
+			if err != nil {
+				cmd.PrintErrf("invalid flags: %s", err.Error())
+				os.Exit(1)
+			}
+
+			err = atlas.Restart(cmd.Context(), logger, version, cwd, stack, args, evalOptions, options)
+			if err != nil {
+				cmd.PrintErrf("could not restart services: %s", err.Error())
+				os.Exit(1)
+			}
+		},
+	}
+
+	restartCmd.Flags().StringVarP(&stack, "stack", "s", "", "Stack name (required)")
+	_ = restartCmd.MarkFlagRequired("stack")
+	restartCmd.Flags().DurationVar(&options.DependencyTimeout, "dependency-timeout", atlas.DefaultWaitTimeout, "Maximum duration a service waits for each of its dependencies, 0 to wait without a limit")
+	restartCmd.Flags().BoolVar(&options.Wait, "wait", false, "Wait until restarted services are healthy, or running if they have no healthcheck")
+	restartCmd.Flags().DurationVar(&options.WaitTimeout, "wait-timeout", atlas.DefaultWaitTimeout, "Maximum duration to wait for services")
+	flags.register(restartCmd)
+
+	rootCmd.AddCommand(restartCmd)
+}
@@ -8,9 +8,14 @@ import (
 
 func prepareUpCmd(rootCmd *cobra.Command) {
 	var stacks []string
+	var flags evalFlags
+	var options atlas.UpOptions
+
 	var upCmd = &cobra.Command{
-		Use:   "up",
+		Use:   "up [service...]",
 		Short: "Build artifacts, create networks and volumes, and start service containers",
+		Long:  "Build artifacts, create networks and volumes, and start service containers. If services are passed, only their artifacts are rebuilt and their containers recreated in an existing stack.",
+		Args:  cobra.ArbitraryArgs,
 		Run: func(cmd *cobra.Command, args []string) {
 
 			logger := createLogger()
@@ -20,7 +25,15 @@ func prepareUpCmd(rootCmd *cobra.Command) {
 				os.Exit(1)
 			}
 
-			err = atlas.Up(cmd.Context(), logger, version, cwd, stacks)
+			evalOptions, err := flags.options()
+			if err != nil {
+				cmd.PrintErrf("invalid flags: %s", err.Error())
+				os.Exit(1)
+			}
+
+			options.Services = args
+
+			err = atlas.Up(cmd.Context(), logger, version, cwd, stacks, evalOptions, options)
 			if err != nil {
 				cmd.PrintErrf("could not up stack: %s", err.Error())
 				os.Exit(1)
@@ -29,5 +42,11 @@ func prepareUpCmd(rootCmd *cobra.Command) {
 	}
 
 	upCmd.Flags().StringArrayVarP(&stacks, "stack", "s", nil, "Stack name")
+	upCmd.Flags().DurationVar(&options.DependencyTimeout, "dependency-timeout", atlas.DefaultWaitTimeout, "Maximum duration a service waits for each of its dependencies, 0 to wait without a limit")
+	upCmd.Flags().BoolVar(&options.Wait, "wait", false, "Wait until all services are healthy, or running if they have no healthcheck")
+	upCmd.Flags().DurationVar(&options.WaitTimeout, "wait-timeout", atlas.DefaultWaitTimeout, "Maximum duration to wait for services")
+	upCmd.Flags().BoolVar(&options.DryRun, "dry-run", false, "Print the changes without making them, like atlas plan")
+	upCmd.Flags().BoolVar(&options.JSON, "json", false, "Print the dry run plan as JSON")
+	flags.register(upCmd)
 	rootCmd.AddCommand(upCmd)
 }
@@ -0,0 +1,39 @@
+package main
+
+import (
+	atlas "github.com/brunoscheufler/atlas/core"
+	"github.com/spf13/cobra"
+	"os"
+)
+
+func prepareValidateCmd(rootCmd *cobra.Command) {
+	var flags evalFlags
+
+	var validateCmd = &cobra.Command{
+		Use:   "validate",
+		Short: "Validate all Atlasfiles in the current workspace without starting anything",
+		Run: func(cmd *cobra.Command, args []string) {
+			logger := createLogger()
+			cwd, err := os.Getwd()
+			if err != nil {
+				cmd.PrintErrf("could not get current directory: %s", err.Error())
+				os.Exit(1)
+			}
+
+			evalOptions, err := flags.options()
+			if err != nil {
+				cmd.PrintErrf("invalid flags: %s", err.Error())
+				os.Exit(1)
+			}
+
+			err = atlas.Validate(cmd.Context(), logger, version, cwd, evalOptions)
+			if err != nil {
+				cmd.PrintErrf("%s\n", err.Error())
+				os.Exit(1)
+			}
+		},
+	}
+
+	flags.register(validateCmd)
+	rootCmd.AddCommand(validateCmd)
+}
@@ -0,0 +1,150 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	atlas "github.com/brunoscheufler/atlas/core"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"os"
+	"strings"
+)
+
+// parseVolumeRef accepts a service/volume reference together with the --stack flag, or a stack/service/volume reference
+func parseVolumeRef(stack, ref string) (string, string, string, error) {
+	parts := strings.Split(ref, "/")
+
+	switch {
+	case len(parts) == 3:
+		if stack != "" && stack != parts[0] {
+			return "", "", "", fmt.Errorf("stack %s in %s conflicts with --stack %s", parts[0], ref, stack)
+		}
+		return parts[0], parts[1], parts[2], nil
+	case len(parts) == 2 && stack != "":
+		return stack, parts[0], parts[1], nil
+	case len(parts) == 2:
+		return "", "", "", fmt.Errorf("missing stack, use --stack or stack/service/volume")
+	default:
+		return "", "", "", fmt.Errorf("invalid volume %s, use stack/service/volume", ref)
+	}
+}
+
+func prepareVolumeCmd(rootCmd *cobra.Command) {
+	var volumeCmd = &cobra.Command{
+		Use:   "volume",
+		Short: "Manage service volumes and their snapshots",
+	}
+
+	prepareVolumeRmCmd(volumeCmd)
+	prepareVolumeLsCmd(volumeCmd)
+
+	prepareVolumeRefCmd(volumeCmd, "snapshot", "name", "Store the contents of a volume as named snapshot in .atlas/snapshots", "could not snapshot volume", atlas.VolumeSnapshot)
+	prepareVolumeRefCmd(volumeCmd, "restore", "name", "Replace the contents of a volume with a snapshot", "could not restore volume", atlas.VolumeRestore)
+	prepareVolumeRefCmd(volumeCmd, "export", "file", "Write the contents of a volume to a tar archive", "could not export volume", atlas.VolumeExport)
+	prepareVolumeRefCmd(volumeCmd, "import", "file", "Replace the contents of a volume with a tar archive", "could not import volume", atlas.VolumeImport)
+
+	rootCmd.AddCommand(volumeCmd)
+}
+
+func prepareVolumeRmCmd(volumeCmd *cobra.Command) {
+	var stack string
+
+	var rmCmd = &cobra.Command{
+		Use:   "rm [stack/]service volume...",
+		Short: "Remove persistent volumes of a service that is not up",
+		Args:  cobra.MinimumNArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			logger := createLogger()
+
+			cwd, err := os.Getwd()
+			if err != nil {
+				cmd.PrintErrf("could not get current directory: %s", err.Error())
+				os.Exit(1)
+			}
+
+			stackName, serviceName, err := parseServiceRef(stack, args[0])
+			if err != nil {
+				cmd.PrintErrf("invalid service: %s", err.Error())
+				os.Exit(1)
+			}
+
+			err = atlas.VolumeRm(cmd.Context(), logger, version, cwd, stackName, serviceName, args[1:])
+			if err != nil {
+				cmd.PrintErrf("could not remove volumes: %s", err.Error())
+				os.Exit(1)
+			}
+		},
+	}
+
+	rmCmd.Flags().StringVarP(&stack, "stack", "s", "", "Stack name")
+
+	volumeCmd.AddCommand(rmCmd)
+}
+
+type volumeRefFn func(ctx context.Context, logger logrus.FieldLogger, version, cwd string, stackName, serviceName, volumeName, arg string) error
+
+// prepareVolumeRefCmd adds a subcommand taking a volume reference and a single argument
+func prepareVolumeRefCmd(volumeCmd *cobra.Command, name, argName, short, errPrefix string, fn volumeRefFn) {
+	var stack string
+
+	var refCmd = &cobra.Command{
+		Use:   fmt.Sprintf("%s [stack/]service/volume %s", name, argName),
+		Short: short,
+		Long:  short + ". Service containers are stopped while the volume is read or written.",
+		Args:  cobra.ExactArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			logger := createLogger()
+
+			cwd, err := os.Getwd()
+			if err != nil {
+				cmd.PrintErrf("could not get current directory: %s", err.Error())
+				os.Exit(1)
+			}
+
+			stackName, serviceName, volumeName, err := parseVolumeRef(stack, args[0])
+			if err != nil {
+				cmd.PrintErrf("invalid volume: %s", err.Error())
+				os.Exit(1)
+			}
+
+			err = fn(cmd.Context(), logger, version, cwd, stackName, serviceName, volumeName, args[1])
+			if err != nil {
+				cmd.PrintErrf("%s: %s", errPrefix, err.Error())
+				os.Exit(1)
+			}
+		},
+	}
+
+	refCmd.Flags().StringVarP(&stack, "stack", "s", "", "Stack name")
+
+	volumeCmd.AddCommand(refCmd)
+}
+
+func prepareVolumeLsCmd(volumeCmd *cobra.Command) {
+	var stacks []string
+
+	var lsCmd = &cobra.Command{
+		Use:   "ls",
+		Short: "List volumes of running services and volumes with snapshots",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			logger := createLogger()
+
+			cwd, err := os.Getwd()
+			if err != nil {
+				cmd.PrintErrf("could not get current directory: %s", err.Error())
+				os.Exit(1)
+			}
+
+			err = atlas.VolumeLs(cmd.Context(), logger, version, cwd, stacks)
+			if err != nil {
+				cmd.PrintErrf("could not list volumes: %s", err.Error())
+				os.Exit(1)
+			}
+		},
+	}
+
+	lsCmd.Flags().StringArrayVarP(&stacks, "stack", "s", nil, "Stack name")
+
+	volumeCmd.AddCommand(lsCmd)
+}
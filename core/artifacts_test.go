@@ -2,36 +2,37 @@ package atlas
 
 import (
 	"github.com/bradleyjkemp/cupaloy"
+	"github.com/brunoscheufler/atlas/atlasfile"
 	"testing"
 )
 
 func TestBuildArtifactGraph(t *testing.T) {
-	g, err := buildArtifactGraph(&Atlasfile{
-		Artifacts: []ArtifactConfig{
+	g, err := buildArtifactGraph(&atlasfile.Atlasfile{
+		Artifacts: []atlasfile.ArtifactConfig{
 			{
 				Name:      "base",
-				DependsOn: ArtifactDependsOn{},
+				DependsOn: atlasfile.ArtifactDependsOn{},
 			},
 			{
 				Name: "api",
-				DependsOn: ArtifactDependsOn{
+				DependsOn: atlasfile.ArtifactDependsOn{
 					Artifacts: []string{"base"},
 				},
 			},
 		},
-		Services: []ServiceConfig{
+		Services: []atlasfile.ServiceConfig{
 			{
 				Name: "api",
-				Artifact: &ArtifactRef{
+				Artifact: &atlasfile.ArtifactRef{
 					Name: "api",
 				},
 			},
 			{
 				Name: "db",
-				Artifact: &ArtifactRef{
-					Artifact: &ArtifactConfig{
+				Artifact: &atlasfile.ArtifactRef{
+					Artifact: &atlasfile.ArtifactConfig{
 						Name: "db",
-						DependsOn: ArtifactDependsOn{
+						DependsOn: atlasfile.ArtifactDependsOn{
 							Artifacts: []string{"base"},
 						},
 					},
@@ -39,10 +40,10 @@ func TestBuildArtifactGraph(t *testing.T) {
 			},
 			{
 				Name: "tool",
-				Artifact: &ArtifactRef{
-					Artifact: &ArtifactConfig{
+				Artifact: &atlasfile.ArtifactRef{
+					Artifact: &atlasfile.ArtifactConfig{
 						Name: "tool",
-						DependsOn: ArtifactDependsOn{
+						DependsOn: atlasfile.ArtifactDependsOn{
 							Services: []string{"api"},
 						},
 					},
@@ -65,33 +66,33 @@ func TestBuildArtifactGraph(t *testing.T) {
 }
 
 func TestBuildArtifactGraphWithImmediate(t *testing.T) {
-	testFile := mergeAtlasFiles([]Atlasfile{
+	testFile := atlasfile.MergeAtlasFiles([]atlasfile.Atlasfile{
 		{
-			Artifacts: []ArtifactConfig{
+			Artifacts: []atlasfile.ArtifactConfig{
 				{
 					Name:      "base",
-					DependsOn: ArtifactDependsOn{},
+					DependsOn: atlasfile.ArtifactDependsOn{},
 				},
 				{
 					Name: "api",
-					DependsOn: ArtifactDependsOn{
+					DependsOn: atlasfile.ArtifactDependsOn{
 						Artifacts: []string{"base"},
 					},
 				},
 			},
-			Services: []ServiceConfig{
+			Services: []atlasfile.ServiceConfig{
 				{
 					Name: "api",
-					Artifact: &ArtifactRef{
+					Artifact: &atlasfile.ArtifactRef{
 						Name: "api",
 					},
 				},
 				{
 					Name: "db",
-					Artifact: &ArtifactRef{
-						Artifact: &ArtifactConfig{
+					Artifact: &atlasfile.ArtifactRef{
+						Artifact: &atlasfile.ArtifactConfig{
 							Name: "db",
-							DependsOn: ArtifactDependsOn{
+							DependsOn: atlasfile.ArtifactDependsOn{
 								Artifacts: []string{"base"},
 							},
 						},
@@ -99,10 +100,10 @@ func TestBuildArtifactGraphWithImmediate(t *testing.T) {
 				},
 				{
 					Name: "tool",
-					Artifact: &ArtifactRef{
-						Artifact: &ArtifactConfig{
+					Artifact: &atlasfile.ArtifactRef{
+						Artifact: &atlasfile.ArtifactConfig{
 							Name: "tool",
-							DependsOn: ArtifactDependsOn{
+							DependsOn: atlasfile.ArtifactDependsOn{
 								Services: []string{"api"},
 							},
 						},
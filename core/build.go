@@ -8,7 +8,7 @@ import (
 	"github.com/sirupsen/logrus"
 )
 
-func Build(ctx context.Context, logger logrus.FieldLogger, version, cwd string, stackNames []string) error {
+func Build(ctx context.Context, logger logrus.FieldLogger, version, cwd string, stackNames []string, evalOptions atlasfile.EvalOptions) error {
 	logger.WithFields(
 		logrus.Fields{
 			"version": version,
@@ -24,13 +24,13 @@ func Build(ctx context.Context, logger logrus.FieldLogger, version, cwd string,
 
 	logger.WithField("cwd", cwd).Debugf("Found root directory")
 
-	mergedFile, err := atlasfile.Collect(ctx, logger, version, cwd)
+	mergedFile, err := atlasfile.Collect(ctx, logger, atlasfile.NewEvalContext(version, cwd, stackNames, evalOptions))
 	if err != nil {
 		return fmt.Errorf("could not collect atlas files: %w", err)
 	}
 
 	if !docker.IsRunning(ctx) {
-		return fmt.Errorf("docker is not running")
+		return fmt.Errorf("%s is not running", docker.RuntimeName())
 	}
 
 	stacks, err := mergedFile.GetStacks(stackNames)
@@ -44,16 +44,17 @@ func Build(ctx context.Context, logger logrus.FieldLogger, version, cwd string,
 	}
 
 	immediateArtifacts, err := getImmediateArtifactsNeededByServices(services, mergedFile)
+	if err != nil {
+		return fmt.Errorf("could not get artifacts: %w", err)
+	}
 
 	// Build artifacts
 	artifactGraph, err := buildArtifactGraphWithImmediate(mergedFile, immediateArtifacts)
-
-	layers, err := artifactGraph.TopologicalSortWithLayers()
 	if err != nil {
-		return fmt.Errorf("could not topologically sort artifacts: %w", err)
+		return fmt.Errorf("could not build artifact graph: %w", err)
 	}
 
-	err = buildArtifacts(ctx, logger, mergedFile, layers, cwd)
+	err = buildArtifacts(ctx, logger, mergedFile, artifactGraph, cwd)
 	if err != nil {
 		return fmt.Errorf("could not build artifacts: %w", err)
 	}
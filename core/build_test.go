@@ -0,0 +1,126 @@
+package atlas
+
+import (
+	"github.com/brunoscheufler/atlas/atlasfile"
+	"github.com/brunoscheufler/atlas/docker"
+	"github.com/brunoscheufler/atlas/docker/dockertest"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testBuildAtlasfile = `
+artifacts:
+  - name: base
+    build:
+      context: base
+  - name: api
+    build:
+      context: api
+      build_args:
+        VERSION: "1"
+    depends_on:
+      artifacts:
+        - base
+services:
+  - name: api
+    artifact:
+      name: api
+stacks:
+  - name: local
+    services:
+      - name: api
+`
+
+func TestBuildCache(t *testing.T) {
+	lt := newLifecycleTest(t, testBuildAtlasfile)
+
+	writeFile := func(name, contents string) {
+		path := filepath.Join(lt.cwd, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(contents), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	writeFile("base/Dockerfile", "FROM alpine\n")
+	writeFile("api/Dockerfile", "FROM base\nCOPY main.go .\n")
+	writeFile("api/main.go", "package main\n")
+	writeFile("api/.dockerignore", "*.log\n")
+
+	// build returns the images built by a build, in order
+	var seen int
+	build := func() []string {
+		err := Build(lt.ctx, lt.logger, "test", lt.cwd, nil, atlasfile.EvalOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		images := make([]string, 0)
+
+		calls := lt.runtime.Calls()
+		for _, call := range calls[seen:] {
+			if strings.HasPrefix(call, dockertest.OpBuildImage+" ") {
+				images = append(images, strings.TrimPrefix(call, dockertest.OpBuildImage+" "))
+			}
+		}
+		seen = len(calls)
+
+		return images
+	}
+
+	assert.Equal(t, []string{"base:latest", "api:latest"}, build())
+
+	hash := lt.runtime.Image("api:latest").Labels[docker.LabelBuildHash]
+	assert.NotEmpty(t, hash)
+
+	// Nothing changed
+	assert.Empty(t, build())
+
+	// Ignored files do not invalidate the cache
+	writeFile("api/debug.log", "started\n")
+	assert.Empty(t, build())
+
+	// Changing the build context of api only rebuilds api
+	writeFile("api/main.go", "package main\n\nfunc main() {}\n")
+	assert.Equal(t, []string{"api:latest"}, build())
+	assert.NotEqual(t, hash, lt.runtime.Image("api:latest").Labels[docker.LabelBuildHash])
+
+	// Changing base rebuilds api, which depends on it
+	writeFile("base/Dockerfile", "FROM alpine:3.18\n")
+	assert.Equal(t, []string{"base:latest", "api:latest"}, build())
+
+	// Changing build args invalidates the cache
+	lt.writeAtlasfile(strings.Replace(testBuildAtlasfile, `VERSION: "1"`, `VERSION: "2"`, 1))
+	assert.Equal(t, []string{"api:latest"}, build())
+
+	// So do BuildKit options, but not the values of secrets or the cache configuration
+	withOptions := strings.Replace(testBuildAtlasfile, `VERSION: "1"`, `VERSION: "2"`, 1)
+	withOptions = strings.Replace(withOptions, "        VERSION: \"2\"\n", `        VERSION: "2"
+      secrets:
+        - id: token
+          env: ATLAS_TEST_TOKEN
+      platform: linux/amd64
+`, 1)
+	lt.writeAtlasfile(withOptions)
+	t.Setenv("ATLAS_TEST_TOKEN", "secret")
+	assert.Equal(t, []string{"api:latest"}, build())
+
+	t.Setenv("ATLAS_TEST_TOKEN", "rotated")
+	lt.writeAtlasfile(strings.Replace(withOptions, "      platform:", "      cache_to:\n        - type=inline\n      platform:", 1))
+	assert.Empty(t, build())
+
+	// Removed images are rebuilt
+	lt.runtime.RemoveImage("api:latest")
+	assert.Equal(t, []string{"api:latest"}, build())
+}
+
+func TestFormatBuildSummary(t *testing.T) {
+	assert.Equal(t, "Artifacts: 2 built (base, api), 0 cached", formatBuildSummary([]string{"base", "api"}, nil))
+	assert.Equal(t, "Artifacts: 1 built (api), 1 cached (base)", formatBuildSummary([]string{"api"}, []string{"base"}))
+	assert.Equal(t, "Artifacts: 0 built, 2 cached (base, api)", formatBuildSummary(nil, []string{"base", "api"}))
+}
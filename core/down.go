@@ -1,26 +1,43 @@
 package atlas
 
 import (
+	"bufio"
 	"context"
 	"fmt"
 	"github.com/brunoscheufler/atlas/atlasfile"
 	"github.com/brunoscheufler/atlas/docker"
+	"github.com/brunoscheufler/atlas/helper"
+	"github.com/moby/term"
 	"github.com/sirupsen/logrus"
 	"golang.org/x/sync/errgroup"
+	"io"
+	"os"
+	"strings"
 )
 
-func Down(ctx context.Context, logger logrus.FieldLogger, cwd, version string, stackNames []string, all bool) error {
+type DownOptions struct {
+	// All removes all resources of the current workspace, including resources missing from the state file
+	All bool
+
+	// Global removes all resources named like Atlas resources on the machine, across all workspaces
+	Global bool
+
+	// Volumes also removes persistent volumes
+	Volumes bool
+
+	// Yes skips the confirmation when removing all resources
+	Yes bool
+}
+
+// Down removes containers and networks of stacks as well as their ephemeral volumes. Persistent volumes are
+// only removed if options.Volumes is set.
+func Down(ctx context.Context, logger logrus.FieldLogger, cwd, version string, stackNames []string, options DownOptions) error {
 	if !docker.IsRunning(ctx) {
-		return fmt.Errorf("docker is not running")
+		return fmt.Errorf("%s is not running", docker.RuntimeName())
 	}
 
-	if all {
-		err := docker.CleanupAll(ctx, logger)
-		if err != nil {
-			return fmt.Errorf("could not cleanup: %w", err)
-		}
-
-		return nil
+	if options.All || options.Global {
+		return downAll(ctx, logger, cwd, options)
 	}
 
 	cwd, err := atlasfile.FindRootDir(cwd)
@@ -28,6 +45,13 @@ func Down(ctx context.Context, logger logrus.FieldLogger, cwd, version string, s
 		return fmt.Errorf("could not find root directory: %w", err)
 	}
 
+	// Hold the state lock until the state file is written
+	ctx, unlock, err := lockState(ctx, cwd, logger)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
 	stateFile, err := readState(ctx, cwd, version, logger)
 	if err != nil {
 		return fmt.Errorf("could not read state file: %w", err)
@@ -44,36 +68,30 @@ func Down(ctx context.Context, logger logrus.FieldLogger, cwd, version string, s
 	}
 
 	for _, stack := range stateFileStacks {
-		logger.WithField("stack", stack.Name).WithField("network", stack.Network).Infof("- Stopping stack %s\n", stack.Name)
-
-		{
-			g, ctx := errgroup.WithContext(ctx)
-			for _, service := range stack.Services {
-				service := service
-				g.Go(func() error {
-					logger.WithFields(logrus.Fields{
-						"stack":   stack.Name,
-						"service": service.Name,
-					}).Infof("\t- Stopping service %s\n", service.Name)
-
-					err = docker.DeleteContainer(ctx, logger, service.ContainerName)
-					if err != nil {
-						return fmt.Errorf("could not stop service: %w", err)
-					}
-
-					return nil
-				})
-			}
+		err = removeStack(ctx, logger, cwd, stack)
+		if err != nil {
+			return err
+		}
+	}
 
-			err = g.Wait()
+	// Keep other stacks when only some stacks were removed
+	if len(stackNames) > 0 {
+		for _, stack := range stateFileStacks {
+			err = deleteStackVolumes(ctx, logger, cwd, stack, options.Volumes)
 			if err != nil {
-				return fmt.Errorf("could not stop stack services: %w", err)
+				return err
 			}
+
+			stateFile.removeStack(stack.Name)
 		}
 
-		err = docker.DeleteNetwork(ctx, logger, stack.Network)
-		if err != nil {
-			return fmt.Errorf("could not delete network: %w", err)
+		if len(stateFile.Stacks) > 0 {
+			err = writeStateFileRaw(cwd, stateFile)
+			if err != nil {
+				return fmt.Errorf("could not write state file: %w", err)
+			}
+
+			return nil
 		}
 	}
 
@@ -84,6 +102,15 @@ func Down(ctx context.Context, logger logrus.FieldLogger, cwd, version string, s
 		}
 	}
 
+	if options.Volumes {
+		for _, stack := range stateFileStacks {
+			err = deleteStackVolumes(ctx, logger, cwd, stack, true)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
 	err = clearStatefile(cwd)
 	if err != nil {
 		return fmt.Errorf("could not clear state file: %w", err)
@@ -91,3 +118,251 @@ func Down(ctx context.Context, logger logrus.FieldLogger, cwd, version string, s
 
 	return nil
 }
+
+// downAll removes all labeled resources of the workspace, or all resources named like Atlas resources if options.Global
+// is set, after listing them and asking for confirmation
+func downAll(ctx context.Context, logger logrus.FieldLogger, cwd string, options DownOptions) error {
+	rootDir, rootErr := atlasfile.FindRootDir(cwd)
+
+	// Hold the state lock while listing and removing resources, so that no other Atlas process creates resources of
+	// the workspace and writes their state in between
+	if rootErr == nil {
+		lockedCtx, unlock, err := lockState(ctx, rootDir, logger)
+		if err != nil {
+			return err
+		}
+		defer unlock()
+
+		ctx = lockedCtx
+	}
+
+	var resources *docker.LabeledResources
+	var err error
+	if options.Global {
+		resources, err = docker.FindAllResources(ctx)
+	} else {
+		if rootErr != nil {
+			return fmt.Errorf("could not find root directory, use --global to remove resources of all workspaces: %w", rootErr)
+		}
+
+		resources, err = docker.FindLabeledResources(ctx, rootDir)
+	}
+	if err != nil {
+		return fmt.Errorf("could not find resources: %w", err)
+	}
+
+	if !options.Volumes {
+		resources = resources.WithoutPersistentVolumes()
+	}
+
+	if resources.IsEmpty() {
+		logger.Infoln("No resources found, nothing to do")
+	} else {
+		printResources(os.Stdout, resources, options.Global)
+
+		if !options.Yes {
+			confirmed, err := confirm(os.Stdin, os.Stdout, "Remove these resources?")
+			if err != nil {
+				return err
+			}
+
+			if !confirmed {
+				logger.Infoln("Aborted")
+				return nil
+			}
+		}
+
+		err = docker.RemoveResources(ctx, logger, resources)
+		if err != nil {
+			return fmt.Errorf("could not cleanup: %w", err)
+		}
+	}
+
+	if rootErr == nil && helper.FileExists(getStatefilePath(rootDir)) {
+		err = clearStatefile(rootDir)
+		if err != nil {
+			return fmt.Errorf("could not clear state file: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// printResources lists resources that will be removed, including their workspace if global is set
+func printResources(w io.Writer, resources *docker.LabeledResources, global bool) {
+	describe := func(kind, name, root string, parts ...string) {
+		var ref []string
+		for _, part := range parts {
+			if part != "" {
+				ref = append(ref, part)
+			}
+		}
+
+		line := fmt.Sprintf("  %s %s", kind, name)
+		if len(ref) > 0 {
+			line += fmt.Sprintf(" (%s)", strings.Join(ref, "/"))
+		}
+
+		if global {
+			if root == "" {
+				root = "unknown workspace"
+			}
+			line += fmt.Sprintf(" in %s", root)
+		}
+
+		fmt.Fprintln(w, line)
+	}
+
+	fmt.Fprintln(w, "The following resources will be removed:")
+
+	for _, container := range resources.Containers {
+		describe("container", container.Name, container.Root, container.Stack, container.Service)
+	}
+
+	for _, volume := range resources.Volumes {
+		kind := "volume"
+		if volume.Persistent {
+			kind = "persistent volume"
+		}
+
+		describe(kind, volume.Name, volume.Root, volume.Stack, volume.Service, volume.Volume)
+	}
+
+	for _, network := range resources.Networks {
+		describe("network", network.Name, network.Root, network.Stack)
+	}
+}
+
+// confirm asks a yes/no question, which must be answered interactively
+func confirm(r io.Reader, w io.Writer, question string) (bool, error) {
+	if f, ok := r.(*os.File); ok && !term.IsTerminal(f.Fd()) {
+		return false, fmt.Errorf("cannot ask for confirmation without a terminal, pass --yes to confirm")
+	}
+
+	fmt.Fprintf(w, "%s [y/N] ", question)
+
+	answer, err := bufio.NewReader(r).ReadString('\n')
+	if err != nil && err != io.EOF {
+		return false, fmt.Errorf("could not read answer: %w", err)
+	}
+
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return true, nil
+	default:
+		return false, nil
+	}
+}
+
+// deleteStackVolumes deletes the ephemeral volumes of all services in a stack, and their persistent volumes if includePersistent is set.
+// Volumes labeled with the stack are included, so that volumes of services missing from the state file are deleted too.
+func deleteStackVolumes(ctx context.Context, logger logrus.FieldLogger, rootDir string, stack StateStack, includePersistent bool) error {
+	resources, err := docker.FindLabeledResources(ctx, rootDir)
+	if err != nil {
+		return fmt.Errorf("could not find labeled resources: %w", err)
+	}
+
+	volumes := make([]StateVolume, 0)
+	for _, service := range stack.Services {
+		volumes = append(volumes, service.Volumes...)
+	}
+
+	for _, volume := range resources.Volumes {
+		if volume.Stack == stack.Name {
+			volumes = append(volumes, StateVolume{Name: volume.Volume, PhysicalName: volume.Name, Persistent: volume.Persistent})
+		}
+	}
+
+	deleted := make(map[string]struct{})
+	for _, volume := range volumes {
+		if _, ok := deleted[volume.PhysicalName]; ok {
+			continue
+		}
+
+		if volume.Persistent && !includePersistent {
+			continue
+		}
+
+		err := docker.DeleteVolume(ctx, logger, volume.PhysicalName)
+		if err != nil {
+			return fmt.Errorf("could not delete volume: %w", err)
+		}
+
+		deleted[volume.PhysicalName] = struct{}{}
+	}
+
+	return nil
+}
+
+// removeStack deletes all service containers and the network of a stack, including containers and networks
+// labeled with the stack that are missing from the state file
+func removeStack(ctx context.Context, logger logrus.FieldLogger, rootDir string, stack StateStack) error {
+	logger.WithField("stack", stack.Name).WithField("network", stack.Network).Infof("- Stopping stack %s\n", stack.Name)
+
+	resources, err := docker.FindLabeledResources(ctx, rootDir)
+	if err != nil {
+		return fmt.Errorf("could not find labeled resources: %w", err)
+	}
+
+	services := append([]StateService{}, stack.Services...)
+	for _, container := range resources.Containers {
+		if container.Stack != stack.Name {
+			continue
+		}
+
+		found := false
+		for _, service := range stack.Services {
+			if service.ContainerName == container.Name {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			services = append(services, StateService{Name: container.Service, ContainerName: container.Name})
+		}
+	}
+
+	g, groupCtx := errgroup.WithContext(ctx)
+	for _, service := range services {
+		service := service
+		g.Go(func() error {
+			logger.WithFields(logrus.Fields{
+				"stack":   stack.Name,
+				"service": service.Name,
+			}).Infof("\t- Stopping service %s\n", service.Name)
+
+			err := docker.DeleteContainer(groupCtx, logger, service.ContainerName)
+			if err != nil {
+				return fmt.Errorf("could not stop service: %w", err)
+			}
+
+			return nil
+		})
+	}
+
+	err = g.Wait()
+	if err != nil {
+		return fmt.Errorf("could not stop stack services: %w", err)
+	}
+
+	networks := []string{stack.Network}
+	for _, network := range resources.Networks {
+		if network.Stack == stack.Name && network.Name != stack.Network {
+			networks = append(networks, network.Name)
+		}
+	}
+
+	for _, network := range networks {
+		if network == "" {
+			continue
+		}
+
+		err = docker.DeleteNetwork(ctx, logger, network)
+		if err != nil {
+			return fmt.Errorf("could not delete network: %w", err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,46 @@
+package atlas
+
+import (
+	"bytes"
+	"github.com/brunoscheufler/atlas/docker"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestPrintResources(t *testing.T) {
+	resources := &docker.LabeledResources{
+		Containers: []docker.LabeledContainer{{Name: "atlas-local-db-1", Root: "/work/a", Stack: "local", Service: "db"}},
+		Volumes:    []docker.LabeledVolume{{Name: "atlas-local-db-data-1", Root: "/work/a", Stack: "local", Service: "db", Volume: "data", Persistent: true}},
+		Networks:   []docker.LabeledNetwork{{Name: "atlas-old-1234"}},
+	}
+
+	var output bytes.Buffer
+	printResources(&output, resources, false)
+
+	assert.Equal(t, `The following resources will be removed:
+  container atlas-local-db-1 (local/db)
+  persistent volume atlas-local-db-data-1 (local/db/data)
+  network atlas-old-1234
+`, output.String())
+
+	output.Reset()
+	printResources(&output, resources, true)
+
+	assert.Equal(t, `The following resources will be removed:
+  container atlas-local-db-1 (local/db) in /work/a
+  persistent volume atlas-local-db-data-1 (local/db/data) in /work/a
+  network atlas-old-1234 in unknown workspace
+`, output.String())
+}
+
+func TestConfirm(t *testing.T) {
+	for answer, expected := range map[string]bool{"y\n": true, "YES\n": true, "n\n": false, "\n": false, "": false} {
+		var output bytes.Buffer
+
+		confirmed, err := confirm(strings.NewReader(answer), &output, "Remove?")
+		assert.NoError(t, err)
+		assert.Equal(t, expected, confirmed, answer)
+		assert.Equal(t, "Remove? [y/N] ", output.String())
+	}
+}
@@ -11,7 +11,7 @@ import (
 	"sort"
 )
 
-func Env(ctx context.Context, logger logrus.FieldLogger, version, cwd string, stackName, serviceName string) error {
+func Env(ctx context.Context, logger logrus.FieldLogger, version, cwd string, stackName, serviceName string, evalOptions atlasfile.EvalOptions) error {
 	initialCwd := cwd
 
 	cwd, err := atlasfile.FindRootDir(cwd)
@@ -21,7 +21,7 @@ func Env(ctx context.Context, logger logrus.FieldLogger, version, cwd string, st
 
 	logger.WithField("cwd", cwd).Debugf("Found root directory")
 
-	mergedFile, err := atlasfile.Collect(ctx, logger, version, cwd)
+	mergedFile, err := atlasfile.Collect(ctx, logger, atlasfile.NewEvalContext(version, cwd, []string{stackName}, evalOptions))
 	if err != nil {
 		return fmt.Errorf("could not collect atlas files: %w", err)
 	}
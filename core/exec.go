@@ -0,0 +1,104 @@
+package atlas
+
+import (
+	"context"
+	"fmt"
+	"github.com/brunoscheufler/atlas/atlasfile"
+	"github.com/brunoscheufler/atlas/docker"
+	"github.com/moby/term"
+	"github.com/sirupsen/logrus"
+	"io"
+	"os"
+)
+
+// shells are tried in order by Shell
+var shells = []string{"bash", "zsh", "ash", "sh"}
+
+// Exec runs command in the container of a stack service and returns its exit code. A TTY is allocated
+// when stdin is a terminal.
+func Exec(ctx context.Context, logger logrus.FieldLogger, version, cwd string, stackName, serviceName string, command []string) (int, error) {
+	containerName, err := getServiceContainer(ctx, logger, version, cwd, stackName, serviceName)
+	if err != nil {
+		return 0, err
+	}
+
+	_, isTerminal := term.GetFdInfo(os.Stdin)
+
+	return docker.ExecInContainer(ctx, containerName, docker.ExecOptions{
+		Command: command,
+		Stdin:   os.Stdin,
+		Stdout:  os.Stdout,
+		Stderr:  os.Stderr,
+		TTY:     isTerminal,
+	})
+}
+
+// Shell opens the first available shell in the container of a stack service and returns its exit code
+func Shell(ctx context.Context, logger logrus.FieldLogger, version, cwd string, stackName, serviceName string) (int, error) {
+	containerName, err := getServiceContainer(ctx, logger, version, cwd, stackName, serviceName)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, shell := range shells {
+		// Check if shell exists by running it without attaching
+		exitCode, err := docker.ExecInContainer(ctx, containerName, docker.ExecOptions{
+			Command: []string{shell, "-c", "exit 0"},
+			Stdout:  io.Discard,
+			Stderr:  io.Discard,
+		})
+		if err != nil || exitCode != 0 {
+			logger.WithField("shell", shell).Debugln("shell not available")
+			continue
+		}
+
+		_, isTerminal := term.GetFdInfo(os.Stdin)
+
+		return docker.ExecInContainer(ctx, containerName, docker.ExecOptions{
+			Command: []string{shell},
+			Stdin:   os.Stdin,
+			Stdout:  os.Stdout,
+			Stderr:  os.Stderr,
+			TTY:     isTerminal,
+		})
+	}
+
+	return 0, fmt.Errorf("could not find a shell in service %s, tried %v", serviceName, shells)
+}
+
+// getServiceContainer returns the name of the running container of a stack service
+func getServiceContainer(ctx context.Context, logger logrus.FieldLogger, version, cwd string, stackName, serviceName string) (string, error) {
+	cwd, err := atlasfile.FindRootDir(cwd)
+	if err != nil {
+		return "", fmt.Errorf("could not find root directory: %w", err)
+	}
+
+	if !docker.IsRunning(ctx) {
+		return "", fmt.Errorf("%s is not running", docker.RuntimeName())
+	}
+
+	statefile, err := readState(ctx, cwd, version, logger)
+	if err != nil {
+		return "", fmt.Errorf("could not read state file: %w", err)
+	}
+
+	if statefile == nil {
+		return "", fmt.Errorf("no state file found, run atlas up first")
+	}
+
+	stack := statefile.GetStack(stackName)
+	if stack == nil {
+		return "", fmt.Errorf("stack %s not found", stackName)
+	}
+
+	service := stack.GetService(serviceName)
+	if service == nil {
+		return "", fmt.Errorf("service %s not found", serviceName)
+	}
+
+	if service.ContainerInfos == nil || service.ContainerInfos.State != "running" {
+		return "", fmt.Errorf("service %s is not running", serviceName)
+	}
+
+	return service.ContainerName, nil
+}
@@ -0,0 +1,624 @@
+package atlas
+
+import (
+	"context"
+	"errors"
+	"github.com/brunoscheufler/atlas/atlasfile"
+	"github.com/brunoscheufler/atlas/docker"
+	"github.com/brunoscheufler/atlas/docker/dockertest"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testAtlasfile = `
+services:
+  - name: db
+    image: postgres:15
+    volumes:
+      - isVolume: true
+        hostPath: data
+        containerPath: /var/lib/postgresql/data
+        lifecycle: persistent
+      - isVolume: true
+        hostPath: tmp
+        containerPath: /tmp
+        lifecycle: ephemeral
+  - name: api
+    image: api:latest
+    environment:
+      LOG_LEVEL: info
+    depends_on:
+      - service: db
+stacks:
+  - name: local
+    services:
+      - name: db
+      - name: api
+`
+
+// lifecycleTest is a workspace using a fake runtime in place of Docker
+type lifecycleTest struct {
+	t       *testing.T
+	ctx     context.Context
+	cwd     string
+	runtime *dockertest.Runtime
+	logger  logrus.FieldLogger
+}
+
+// newLifecycleTest creates a workspace with atlasfileContents as root Atlasfile and replaces the runtime with a fake
+func newLifecycleTest(t *testing.T, atlasfileContents string) *lifecycleTest {
+	runtime := dockertest.NewRuntime()
+	docker.SetRuntime(runtime)
+	t.Cleanup(func() {
+		docker.SetRuntime(nil)
+	})
+
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	lt := &lifecycleTest{t: t, ctx: context.Background(), cwd: t.TempDir(), runtime: runtime, logger: logger}
+	lt.writeAtlasfile(atlasfileContents)
+
+	return lt
+}
+
+// newUpLifecycleTest creates a workspace with testAtlasfile and brings up its stack
+func newUpLifecycleTest(t *testing.T) *lifecycleTest {
+	lt := newLifecycleTest(t, testAtlasfile)
+	lt.mustUp()
+	return lt
+}
+
+func (lt *lifecycleTest) writeAtlasfile(contents string) {
+	err := os.MkdirAll(filepath.Join(lt.cwd, ".atlas"), 0755)
+	if err != nil {
+		lt.t.Fatal(err)
+	}
+
+	err = os.WriteFile(filepath.Join(lt.cwd, ".atlas", "Atlasfile.root.yaml"), []byte(contents), 0644)
+	if err != nil {
+		lt.t.Fatal(err)
+	}
+}
+
+func (lt *lifecycleTest) up() error {
+	return Up(lt.ctx, lt.logger, "test", lt.cwd, nil, atlasfile.EvalOptions{}, UpOptions{})
+}
+
+func (lt *lifecycleTest) mustUp() {
+	if err := lt.up(); err != nil {
+		lt.t.Fatal(err)
+	}
+}
+
+func (lt *lifecycleTest) down(options DownOptions) error {
+	return Down(lt.ctx, lt.logger, lt.cwd, "test", nil, options)
+}
+
+func (lt *lifecycleTest) mustDown(options DownOptions) {
+	if err := lt.down(options); err != nil {
+		lt.t.Fatal(err)
+	}
+}
+
+// savedState returns the state file without refreshing it
+func (lt *lifecycleTest) savedState() *Statefile {
+	statefile, err := loadStatefile(lt.cwd, lt.logger)
+	if err != nil {
+		lt.t.Fatal(err)
+	}
+
+	if statefile == nil {
+		lt.t.Fatal("state file does not exist")
+	}
+
+	return statefile
+}
+
+// state returns the state file refreshed from the runtime
+func (lt *lifecycleTest) state() *Statefile {
+	statefile, err := readState(lt.ctx, lt.cwd, "test", lt.logger)
+	if err != nil {
+		lt.t.Fatal(err)
+	}
+
+	if statefile == nil {
+		lt.t.Fatal("state file does not exist")
+	}
+
+	return statefile
+}
+
+// containerOf returns the name of the container of a service in the state file
+func (lt *lifecycleTest) containerOf(statefile *Statefile, stackName, serviceName string) string {
+	stack := statefile.GetStack(stackName)
+	if stack == nil {
+		lt.t.Fatalf("stack %s not found", stackName)
+	}
+
+	service := stack.GetService(serviceName)
+	if service == nil {
+		lt.t.Fatalf("service %s not found", serviceName)
+	}
+
+	return service.ContainerName
+}
+
+// container returns the fake container of a service that is up
+func (lt *lifecycleTest) container(stackName, serviceName string) *dockertest.Container {
+	container := lt.runtime.Container(lt.containerOf(lt.savedState(), stackName, serviceName))
+	if container == nil {
+		lt.t.Fatalf("container of service %s not found", serviceName)
+	}
+
+	return container
+}
+
+func TestUp(t *testing.T) {
+	lt := newUpLifecycleTest(t)
+
+	statefile := lt.savedState()
+	assert.Equal(t, stateSchemaVersion, statefile.SchemaVersion)
+	if len(statefile.Stacks) != 1 {
+		t.Fatalf("expected one stack, got %d", len(statefile.Stacks))
+	}
+
+	stack := statefile.Stacks[0]
+	assert.Equal(t, "local", stack.Name)
+	assert.Equal(t, []string{stack.Network}, lt.runtime.Networks())
+	assert.Len(t, stack.Services, 2)
+
+	db := lt.container("local", "db")
+	assert.True(t, db.Running)
+	assert.Equal(t, stack.Network, db.Config.Network)
+	assert.Equal(t, "local", db.Labels[docker.LabelStack])
+	assert.Equal(t, "db", db.Labels[docker.LabelService])
+	assert.Equal(t, lt.cwd, db.Labels[docker.LabelRoot])
+
+	api := lt.container("local", "api")
+	assert.Contains(t, api.Config.Env, "LOG_LEVEL=info")
+	assert.Equal(t, "running", stack.GetService("api").ContainerInfos.State)
+
+	// Both volumes of db are labeled and recorded
+	assert.Len(t, lt.runtime.Volumes(), 2)
+	assert.Len(t, stack.GetService("db").Volumes, 2)
+	for _, name := range lt.runtime.Volumes() {
+		volume := lt.runtime.Volume(name)
+		assert.Equal(t, "db", volume.Labels[docker.LabelService])
+		assert.True(t, strings.HasPrefix(name, "atlas-local-db-"), name)
+	}
+}
+
+func TestUpKeepsUnchangedContainers(t *testing.T) {
+	lt := newUpLifecycleTest(t)
+
+	before := lt.savedState()
+	containersBefore := lt.runtime.Containers()
+
+	lt.mustUp()
+
+	after := lt.savedState()
+	assert.Equal(t, containersBefore, lt.runtime.Containers())
+	assert.Equal(t, before.Stacks[0].Network, after.Stacks[0].Network)
+
+	// Changing the environment of api recreates only its container
+	lt.writeAtlasfile(strings.Replace(testAtlasfile, "LOG_LEVEL: info", "LOG_LEVEL: debug", 1))
+	lt.mustUp()
+
+	changed := lt.savedState()
+	assert.Equal(t, lt.containerOf(before, "local", "db"), lt.containerOf(changed, "local", "db"))
+	assert.NotEqual(t, lt.containerOf(before, "local", "api"), lt.containerOf(changed, "local", "api"))
+	assert.Nil(t, lt.runtime.Container(lt.containerOf(before, "local", "api")))
+	assert.Len(t, lt.runtime.Containers(), 2)
+}
+
+func TestUpRestartsStoppedContainers(t *testing.T) {
+	lt := newUpLifecycleTest(t)
+
+	apiContainer := lt.containerOf(lt.savedState(), "local", "api")
+	lt.runtime.SetExited(apiContainer, 1)
+
+	lt.mustUp()
+
+	assert.True(t, lt.runtime.Container(apiContainer).Running)
+	assert.Equal(t, apiContainer, lt.containerOf(lt.savedState(), "local", "api"))
+}
+
+func TestUpDependencyTimeout(t *testing.T) {
+	lt := newLifecycleTest(t, `
+services:
+  - name: db
+    image: postgres:15
+    healthcheck:
+      command: ["pg_isready"]
+  - name: api
+    image: api:latest
+    depends_on:
+      - service: db
+        condition: healthy
+stacks:
+  - name: local
+    services:
+      - name: db
+      - name: api
+`)
+
+	// The healthcheck of db never passes
+	lt.runtime.SetStartHealth("atlas-local-db", "starting")
+
+	err := Up(lt.ctx, lt.logger, "test", lt.cwd, nil, atlasfile.EvalOptions{}, UpOptions{DependencyTimeout: 100 * time.Millisecond})
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.ErrorContains(t, err, "dependency db of service api did not become healthy: timed out after 100ms")
+	assert.ErrorContains(t, err, "is running (health: starting)")
+
+	// api was never created
+	containers := lt.runtime.Containers()
+	if len(containers) != 1 {
+		t.Fatalf("expected one container, got %v", containers)
+	}
+	assert.True(t, strings.HasPrefix(containers[0], "atlas-local-db-"))
+}
+
+func TestWaitForExitedContainer(t *testing.T) {
+	lt := newUpLifecycleTest(t)
+
+	apiContainer := lt.containerOf(lt.savedState(), "local", "api")
+
+	wait := func(condition atlasfile.ServiceDependencyCondition) error {
+		ctx, cancel := context.WithTimeout(lt.ctx, time.Second)
+		defer cancel()
+		return docker.WaitForContainer(ctx, apiContainer, condition)
+	}
+
+	// A crashed container is never ready
+	lt.runtime.SetExited(apiContainer, 3)
+	err := wait(atlasfile.ServiceDependencyStarted)
+	assert.ErrorContains(t, err, "exited with code 3")
+	assert.NotErrorIs(t, err, context.DeadlineExceeded)
+
+	err = wait(atlasfile.ServiceDependencyCompleted)
+	assert.ErrorContains(t, err, "exited with code 3")
+
+	// Only completed dependencies may exit, with code 0
+	lt.runtime.SetExited(apiContainer, 0)
+	assert.ErrorContains(t, wait(atlasfile.ServiceDependencyStarted), "exited with code 0")
+	assert.NoError(t, wait(atlasfile.ServiceDependencyCompleted))
+}
+
+func TestDown(t *testing.T) {
+	lt := newUpLifecycleTest(t)
+
+	lt.mustDown(DownOptions{})
+
+	assert.Empty(t, lt.runtime.Containers())
+	assert.Empty(t, lt.runtime.Networks())
+
+	// Only the persistent volume is kept
+	volumes := lt.runtime.Volumes()
+	if len(volumes) != 1 {
+		t.Fatalf("expected one volume, got %v", volumes)
+	}
+	assert.Equal(t, "data", lt.runtime.Volume(volumes[0]).Labels[docker.LabelVolume])
+
+	statefile, err := readState(lt.ctx, lt.cwd, "test", lt.logger)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if statefile != nil {
+		assert.Empty(t, statefile.Stacks)
+	}
+}
+
+func TestDownAll(t *testing.T) {
+	lt := newUpLifecycleTest(t)
+
+	// Resources missing from the state file are removed as well
+	err := os.Remove(filepath.Join(lt.cwd, ".atlas", "state.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lt.mustDown(DownOptions{All: true, Yes: true})
+
+	assert.Empty(t, lt.runtime.Containers())
+	assert.Empty(t, lt.runtime.Networks())
+	assert.Len(t, lt.runtime.Volumes(), 1)
+
+	// The state lock was released
+	_, unlock, err := lockState(context.Background(), lt.cwd, lt.logger)
+	if err != nil {
+		t.Fatal(err)
+	}
+	unlock()
+}
+
+func TestDownVolumes(t *testing.T) {
+	lt := newUpLifecycleTest(t)
+
+	lt.mustDown(DownOptions{Volumes: true})
+
+	assert.Empty(t, lt.runtime.Containers())
+	assert.Empty(t, lt.runtime.Networks())
+	assert.Empty(t, lt.runtime.Volumes())
+}
+
+func TestStopStart(t *testing.T) {
+	lt := newUpLifecycleTest(t)
+
+	api := lt.container("local", "api")
+
+	err := Stop(lt.ctx, lt.logger, "test", lt.cwd, "local", "api")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.False(t, lt.runtime.Container(api.Name).Running)
+	assert.Equal(t, "exited", lt.state().GetStack("local").GetService("api").ContainerInfos.State)
+
+	err = Start(lt.ctx, lt.logger, "test", lt.cwd, "local", "api")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.True(t, lt.runtime.Container(api.Name).Running)
+
+	err = Start(lt.ctx, lt.logger, "test", lt.cwd, "local", "unknown")
+	assert.ErrorContains(t, err, "service unknown not found")
+
+	err = Stop(lt.ctx, lt.logger, "test", lt.cwd, "unknown", "api")
+	assert.ErrorContains(t, err, "stack unknown not found")
+}
+
+func TestPs(t *testing.T) {
+	lt := newLifecycleTest(t, testAtlasfile)
+
+	// Nothing is up yet
+	err := Ps(lt.ctx, lt.logger, lt.cwd, "test", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lt.mustUp()
+
+	err = Ps(lt.ctx, lt.logger, lt.cwd, "test", []string{"local"})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRuntimeNotRunning(t *testing.T) {
+	lt := newLifecycleTest(t, testAtlasfile)
+
+	lt.runtime.FailOn(dockertest.OpPing, "", errors.New("connection refused"))
+
+	assert.ErrorContains(t, lt.up(), "fake is not running")
+	assert.ErrorContains(t, Ps(lt.ctx, lt.logger, lt.cwd, "test", nil), "fake is not running")
+}
+
+func TestReadStateRefreshesState(t *testing.T) {
+	lt := newUpLifecycleTest(t)
+
+	lt.runtime.RemoveContainerExternally(lt.containerOf(lt.savedState(), "local", "api"))
+
+	statefile := lt.state()
+	assert.Nil(t, statefile.GetStack("local").GetService("api"))
+	assert.NotNil(t, statefile.GetStack("local").GetService("db"))
+
+	// The refreshed state is written
+	assert.Nil(t, lt.savedState().GetStack("local").GetService("api"))
+
+	// Stacks without containers are kept while their network exists
+	lt.runtime.RemoveContainerExternally(lt.containerOf(statefile, "local", "db"))
+
+	statefile = lt.state()
+	if len(statefile.Stacks) != 1 {
+		t.Fatalf("expected one stack, got %d", len(statefile.Stacks))
+	}
+	assert.Empty(t, statefile.Stacks[0].Services)
+	assert.Equal(t, lt.runtime.Networks(), []string{statefile.Stacks[0].Network})
+
+	// The next up recreates the stack in its existing network
+	networks := lt.runtime.Networks()
+	lt.mustUp()
+	assert.Len(t, lt.runtime.Containers(), 2)
+	assert.Len(t, lt.savedState().GetStack("local").Services, 2)
+	assert.Equal(t, networks, lt.runtime.Networks())
+}
+
+func TestReadStateRecoversFromLabels(t *testing.T) {
+	lt := newUpLifecycleTest(t)
+
+	expected := lt.savedState()
+
+	err := os.Remove(filepath.Join(lt.cwd, ".atlas", "state.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	statefile := lt.state()
+	assert.Equal(t, lt.containerOf(expected, "local", "db"), lt.containerOf(statefile, "local", "db"))
+	assert.Equal(t, lt.containerOf(expected, "local", "api"), lt.containerOf(statefile, "local", "api"))
+	assert.Equal(t, expected.Stacks[0].Network, statefile.Stacks[0].Network)
+
+	// Up keeps the recovered containers
+	containers := lt.runtime.Containers()
+	lt.mustUp()
+	assert.Equal(t, containers, lt.runtime.Containers())
+}
+
+func TestPlanDoesNotWriteState(t *testing.T) {
+	lt := newUpLifecycleTest(t)
+
+	mergedFile, err := atlasfile.Collect(lt.ctx, lt.logger, atlasfile.NewEvalContext("test", lt.cwd, nil, atlasfile.EvalOptions{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	statePath := filepath.Join(lt.cwd, ".atlas", "state.json")
+
+	lt.runtime.RemoveContainerExternally(lt.containerOf(lt.savedState(), "local", "api"))
+
+	before, err := os.ReadFile(statePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	plan, err := planUp(lt.ctx, lt.logger, "test", lt.cwd, mergedFile, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The plan is based on the refreshed state
+	actions := make(map[string]PlanAction)
+	for _, container := range plan.Containers {
+		actions[container.Service] = container.Action
+	}
+	assert.Equal(t, PlanActionCreate, actions["api"])
+
+	after, err := os.ReadFile(statePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, before, after)
+
+	// Corrupted state files are left in place for the next command to back up
+	err = os.WriteFile(statePath, []byte("{"), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = planUp(lt.ctx, lt.logger, "test", lt.cwd, mergedFile, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	after, err = os.ReadFile(statePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, []byte("{"), after)
+	assert.NoFileExists(t, statePath+".corrupted")
+}
+
+func TestUpPartialFailure(t *testing.T) {
+	lt := newLifecycleTest(t, testAtlasfile)
+
+	lt.runtime.FailOn(dockertest.OpCreateContainer, "atlas-local-api", errors.New("port is already allocated"))
+
+	assert.ErrorContains(t, lt.up(), "port is already allocated")
+
+	// db was started before api failed
+	containers := lt.runtime.Containers()
+	if len(containers) != 1 {
+		t.Fatalf("expected one container, got %v", containers)
+	}
+	assert.True(t, strings.HasPrefix(containers[0], "atlas-local-db-"))
+
+	// The state file tracks everything created before the failure
+	statefile := lt.savedState()
+	assert.Equal(t, containers[0], lt.containerOf(statefile, "local", "db"))
+	assert.Nil(t, statefile.GetStack("local").GetService("api"))
+	assert.Equal(t, lt.runtime.Networks(), []string{statefile.GetStack("local").Network})
+	if len(statefile.Volumes) != 1 {
+		t.Fatalf("expected one volume, got %v", statefile.Volumes)
+	}
+	assert.Equal(t, "tmp", lt.runtime.Volume(statefile.Volumes[0]).Labels[docker.LabelVolume])
+
+	// Once the failure is resolved, up reuses the resources created by the failed attempt
+	lt.runtime.Reset()
+	networks := lt.runtime.Networks()
+
+	lt.mustUp()
+
+	statefile = lt.savedState()
+	assert.Equal(t, containers[0], lt.containerOf(statefile, "local", "db"))
+	assert.Equal(t, networks, lt.runtime.Networks())
+	assert.Len(t, lt.runtime.Containers(), 2)
+}
+
+func TestUpFailureEnsuringVolumes(t *testing.T) {
+	lt := newLifecycleTest(t, testAtlasfile)
+
+	lt.runtime.FailOn(dockertest.OpCreateVolume, "-tmp-", errors.New("no space left on device"))
+
+	assert.ErrorContains(t, lt.up(), "no space left on device")
+	assert.Empty(t, lt.runtime.Containers())
+
+	// The network was created before the failure and is tracked without services
+	statefile := lt.savedState()
+	if len(statefile.Stacks) != 1 {
+		t.Fatalf("expected one stack, got %d", len(statefile.Stacks))
+	}
+	assert.Empty(t, statefile.Stacks[0].Services)
+	assert.Equal(t, lt.runtime.Networks(), []string{statefile.Stacks[0].Network})
+
+	lt.mustDown(DownOptions{})
+	assert.Empty(t, lt.runtime.Networks())
+}
+
+func TestUpFailureRecreatingService(t *testing.T) {
+	lt := newUpLifecycleTest(t)
+
+	before := lt.savedState()
+
+	// Changing db recreates it, api is recreated with it as it depends on db
+	lt.writeAtlasfile(strings.Replace(testAtlasfile, "postgres:15", "postgres:16", 1))
+	lt.runtime.FailOn(dockertest.OpCreateContainer, "atlas-local-db", errors.New("image platform mismatch"))
+
+	assert.ErrorContains(t, lt.up(), "image platform mismatch")
+
+	// The outdated db container was removed, api was not reconciled and keeps its container
+	statefile := lt.state()
+	assert.Nil(t, statefile.GetStack("local").GetService("db"))
+	assert.Equal(t, lt.containerOf(before, "local", "api"), lt.containerOf(statefile, "local", "api"))
+	assert.Equal(t, []string{lt.containerOf(before, "local", "api")}, lt.runtime.Containers())
+
+	lt.runtime.Reset()
+
+	lt.mustDown(DownOptions{Volumes: true})
+	assert.Empty(t, lt.runtime.Containers())
+	assert.Empty(t, lt.runtime.Networks())
+	assert.Empty(t, lt.runtime.Volumes())
+}
+
+func TestDownAfterPartialFailure(t *testing.T) {
+	lt := newLifecycleTest(t, testAtlasfile)
+
+	lt.runtime.FailOn(dockertest.OpCreateContainer, "atlas-local-api", errors.New("port is already allocated"))
+
+	if lt.up() == nil {
+		t.Fatal("expected up to fail")
+	}
+
+	// Resources of the failed attempt are found by their labels
+	lt.mustDown(DownOptions{Volumes: true})
+
+	assert.Empty(t, lt.runtime.Containers())
+	assert.Empty(t, lt.runtime.Networks())
+	assert.Empty(t, lt.runtime.Volumes())
+}
+
+func TestDownFailure(t *testing.T) {
+	lt := newUpLifecycleTest(t)
+
+	lt.runtime.FailOn(dockertest.OpRemoveContainer, "atlas-local-api", errors.New("device or resource busy"))
+
+	assert.ErrorContains(t, lt.down(DownOptions{}), "device or resource busy")
+
+	// The stack is kept so down can be retried
+	statefile := lt.state()
+	if statefile.GetStack("local") == nil {
+		t.Fatal("stack local not found")
+	}
+	assert.NotNil(t, statefile.GetStack("local").GetService("api"))
+
+	lt.runtime.Reset()
+
+	lt.mustDown(DownOptions{})
+	assert.Empty(t, lt.runtime.Containers())
+	assert.Empty(t, lt.runtime.Networks())
+}
@@ -13,7 +13,7 @@ func List(ctx context.Context, logger logrus.FieldLogger, version, cwd string) e
 		return fmt.Errorf("could not find root directory: %w", err)
 	}
 
-	mergedFile, err := atlasfile.Collect(ctx, logger, version, cwd)
+	mergedFile, err := atlasfile.Collect(ctx, logger, atlasfile.NewEvalContext(version, cwd, nil, atlasfile.EvalOptions{}))
 	if err != nil {
 		return fmt.Errorf("could not collect atlas files: %w", err)
 	}
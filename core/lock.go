@@ -0,0 +1,84 @@
+package atlas
+
+import (
+	"context"
+	"fmt"
+	"github.com/sirupsen/logrus"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// stateLockKey is the context key marking the state lock of a workspace as held by the current call chain
+type stateLockKey string
+
+func getStateLockPath(rootDir string) string {
+	return filepath.Join(rootDir, ".atlas", "state.lock")
+}
+
+// lockState acquires the advisory lock guarding the state file of a workspace, waiting for other Atlas processes and
+// other call chains of this process to release it. The returned context marks the lock as held, so that commands can
+// hold it across reading, modifying, and writing the state file while readState locks it with the same context as
+// well. The returned function releases the lock, the context must not be used to lock the state file afterwards.
+func lockState(ctx context.Context, rootDir string, logger logrus.FieldLogger) (context.Context, func(), error) {
+	lockPath := getStateLockPath(rootDir)
+
+	if ctx.Value(stateLockKey(lockPath)) != nil {
+		return ctx, func() {}, nil
+	}
+
+	file, err := acquireLockFile(ctx, lockPath, logger)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var once sync.Once
+	return context.WithValue(ctx, stateLockKey(lockPath), true), func() {
+		once.Do(func() {
+			err := unlockFile(file)
+			if err != nil {
+				logger.WithError(err).Warnln("Could not release state lock")
+			}
+
+			_ = file.Close()
+		})
+	}, nil
+}
+
+func acquireLockFile(ctx context.Context, lockPath string, logger logrus.FieldLogger) (*os.File, error) {
+	err := os.MkdirAll(filepath.Dir(lockPath), 0755)
+	if err != nil {
+		return nil, fmt.Errorf("could not create directory: %w", err)
+	}
+
+	file, err := os.OpenFile(lockPath, os.O_CREATE|os.O_RDWR, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("could not open state lock: %w", err)
+	}
+
+	waiting := false
+	for {
+		locked, err := tryLockFile(file)
+		if err != nil {
+			_ = file.Close()
+			return nil, fmt.Errorf("could not lock state: %w", err)
+		}
+
+		if locked {
+			return file, nil
+		}
+
+		if !waiting {
+			logger.Infoln("Waiting for another Atlas process to release the state lock")
+			waiting = true
+		}
+
+		select {
+		case <-ctx.Done():
+			_ = file.Close()
+			return nil, fmt.Errorf("could not lock state: %w", ctx.Err())
+		case <-time.After(100 * time.Millisecond):
+		}
+	}
+}
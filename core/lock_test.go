@@ -0,0 +1,104 @@
+package atlas
+
+import (
+	"context"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestLockState(t *testing.T) {
+	rootDir := t.TempDir()
+	logger := logrus.New()
+
+	lockedCtx, unlock, err := lockState(context.Background(), rootDir, logger)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Reentrant within the call chain holding the lock
+	_, unlockInner, err := lockState(lockedCtx, rootDir, logger)
+	if err != nil {
+		t.Fatal(err)
+	}
+	unlockInner()
+
+	// Other call chains of the process must wait while the lock is held
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	_, _, err = lockState(ctx, rootDir, logger)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	// Locks of other workspaces are independent
+	_, unlockOther, err := lockState(context.Background(), t.TempDir(), logger)
+	if err != nil {
+		t.Fatal(err)
+	}
+	unlockOther()
+
+	// Another process (simulated by a separate file handle) must wait while the lock is held
+	other, err := os.OpenFile(getStateLockPath(rootDir), os.O_RDWR, 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer other.Close()
+
+	locked, err := tryLockFile(other)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.False(t, locked)
+
+	unlock()
+
+	// Calling unlock twice must not release a lock acquired afterwards
+	_, unlockAgain, err := lockState(context.Background(), rootDir, logger)
+	if err != nil {
+		t.Fatal(err)
+	}
+	unlock()
+
+	locked, err = tryLockFile(other)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.False(t, locked)
+
+	unlockAgain()
+
+	locked, err = tryLockFile(other)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.True(t, locked)
+	if err := unlockFile(other); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLockStateWaitsForRelease(t *testing.T) {
+	rootDir := t.TempDir()
+	logger := logrus.New()
+
+	_, unlock, err := lockState(context.Background(), rootDir, logger)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	go func() {
+		time.Sleep(200 * time.Millisecond)
+		unlock()
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, unlockWaiting, err := lockState(ctx, rootDir, logger)
+	if err != nil {
+		t.Fatal(err)
+	}
+	unlockWaiting()
+}
@@ -0,0 +1,23 @@
+//go:build !windows
+
+package atlas
+
+import (
+	"errors"
+	"os"
+	"syscall"
+)
+
+// tryLockFile acquires an exclusive lock on file without blocking and returns false if another process holds it
+func tryLockFile(file *os.File) (bool, error) {
+	err := syscall.Flock(int(file.Fd()), syscall.LOCK_EX|syscall.LOCK_NB)
+	if errors.Is(err, syscall.EWOULDBLOCK) {
+		return false, nil
+	}
+
+	return err == nil, err
+}
+
+func unlockFile(file *os.File) error {
+	return syscall.Flock(int(file.Fd()), syscall.LOCK_UN)
+}
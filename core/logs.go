@@ -0,0 +1,83 @@
+package atlas
+
+import (
+	"context"
+	"fmt"
+	"github.com/brunoscheufler/atlas/atlasfile"
+	"github.com/brunoscheufler/atlas/docker"
+	"github.com/brunoscheufler/atlas/exec"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/sync/errgroup"
+	"os"
+)
+
+// Logs streams logs of all services in stackNames (or all stacks), optionally limited to serviceNames,
+// prefixing each line with stack/service
+func Logs(ctx context.Context, logger logrus.FieldLogger, version, cwd string, stackNames, serviceNames []string, options docker.LogsOptions) error {
+	cwd, err := atlasfile.FindRootDir(cwd)
+	if err != nil {
+		return fmt.Errorf("could not find root directory: %w", err)
+	}
+
+	if !docker.IsRunning(ctx) {
+		return fmt.Errorf("%s is not running", docker.RuntimeName())
+	}
+
+	statefile, err := readState(ctx, cwd, version, logger)
+	if err != nil {
+		return fmt.Errorf("could not read state file: %w", err)
+	}
+
+	if statefile == nil {
+		logger.Infoln("No state file found, nothing to do")
+		return nil
+	}
+
+	stacks, err := statefile.GetStacks(stackNames)
+	if err != nil {
+		return fmt.Errorf("could not get stacks: %w", err)
+	}
+
+	requested := make(map[string]bool, len(serviceNames))
+	for _, serviceName := range serviceNames {
+		requested[serviceName] = false
+	}
+
+	// containers maps stack/service prefixes to container names
+	prefixes := make([]string, 0)
+	containers := make(map[string]string)
+
+	for _, stack := range stacks {
+		for _, service := range stack.Services {
+			if len(serviceNames) > 0 {
+				if _, ok := requested[service.Name]; !ok {
+					continue
+				}
+				requested[service.Name] = true
+			}
+
+			prefix := fmt.Sprintf("%s/%s", stack.Name, service.Name)
+			prefixes = append(prefixes, prefix)
+			containers[prefix] = service.ContainerName
+		}
+	}
+
+	for _, serviceName := range serviceNames {
+		if !requested[serviceName] {
+			return fmt.Errorf("service %s not found", serviceName)
+		}
+	}
+
+	g, ctx := errgroup.WithContext(ctx)
+
+	for _, prefix := range prefixes {
+		prefix := prefix
+
+		g.Go(func() error {
+			stdout := exec.NewPrefixWriter(os.Stdout, prefix)
+			return docker.StreamContainerLogs(ctx, containers[prefix], options, stdout, stdout.WithWriter(os.Stderr))
+		})
+	}
+
+	return g.Wait()
+}
@@ -0,0 +1,137 @@
+package atlas
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// stateSchemaVersion is the version of the state file format written by this version of Atlas. It only changes
+// when the format changes, and must be bumped together with adding a migration to stateMigrations.
+const stateSchemaVersion = 2
+
+// rawState is the generic JSON representation of a state file, so migrations do not depend on the current types
+type rawState = map[string]interface{}
+
+type stateMigration func(state rawState) error
+
+// stateMigrations[i] migrates a state file from schema version i+1 to i+2
+var stateMigrations = []stateMigration{
+	migrateStateV1,
+}
+
+// getSchemaVersion returns the schema version of a state file. State files written before schema versions were
+// introduced do not have one and are version 1.
+func getSchemaVersion(state rawState) (int, error) {
+	value, ok := state["schemaVersion"]
+	if !ok {
+		return 1, nil
+	}
+
+	schemaVersion, ok := value.(float64)
+	if !ok || schemaVersion < 1 || schemaVersion != float64(int(schemaVersion)) {
+		return 0, fmt.Errorf("invalid schema version %v", value)
+	}
+
+	return int(schemaVersion), nil
+}
+
+// migrateState migrates a state file to the current schema version and returns the schema version it was stored with
+func migrateState(state rawState) (int, error) {
+	schemaVersion, err := getSchemaVersion(state)
+	if err != nil {
+		return 0, err
+	}
+
+	if schemaVersion > stateSchemaVersion {
+		return schemaVersion, fmt.Errorf("state file has schema version %d, which is newer than the supported version %d, please upgrade Atlas", schemaVersion, stateSchemaVersion)
+	}
+
+	for v := schemaVersion; v < stateSchemaVersion; v++ {
+		err = stateMigrations[v-1](state)
+		if err != nil {
+			return schemaVersion, fmt.Errorf("could not migrate state file from schema version %d to %d: %w", v, v+1, err)
+		}
+
+		state["schemaVersion"] = v + 1
+	}
+
+	return schemaVersion, nil
+}
+
+// migrateStateV1 assigns volumes to the services they were created for. Version 1 only tracked a flat list of
+// volume names like atlas-<stack>-<service>-<volume>-<suffix>, all of which were ephemeral.
+func migrateStateV1(state rawState) error {
+	volumes, _ := state["volumes"].([]interface{})
+	stacks, _ := state["stacks"].([]interface{})
+
+	type candidate struct {
+		prefix  string
+		service map[string]interface{}
+	}
+
+	candidates := make([]candidate, 0)
+	for _, s := range stacks {
+		stack, ok := s.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("invalid stack %v", s)
+		}
+
+		services, _ := stack["services"].([]interface{})
+		for _, svc := range services {
+			service, ok := svc.(map[string]interface{})
+			if !ok {
+				return fmt.Errorf("invalid service %v", svc)
+			}
+
+			service["volumes"] = make([]interface{}, 0)
+			candidates = append(candidates, candidate{
+				prefix:  fmt.Sprintf("atlas-%s-%s-", stack["name"], service["name"]),
+				service: service,
+			})
+		}
+	}
+
+	// Match the longest prefix first, stack and service names may contain dashes
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return len(candidates[i].prefix) > len(candidates[j].prefix)
+	})
+
+	for _, v := range volumes {
+		physicalName, ok := v.(string)
+		if !ok {
+			return fmt.Errorf("invalid volume %v", v)
+		}
+
+		for _, c := range candidates {
+			if !strings.HasPrefix(physicalName, c.prefix) {
+				continue
+			}
+
+			// Strip the randomized suffix
+			name := strings.TrimPrefix(physicalName, c.prefix)
+			if i := strings.LastIndex(name, "-"); i > 0 {
+				name = name[:i]
+			}
+
+			c.service["volumes"] = append(c.service["volumes"].([]interface{}), map[string]interface{}{
+				"name":         name,
+				"physicalName": physicalName,
+			})
+			break
+		}
+	}
+
+	return nil
+}
+
+// decodeState decodes a migrated state file
+func decodeState(state rawState, stateFile *Statefile) error {
+	marshalled, err := json.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("could not marshal state file: %w", err)
+	}
+
+	return json.Unmarshal(marshalled, stateFile)
+}
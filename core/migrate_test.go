@@ -0,0 +1,84 @@
+package atlas
+
+import (
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadStatefileMigratesV1(t *testing.T) {
+	rootDir := t.TempDir()
+
+	// State file written by a release before schema versions were introduced
+	v1 := `{
+		"version": "0.1.0",
+		"stacks": [
+			{
+				"name": "local",
+				"network": "atlas-local-1234",
+				"services": [
+					{"name": "db", "containerName": "atlas-local-db-5678", "containerInfo": null},
+					{"name": "db-replica", "containerName": "atlas-local-db-replica-9012", "containerInfo": null}
+				]
+			}
+		],
+		"volumes": ["atlas-local-db-data-3456", "atlas-local-db-replica-data-7890", "atlas-gone-db-data-1111"]
+	}`
+
+	if err := os.MkdirAll(filepath.Dir(getStatefilePath(rootDir)), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(getStatefilePath(rootDir), []byte(v1), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	stateFile, err := loadStatefile(rootDir, logrus.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, &Statefile{
+		SchemaVersion: stateSchemaVersion,
+		Version:       "0.1.0",
+		Stacks: []StateStack{
+			{
+				Name:    "local",
+				Network: "atlas-local-1234",
+				Services: []StateService{
+					{
+						Name:          "db",
+						ContainerName: "atlas-local-db-5678",
+						Volumes:       []StateVolume{{Name: "data", PhysicalName: "atlas-local-db-data-3456"}},
+					},
+					{
+						Name:          "db-replica",
+						ContainerName: "atlas-local-db-replica-9012",
+						Volumes:       []StateVolume{{Name: "data", PhysicalName: "atlas-local-db-replica-data-7890"}},
+					},
+				},
+			},
+		},
+		Volumes: []string{"atlas-local-db-data-3456", "atlas-local-db-replica-data-7890", "atlas-gone-db-data-1111"},
+	}, stateFile)
+}
+
+func TestMigrateStateNewerSchema(t *testing.T) {
+	_, err := migrateState(rawState{"schemaVersion": float64(stateSchemaVersion + 1)})
+	assert.ErrorContains(t, err, "please upgrade Atlas")
+
+	_, err = migrateState(rawState{"schemaVersion": "2"})
+	assert.ErrorContains(t, err, "invalid schema version")
+
+	schemaVersion, err := migrateState(rawState{"schemaVersion": float64(stateSchemaVersion)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, stateSchemaVersion, schemaVersion)
+}
+
+func TestStateMigrations(t *testing.T) {
+	// Every schema version before the current one needs a migration
+	assert.Len(t, stateMigrations, stateSchemaVersion-1)
+}
@@ -0,0 +1,621 @@
+package atlas
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/brunoscheufler/atlas/atlasfile"
+	"github.com/brunoscheufler/atlas/docker"
+	"github.com/sirupsen/logrus"
+	"io"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+)
+
+type PlanAction string
+
+const (
+	PlanActionCreate   PlanAction = "create"
+	PlanActionRecreate PlanAction = "recreate"
+	PlanActionStart    PlanAction = "start"
+	PlanActionKeep     PlanAction = "keep"
+	PlanActionRemove   PlanAction = "remove"
+)
+
+// UpPlan lists the changes atlas up would make without making them. Names of resources that
+// would be created end in a placeholder instead of their random suffix.
+type UpPlan struct {
+	// Artifacts are built layer by layer, artifacts of a layer are built in parallel
+	Artifacts  [][]PlannedArtifact `json:"artifacts"`
+	Networks   []PlannedNetwork    `json:"networks"`
+	Volumes    []PlannedVolume     `json:"volumes"`
+	Containers []PlannedContainer  `json:"containers"`
+}
+
+type PlannedArtifact struct {
+	Name  string `json:"name"`
+	Image string `json:"image"`
+
+	// Cached is true if the image was built from the same inputs before and will not be rebuilt
+	Cached bool `json:"cached"`
+}
+
+type PlannedNetwork struct {
+	Action PlanAction `json:"action"`
+	Stack  string     `json:"stack"`
+	Name   string     `json:"name"`
+}
+
+type PlannedVolume struct {
+	Action  PlanAction `json:"action"`
+	Stack   string     `json:"stack"`
+	Service string     `json:"service"`
+	Volume  string     `json:"volume"`
+	Name    string     `json:"name"`
+
+	// Persistent volumes are kept when their stack is removed
+	Persistent bool `json:"persistent"`
+}
+
+type PlannedContainer struct {
+	Action  PlanAction `json:"action"`
+	Reason  string     `json:"reason,omitempty"`
+	Stack   string     `json:"stack"`
+	Service string     `json:"service"`
+	Name    string     `json:"name"`
+
+	// Args are docker run arguments equivalent to the container that is created, with secret environment values masked
+	Args         []string `json:"args,omitempty"`
+	JoinNetworks []string `json:"joinNetworks,omitempty"`
+}
+
+const plannedNameSuffix = "****"
+
+// Plan prints the changes atlas up would make to stacks, or to individual services of a stack
+func Plan(ctx context.Context, logger logrus.FieldLogger, version, cwd string, stackNames []string, evalOptions atlasfile.EvalOptions, serviceNames []string, jsonOutput bool) error {
+	cwd, err := atlasfile.FindRootDir(cwd)
+	if err != nil {
+		return fmt.Errorf("could not find root directory: %w", err)
+	}
+
+	mergedFile, err := atlasfile.Collect(ctx, logger, atlasfile.NewEvalContext(version, cwd, stackNames, evalOptions))
+	if err != nil {
+		return fmt.Errorf("could not collect atlas files: %w", err)
+	}
+
+	if !docker.IsRunning(ctx) {
+		return fmt.Errorf("%s is not running", docker.RuntimeName())
+	}
+
+	return printUpPlan(ctx, logger, version, cwd, mergedFile, stackNames, serviceNames, jsonOutput)
+}
+
+func printUpPlan(ctx context.Context, logger logrus.FieldLogger, version, cwd string, mergedFile *atlasfile.Atlasfile, stackNames []string, serviceNames []string, jsonOutput bool) error {
+	var plan *UpPlan
+	var err error
+	if len(serviceNames) > 0 {
+		if len(stackNames) != 1 {
+			return fmt.Errorf("exactly one stack must be specified when planning individual services")
+		}
+
+		plan, err = planUpServices(ctx, logger, version, cwd, mergedFile, stackNames[0], serviceNames)
+	} else {
+		plan, err = planUp(ctx, logger, version, cwd, mergedFile, stackNames)
+	}
+	if err != nil {
+		return fmt.Errorf("could not plan changes: %w", err)
+	}
+
+	if jsonOutput {
+		marshaled, err := json.MarshalIndent(plan, "", "  ")
+		if err != nil {
+			return fmt.Errorf("could not marshal plan: %w", err)
+		}
+
+		fmt.Println(string(marshaled))
+		return nil
+	}
+
+	plan.print(os.Stdout)
+
+	return nil
+}
+
+// planUp mirrors Up: artifacts are rebuilt if their inputs changed, networks and volumes recorded in the state file
+// are reused, and only containers whose config hash changed are recreated. Stacks missing from the Atlasfiles are
+// removed if no stacks were selected.
+func planUp(ctx context.Context, logger logrus.FieldLogger, version, cwd string, mergedFile *atlasfile.Atlasfile, stackNames []string) (*UpPlan, error) {
+	stacks, err := mergedFile.GetStacks(stackNames)
+	if err != nil {
+		return nil, fmt.Errorf("could not get stacks: %w", err)
+	}
+
+	services, err := getRequiredServicesForStacks(stacks, mergedFile)
+	if err != nil {
+		return nil, fmt.Errorf("could not get required services: %w", err)
+	}
+
+	immediateArtifacts, err := getImmediateArtifactsNeededByServices(services, mergedFile)
+	if err != nil {
+		return nil, fmt.Errorf("could not get artifacts: %w", err)
+	}
+
+	plan := &UpPlan{}
+
+	plan.Artifacts, err = planArtifacts(ctx, mergedFile, immediateArtifacts)
+	if err != nil {
+		return nil, err
+	}
+
+	statefile, err := previewState(ctx, cwd, version, logger)
+	if err != nil {
+		return nil, fmt.Errorf("could not read state file: %w", err)
+	}
+
+	if statefile == nil {
+		statefile = &Statefile{}
+	}
+
+	if stackNames == nil {
+		for _, stateStack := range statefile.Stacks {
+			if mergedFile.GetStack(stateStack.Name) != nil {
+				continue
+			}
+
+			for _, service := range stateStack.Services {
+				plan.Containers = append(plan.Containers, PlannedContainer{
+					Action:  PlanActionRemove,
+					Reason:  "stack was removed",
+					Stack:   stateStack.Name,
+					Service: service.Name,
+					Name:    service.ContainerName,
+				})
+
+				for _, volume := range service.Volumes {
+					// Persistent volumes are kept when removing stacks
+					if volume.Persistent {
+						continue
+					}
+
+					plan.Volumes = append(plan.Volumes, PlannedVolume{
+						Action:  PlanActionRemove,
+						Stack:   stateStack.Name,
+						Service: service.Name,
+						Volume:  volume.Name,
+						Name:    volume.PhysicalName,
+					})
+				}
+			}
+
+			plan.Networks = append(plan.Networks, PlannedNetwork{Action: PlanActionRemove, Stack: stateStack.Name, Name: stateStack.Network})
+		}
+	}
+
+	existingNetworks := statefile.ensuredNetworks()
+	ensuredNetworks := make(docker.EnsuredNetworks, 0, len(stacks))
+
+	for _, stack := range stacks {
+		network := PlannedNetwork{Action: PlanActionCreate, Stack: stack.Name, Name: fmt.Sprintf("atlas-%s-%s", stack.Name, plannedNameSuffix)}
+
+		if netName := existingNetworks.Get(stack.Name); netName != "" {
+			networkId, err := docker.GetNetworkId(ctx, netName)
+			if err != nil {
+				return nil, fmt.Errorf("could not get network id: %w", err)
+			}
+
+			if networkId != "" {
+				network.Action = PlanActionKeep
+				network.Name = netName
+			}
+		}
+
+		plan.Networks = append(plan.Networks, network)
+		ensuredNetworks = append(ensuredNetworks, docker.EnsuredNetwork{Stack: stack.Name, PhysicalName: network.Name})
+	}
+
+	// Services may join networks of stacks that are already up
+	for _, network := range existingNetworks {
+		if ensuredNetworks.Get(network.Stack) == "" {
+			ensuredNetworks = append(ensuredNetworks, network)
+		}
+	}
+
+	existingVolumes := statefile.ensuredVolumes()
+
+	for _, stack := range stacks {
+		stack := stack
+
+		var ensuredVolumes docker.EnsuredVolumes
+		for _, stackService := range stack.Services {
+			serviceVolumes, err := plan.addVolumes(ctx, cwd, stack.Name, mergedFile.GetService(stackService.Name), existingVolumes)
+			if err != nil {
+				return nil, err
+			}
+
+			ensuredVolumes = append(ensuredVolumes, serviceVolumes...)
+		}
+
+		stateStack := statefile.GetStack(stack.Name)
+
+		for _, stackService := range stack.Services {
+			config, hash, err := getServiceContainerConfig(ctx, &stack, mergedFile, stackService.Name, ensuredVolumes, ensuredNetworks)
+			if err != nil {
+				return nil, err
+			}
+
+			container := PlannedContainer{
+				Action:  PlanActionCreate,
+				Stack:   stack.Name,
+				Service: stackService.Name,
+				Name:    fmt.Sprintf("atlas-%s-%s-%s", stack.Name, stackService.Name, plannedNameSuffix),
+			}
+
+			var existing *StateService
+			if stateStack != nil {
+				existing = stateStack.GetService(stackService.Name)
+			}
+
+			switch {
+			case existing == nil:
+			case existing.ConfigHash != hash:
+				container.Action = PlanActionRecreate
+				container.Reason = "configuration or image changed"
+			case existing.ContainerInfos != nil && existing.ContainerInfos.State != "running":
+				container.Action = PlanActionStart
+				container.Name = existing.ContainerName
+			default:
+				container.Action = PlanActionKeep
+				container.Name = existing.ContainerName
+
+				if service := mergedFile.GetService(stackService.Name); service.Artifact != nil {
+					container.Reason = "recreated if the rebuilt image changed"
+				}
+			}
+
+			if container.Action == PlanActionCreate || container.Action == PlanActionRecreate {
+				container.Args = maskSecretArgs(config.Args())
+				container.JoinNetworks = config.JoinNetworks
+			}
+
+			plan.Containers = append(plan.Containers, container)
+		}
+
+		if stateStack != nil {
+			for _, service := range stateStack.Services {
+				if stack.GetService(service.Name) != nil {
+					continue
+				}
+
+				plan.Containers = append(plan.Containers, PlannedContainer{
+					Action:  PlanActionRemove,
+					Reason:  "service was removed from stack",
+					Stack:   stack.Name,
+					Service: service.Name,
+					Name:    service.ContainerName,
+				})
+			}
+		}
+	}
+
+	return plan, nil
+}
+
+// planUpServices mirrors upServices: artifacts of the services and their dependents are rebuilt if their inputs
+// changed, and the service containers are recreated on the existing stack network
+func planUpServices(ctx context.Context, logger logrus.FieldLogger, version, cwd string, mergedFile *atlasfile.Atlasfile, stackName string, serviceNames []string) (*UpPlan, error) {
+	stack := mergedFile.GetStack(stackName)
+	if stack == nil {
+		return nil, fmt.Errorf("could not find stack %s", stackName)
+	}
+
+	statefile, err := previewState(ctx, cwd, version, logger)
+	if err != nil {
+		return nil, fmt.Errorf("could not read state file: %w", err)
+	}
+
+	var stateStack *StateStack
+	if statefile != nil {
+		stateStack = statefile.GetStack(stackName)
+	}
+
+	if stateStack == nil {
+		return nil, fmt.Errorf("stack %s is not up, run atlas up -s %s first", stackName, stackName)
+	}
+
+	services := make([]atlasfile.ServiceConfig, 0, len(serviceNames))
+	for _, serviceName := range serviceNames {
+		if stack.GetService(serviceName) == nil {
+			return nil, fmt.Errorf("service %s not found in stack %s", serviceName, stackName)
+		}
+
+		services = append(services, *mergedFile.GetService(serviceName))
+	}
+
+	artifacts, err := getArtifactsToRebuild(mergedFile, services)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := &UpPlan{}
+
+	plan.Artifacts, err = planArtifacts(ctx, mergedFile, artifacts)
+	if err != nil {
+		return nil, err
+	}
+
+	ensuredNetworks := statefile.ensuredNetworks()
+	existingVolumes := statefile.ensuredVolumes()
+
+	for _, service := range services {
+		service := service
+
+		ensuredVolumes, err := plan.addVolumes(ctx, cwd, stackName, &service, existingVolumes)
+		if err != nil {
+			return nil, err
+		}
+
+		config, _, err := getServiceContainerConfig(ctx, stack, mergedFile, service.Name, ensuredVolumes, ensuredNetworks)
+		if err != nil {
+			return nil, err
+		}
+
+		container := PlannedContainer{
+			Action:       PlanActionCreate,
+			Stack:        stackName,
+			Service:      service.Name,
+			Name:         fmt.Sprintf("atlas-%s-%s-%s", stackName, service.Name, plannedNameSuffix),
+			Args:         maskSecretArgs(config.Args()),
+			JoinNetworks: config.JoinNetworks,
+		}
+
+		if stateStack.GetService(service.Name) != nil {
+			container.Action = PlanActionRecreate
+			container.Reason = "service was selected"
+		}
+
+		plan.Containers = append(plan.Containers, container)
+	}
+
+	return plan, nil
+}
+
+// planArtifacts mirrors buildArtifacts: artifacts whose input hash matches their existing image are not rebuilt
+func planArtifacts(ctx context.Context, file *atlasfile.Atlasfile, immediateArtifacts []atlasfile.ArtifactConfig) ([][]PlannedArtifact, error) {
+	artifactGraph, err := buildArtifactGraphWithImmediate(file, immediateArtifacts)
+	if err != nil {
+		return nil, fmt.Errorf("could not build artifact graph: %w", err)
+	}
+
+	layers, err := artifactGraph.TopologicalSortWithLayers()
+	if err != nil {
+		return nil, fmt.Errorf("could not topologically sort artifacts: %w", err)
+	}
+
+	hashes := make(map[string]string)
+	plannedLayers := make([][]PlannedArtifact, 0, len(layers))
+	for _, layer := range layers {
+		plannedLayer := make([]PlannedArtifact, 0, len(layer))
+
+		for _, artifactName := range layer {
+			artifact := file.GetArtifact(artifactName)
+			if artifact == nil {
+				return nil, fmt.Errorf("could not find artifact %s", artifactName)
+			}
+
+			dependencyHashes := make(map[string]string)
+			for _, dependency := range artifactGraph.NodesWithEdgeToN(artifactName) {
+				dependencyHashes[dependency] = hashes[dependency]
+			}
+
+			hash, err := docker.HashArtifactInputs(artifact, dependencyHashes)
+			if err != nil {
+				return nil, err
+			}
+			hashes[artifactName] = hash
+
+			cached, err := docker.IsArtifactCached(ctx, artifact, hash)
+			if err != nil {
+				return nil, fmt.Errorf("could not check cached image of artifact %s: %w", artifact.Name, err)
+			}
+
+			plannedLayer = append(plannedLayer, PlannedArtifact{Name: artifact.Name, Image: atlasfile.BuildImageName(artifact), Cached: cached})
+		}
+
+		plannedLayers = append(plannedLayers, plannedLayer)
+	}
+
+	return plannedLayers, nil
+}
+
+// addVolumes plans the volumes of a stack service like docker.EnsureServiceVolumes and returns them as ensured volumes
+func (p *UpPlan) addVolumes(ctx context.Context, rootDir, stackName string, service *atlasfile.ServiceConfig, existing docker.EnsuredVolumes) (docker.EnsuredVolumes, error) {
+	var ensuredVolumes docker.EnsuredVolumes
+
+	for _, volume := range service.Volumes {
+		if !volume.IsVolume {
+			continue
+		}
+
+		ensured := docker.EnsuredVolume{
+			Stack:      stackName,
+			Service:    service.Name,
+			VolumeName: volume.HostPathOrVolumeName,
+			Persistent: volume.IsPersistent(),
+		}
+
+		action := PlanActionKeep
+
+		for _, prev := range existing {
+			if prev.Stack == stackName && prev.Service == service.Name && prev.VolumeName == volume.HostPathOrVolumeName && prev.Persistent == ensured.Persistent {
+				ensured.PhysicalName = prev.PhysicalName
+			}
+		}
+
+		if ensured.PhysicalName == "" && ensured.Persistent {
+			ensured.PhysicalName = docker.PersistentVolumeName(rootDir, stackName, service.Name, volume.HostPathOrVolumeName)
+
+			exists, err := docker.VolumeExists(ctx, ensured.PhysicalName)
+			if err != nil {
+				return nil, err
+			}
+
+			if !exists {
+				action = PlanActionCreate
+			}
+		}
+
+		if ensured.PhysicalName == "" {
+			action = PlanActionCreate
+			ensured.PhysicalName = fmt.Sprintf("atlas-%s-%s-%s-%s", stackName, service.Name, volume.HostPathOrVolumeName, plannedNameSuffix)
+		}
+
+		p.Volumes = append(p.Volumes, PlannedVolume{
+			Action:     action,
+			Stack:      stackName,
+			Service:    service.Name,
+			Volume:     volume.HostPathOrVolumeName,
+			Name:       ensured.PhysicalName,
+			Persistent: ensured.Persistent,
+		})
+
+		ensuredVolumes = append(ensuredVolumes, ensured)
+	}
+
+	return ensuredVolumes, nil
+}
+
+var planActionSymbols = map[PlanAction]string{
+	PlanActionCreate:   "+",
+	PlanActionRecreate: "~",
+	PlanActionStart:    ">",
+	PlanActionKeep:     "=",
+	PlanActionRemove:   "-",
+}
+
+func (p *UpPlan) print(w io.Writer) {
+	counts := make(map[PlanAction]int)
+
+	fmt.Fprintln(w, "Artifacts:")
+	if len(p.Artifacts) == 0 {
+		fmt.Fprintln(w, "  (none)")
+	}
+	for i, layer := range p.Artifacts {
+		names := make([]string, len(layer))
+		for j, artifact := range layer {
+			names[j] = fmt.Sprintf("%s (%s)", artifact.Name, artifact.Image)
+			if artifact.Cached {
+				names[j] = fmt.Sprintf("%s (%s, cached)", artifact.Name, artifact.Image)
+			}
+		}
+
+		fmt.Fprintf(w, "  %d. build %s\n", i+1, strings.Join(names, ", "))
+	}
+
+	fmt.Fprintln(w, "\nNetworks:")
+	if len(p.Networks) == 0 {
+		fmt.Fprintln(w, "  (none)")
+	}
+	for _, network := range p.Networks {
+		counts[network.Action]++
+		fmt.Fprintf(w, "  %s %s: %s %s\n", planActionSymbols[network.Action], network.Stack, network.Action, network.Name)
+	}
+
+	fmt.Fprintln(w, "\nVolumes:")
+	if len(p.Volumes) == 0 {
+		fmt.Fprintln(w, "  (none)")
+	}
+	for _, volume := range p.Volumes {
+		counts[volume.Action]++
+		line := fmt.Sprintf("  %s %s/%s %s: %s %s", planActionSymbols[volume.Action], volume.Stack, volume.Service, volume.Volume, volume.Action, volume.Name)
+		if volume.Persistent {
+			line += " (persistent)"
+		}
+		fmt.Fprintln(w, line)
+	}
+
+	fmt.Fprintln(w, "\nContainers:")
+	if len(p.Containers) == 0 {
+		fmt.Fprintln(w, "  (none)")
+	}
+	for _, container := range p.Containers {
+		counts[container.Action]++
+
+		line := fmt.Sprintf("  %s %s/%s: %s %s", planActionSymbols[container.Action], container.Stack, container.Service, container.Action, container.Name)
+		if container.Reason != "" {
+			line += fmt.Sprintf(" (%s)", container.Reason)
+		}
+		fmt.Fprintln(w, line)
+
+		if len(container.Args) > 0 {
+			fmt.Fprintf(w, "      docker run -d --name %s %s\n", container.Name, strings.Join(container.Args, " "))
+		}
+
+		for _, network := range container.JoinNetworks {
+			fmt.Fprintf(w, "      docker network connect %s %s\n", network, container.Name)
+		}
+	}
+
+	fmt.Fprintf(
+		w,
+		"\nPlan: %d to create, %d to recreate, %d to start, %d to remove, %d unchanged\n",
+		counts[PlanActionCreate],
+		counts[PlanActionRecreate],
+		counts[PlanActionStart],
+		counts[PlanActionRemove],
+		counts[PlanActionKeep],
+	)
+}
+
+const maskedValue = "****"
+
+// secretKeyParts are parts of environment variable names (split at underscores) that mark their values as secret
+var secretKeyParts = []string{"PASSWORD", "PASSWD", "PASS", "PWD", "SECRET", "TOKEN", "KEY", "APIKEY", "CREDENTIAL", "CREDENTIALS", "PRIVATE", "AUTH"}
+
+// isSecretEnvKey returns true if the environment variable name suggests its value is a secret
+func isSecretEnvKey(key string) bool {
+	for _, part := range strings.Split(strings.ToUpper(key), "_") {
+		for _, secretPart := range secretKeyParts {
+			if part == secretPart {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// maskSecretArgs returns a copy of docker run args with values of secret environment variables and
+// passwords in URLs masked
+func maskSecretArgs(args []string) []string {
+	masked := make([]string, len(args))
+	copy(masked, args)
+
+	for i := 0; i < len(masked)-1; i++ {
+		if masked[i] != "-e" {
+			continue
+		}
+
+		key, quoted, ok := strings.Cut(masked[i+1], "=")
+		if !ok {
+			continue
+		}
+
+		value, err := strconv.Unquote(quoted)
+		if err != nil {
+			value = quoted
+		}
+
+		if isSecretEnvKey(key) {
+			value = maskedValue
+		} else if parsed, err := url.Parse(value); err == nil && parsed.User != nil {
+			value = parsed.Redacted()
+		}
+
+		masked[i+1] = fmt.Sprintf("%s=%q", key, value)
+		i++
+	}
+
+	return masked
+}
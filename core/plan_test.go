@@ -0,0 +1,71 @@
+package atlas
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestMaskSecretArgs(t *testing.T) {
+	args := []string{
+		"--hostname", "api",
+		"-e", `DB_PASSWORD="hunter2"`,
+		"-e", `DATABASE_URL="postgres://atlas:hunter2@db:5432/atlas"`,
+		"-e", `GITHUB_TOKEN="ghp_abc"`,
+		"-e", `PORT="8080"`,
+		"-e", `KEYCLOAK_URL="http://keycloak"`,
+		"api:latest",
+	}
+
+	masked := maskSecretArgs(args)
+
+	assert.Equal(t, []string{
+		"--hostname", "api",
+		"-e", `DB_PASSWORD="****"`,
+		"-e", `DATABASE_URL="postgres://atlas:xxxxx@db:5432/atlas"`,
+		"-e", `GITHUB_TOKEN="****"`,
+		"-e", `PORT="8080"`,
+		"-e", `KEYCLOAK_URL="http://keycloak"`,
+		"api:latest",
+	}, masked)
+
+	// Original args are used to create containers and must not be modified
+	assert.Equal(t, `DB_PASSWORD="hunter2"`, args[3])
+}
+
+func TestPrintUpPlan(t *testing.T) {
+	plan := &UpPlan{
+		Artifacts: [][]PlannedArtifact{
+			{{Name: "base", Image: "atlas-base", Cached: true}},
+			{{Name: "api", Image: "atlas-api"}},
+		},
+		Networks: []PlannedNetwork{
+			{Action: PlanActionKeep, Stack: "local", Name: "atlas-local-1234"},
+		},
+		Containers: []PlannedContainer{
+			{Action: PlanActionRecreate, Reason: "configuration or image changed", Stack: "local", Service: "api", Name: "atlas-local-api-****", Args: []string{"--hostname", "api", "atlas-api"}},
+			{Action: PlanActionRemove, Reason: "service was removed from stack", Stack: "local", Service: "worker", Name: "atlas-local-worker-5678"},
+		},
+	}
+
+	var output bytes.Buffer
+	plan.print(&output)
+
+	assert.Equal(t, `Artifacts:
+  1. build base (atlas-base, cached)
+  2. build api (atlas-api)
+
+Networks:
+  = local: keep atlas-local-1234
+
+Volumes:
+  (none)
+
+Containers:
+  ~ local/api: recreate atlas-local-api-**** (configuration or image changed)
+      docker run -d --name atlas-local-api-**** --hostname api atlas-api
+  - local/worker: remove atlas-local-worker-5678 (service was removed from stack)
+
+Plan: 0 to create, 1 to recreate, 0 to start, 1 to remove, 1 unchanged
+`, output.String())
+}
@@ -15,7 +15,7 @@ func Ps(ctx context.Context, logger logrus.FieldLogger, cwd, version string, sta
 	}
 
 	if !docker.IsRunning(ctx) {
-		return fmt.Errorf("docker is not running")
+		return fmt.Errorf("%s is not running", docker.RuntimeName())
 	}
 
 	statefile, err := readState(ctx, cwd, version, logger)
@@ -0,0 +1,212 @@
+package atlas
+
+import (
+	"context"
+	"fmt"
+	"github.com/brunoscheufler/atlas/atlasfile"
+	"github.com/brunoscheufler/atlas/docker"
+	"github.com/sirupsen/logrus"
+)
+
+// Restart rebuilds the artifacts of running services in a stack and recreates their containers,
+// keeping the stack network, volumes, and all other services
+func Restart(ctx context.Context, logger logrus.FieldLogger, version, cwd string, stackName string, serviceNames []string, evalOptions atlasfile.EvalOptions, options UpOptions) error {
+	cwd, err := atlasfile.FindRootDir(cwd)
+	if err != nil {
+		return fmt.Errorf("could not find root directory: %w", err)
+	}
+
+	logger.WithField("cwd", cwd).Debugf("Found root directory")
+
+	mergedFile, err := atlasfile.Collect(ctx, logger, atlasfile.NewEvalContext(version, cwd, []string{stackName}, evalOptions))
+	if err != nil {
+		return fmt.Errorf("could not collect atlas files: %w", err)
+	}
+
+	if !docker.IsRunning(ctx) {
+		return fmt.Errorf("%s is not running", docker.RuntimeName())
+	}
+
+	return upServices(ctx, logger, version, cwd, mergedFile, stackName, serviceNames, true, options)
+}
+
+// upServices rebuilds the artifacts of services in a stack that is already up, including artifacts depending on them,
+// and recreates only the service containers on the existing network using existing volumes. Only the entries of
+// the services are updated in the state file. If requireRunning is set, all services must already be part of the stack state.
+func upServices(
+	ctx context.Context,
+	logger logrus.FieldLogger,
+	version, cwd string,
+	mergedFile *atlasfile.Atlasfile,
+	stackName string,
+	serviceNames []string,
+	requireRunning bool,
+	options UpOptions,
+) error {
+	stack := mergedFile.GetStack(stackName)
+	if stack == nil {
+		return fmt.Errorf("could not find stack %s", stackName)
+	}
+
+	// Hold the state lock until the state file is written
+	ctx, unlock, err := lockState(ctx, cwd, logger)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	statefile, err := readState(ctx, cwd, version, logger)
+	if err != nil {
+		return fmt.Errorf("could not read state file: %w", err)
+	}
+
+	var stateStack *StateStack
+	if statefile != nil {
+		stateStack = statefile.GetStack(stackName)
+	}
+
+	if stateStack == nil {
+		return fmt.Errorf("stack %s is not up, run atlas up -s %s first", stackName, stackName)
+	}
+
+	services := make([]atlasfile.ServiceConfig, 0, len(serviceNames))
+	for _, serviceName := range serviceNames {
+		if stack.GetService(serviceName) == nil {
+			return fmt.Errorf("service %s not found in stack %s", serviceName, stackName)
+		}
+
+		if requireRunning && stateStack.GetService(serviceName) == nil {
+			return fmt.Errorf("service %s is not running in stack %s", serviceName, stackName)
+		}
+
+		services = append(services, *mergedFile.GetService(serviceName))
+	}
+
+	err = rebuildArtifacts(ctx, logger, mergedFile, services, cwd)
+	if err != nil {
+		return err
+	}
+
+	// Dependencies are resolved against containers of the existing stack
+	for _, service := range stateStack.Services {
+		stack.SetContainerName(service.Name, service.ContainerName)
+	}
+
+	labels := docker.Labels{Root: cwd, Version: version}
+
+	ensuredNetworks := statefile.ensuredNetworks()
+	existingVolumes := statefile.ensuredVolumes()
+
+	for _, service := range services {
+		service := service
+
+		ensuredVolumes, err := docker.EnsureServiceVolumes(ctx, logger, labels, stackName, &service, existingVolumes)
+		statefile.addVolumes(ensuredVolumes)
+		if err != nil {
+			return writePartialState(cwd, statefile, fmt.Errorf("could not ensure volumes: %w", err))
+		}
+
+		config, _, err := getServiceContainerConfig(ctx, stack, mergedFile, service.Name, ensuredVolumes, ensuredNetworks)
+		if err != nil {
+			return writePartialState(cwd, statefile, err)
+		}
+
+		if existing := stateStack.GetService(service.Name); existing != nil {
+			logger.WithField("stack", stackName).Infof("Removing %s\n", service.Name)
+
+			err = docker.DeleteContainer(ctx, logger, existing.ContainerName)
+			if err != nil {
+				return writePartialState(cwd, statefile, fmt.Errorf("could not remove container of service %s: %w", service.Name, err))
+			}
+		}
+
+		stateService, err := createService(ctx, logger, labels, stack, mergedFile, service.Name, config, ensuredVolumes, options.DependencyTimeout)
+		if err != nil {
+			return writePartialState(cwd, statefile, fmt.Errorf("could not start service %s: %w", service.Name, err))
+		}
+
+		stack.SetContainerName(service.Name, stateService.ContainerName)
+
+		stateService.ContainerInfos, err = docker.GetContainerInfo(ctx, stateService.ContainerName)
+		if err != nil {
+			return fmt.Errorf("could not get container infos: %w", err)
+		}
+
+		statefile.setService(stackName, *stateService)
+
+		// Persist after each service so recreated containers are tracked even if a later one fails
+		err = writeStateFileRaw(cwd, statefile)
+		if err != nil {
+			return fmt.Errorf("could not write state: %w", err)
+		}
+	}
+
+	if options.Wait {
+		targeted := atlasfile.StackConfig{Name: stackName}
+		for _, serviceName := range serviceNames {
+			targeted.Services = append(targeted.Services, *stack.GetService(serviceName))
+			targeted.SetContainerName(serviceName, stack.GetContainerName(serviceName))
+		}
+
+		err = waitForStacks(ctx, logger, []atlasfile.StackConfig{targeted}, options.WaitTimeout)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// rebuildArtifacts builds the artifacts of services and all artifacts depending on them
+func rebuildArtifacts(ctx context.Context, logger logrus.FieldLogger, file *atlasfile.Atlasfile, services []atlasfile.ServiceConfig, cwd string) error {
+	artifacts, err := getArtifactsToRebuild(file, services)
+	if err != nil {
+		return err
+	}
+
+	artifactGraph, err := buildArtifactGraphWithImmediate(file, artifacts)
+	if err != nil {
+		return fmt.Errorf("could not build artifact graph: %w", err)
+	}
+
+	err = buildArtifacts(ctx, logger, file, artifactGraph, cwd)
+	if err != nil {
+		return fmt.Errorf("could not build artifacts: %w", err)
+	}
+
+	return nil
+}
+
+// getArtifactsToRebuild returns the artifacts of services followed by all artifacts depending on them
+func getArtifactsToRebuild(file *atlasfile.Atlasfile, services []atlasfile.ServiceConfig) ([]atlasfile.ArtifactConfig, error) {
+	immediateArtifacts, err := getImmediateArtifactsNeededByServices(services, file)
+	if err != nil {
+		return nil, fmt.Errorf("could not get artifacts: %w", err)
+	}
+
+	fullGraph, err := buildArtifactGraph(file)
+	if err != nil {
+		return nil, fmt.Errorf("could not build artifact graph: %w", err)
+	}
+
+	artifacts := make([]atlasfile.ArtifactConfig, 0, len(immediateArtifacts))
+	added := make(map[string]struct{})
+
+	for _, artifact := range immediateArtifacts {
+		for _, name := range append([]string{artifact.Name}, fullGraph.DFS(artifact.Name, -1)...) {
+			if _, ok := added[name]; ok {
+				continue
+			}
+			added[name] = struct{}{}
+
+			dependent := file.GetArtifact(name)
+			if dependent == nil {
+				return nil, fmt.Errorf("could not find artifact %s", name)
+			}
+
+			artifacts = append(artifacts, *dependent)
+		}
+	}
+
+	return artifacts, nil
+}
@@ -0,0 +1,59 @@
+package atlas
+
+import (
+	"github.com/brunoscheufler/atlas/atlasfile"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestGetArtifactsToRebuild(t *testing.T) {
+	testFile := atlasfile.MergeAtlasFiles([]atlasfile.Atlasfile{
+		{
+			Artifacts: []atlasfile.ArtifactConfig{
+				{Name: "base"},
+				{Name: "api", DependsOn: atlasfile.ArtifactDependsOn{Artifacts: []string{"base"}}},
+			},
+			Services: []atlasfile.ServiceConfig{
+				{Name: "api", Artifact: &atlasfile.ArtifactRef{Name: "api"}},
+				{Name: "db", Image: "postgres:14"},
+				{
+					Name: "tool",
+					Artifact: &atlasfile.ArtifactRef{
+						Artifact: &atlasfile.ArtifactConfig{
+							Name:      "tool",
+							DependsOn: atlasfile.ArtifactDependsOn{Services: []string{"api"}},
+						},
+					},
+				},
+			},
+		},
+	})
+
+	artifacts, err := getArtifactsToRebuild(testFile, []atlasfile.ServiceConfig{*testFile.GetService("api"), *testFile.GetService("db")})
+	assert.NoError(t, err)
+
+	names := make([]string, len(artifacts))
+	for i, artifact := range artifacts {
+		names[i] = artifact.Name
+	}
+
+	// base is not a dependent, it is added by buildArtifactGraphWithImmediate as a dependency of api
+	assert.Equal(t, []string{"api", "tool"}, names)
+}
+
+func TestStatefileSetService(t *testing.T) {
+	statefile := &Statefile{
+		Stacks: []StateStack{
+			{Name: "local", Services: []StateService{{Name: "api", ContainerName: "atlas-local-api-1"}, {Name: "db", ContainerName: "atlas-local-db-1"}}},
+		},
+	}
+
+	statefile.setService("local", StateService{Name: "api", ContainerName: "atlas-local-api-2"})
+	statefile.setService("local", StateService{Name: "worker", ContainerName: "atlas-local-worker-1"})
+
+	assert.Equal(t, []StateService{
+		{Name: "api", ContainerName: "atlas-local-api-2"},
+		{Name: "db", ContainerName: "atlas-local-db-1"},
+		{Name: "worker", ContainerName: "atlas-local-worker-1"},
+	}, statefile.Stacks[0].Services)
+}
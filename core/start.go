@@ -15,7 +15,7 @@ func Start(ctx context.Context, logger logrus.FieldLogger, version, cwd string,
 	}
 
 	if !docker.IsRunning(ctx) {
-		return fmt.Errorf("docker is not running")
+		return fmt.Errorf("%s is not running", docker.RuntimeName())
 	}
 
 	statefile, err := readState(ctx, cwd, version, logger)
@@ -1,21 +1,28 @@
 package atlas
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"github.com/brunoscheufler/atlas/atlasfile"
 	"github.com/brunoscheufler/atlas/docker"
 	"github.com/sirupsen/logrus"
-	"golang.org/x/sync/errgroup"
+	"io"
 	"os"
 	"path/filepath"
 )
 
 type Statefile struct {
+	// SchemaVersion is the version of the state file format, see stateSchemaVersion
+	SchemaVersion int `json:"schemaVersion"`
+
+	// Version is the version of Atlas that last wrote the state file
 	Version string       `json:"version"`
 	Stacks  []StateStack `json:"stacks"`
-	Volumes []string     `json:"volumes"`
+
+	// Volumes lists ephemeral volumes, persistent volumes are not removed with their stack
+	Volumes []string `json:"volumes"`
 }
 
 func (s *Statefile) GetStack(stackName string) *StateStack {
@@ -67,13 +74,152 @@ type StateService struct {
 
 	ContainerName  string                 `json:"containerName"`
 	ContainerInfos *docker.ContainerInfos `json:"containerInfo"`
+
+	// ConfigHash is the hash of the effective container config, used to recreate only containers whose config changed
+	ConfigHash string `json:"configHash"`
+
+	// Volumes lists volumes mounted into the service container so they can be reused when recreating it
+	Volumes []StateVolume `json:"volumes"`
+}
+
+type StateVolume struct {
+	Name         string `json:"name"`
+	PhysicalName string `json:"physicalName"`
+	Persistent   bool   `json:"persistent,omitempty"`
+}
+
+// setStack replaces the stack or adds it if it does not exist yet
+func (s *Statefile) setStack(stack StateStack) {
+	for i := range s.Stacks {
+		if s.Stacks[i].Name == stack.Name {
+			s.Stacks[i] = stack
+			return
+		}
+	}
+
+	s.Stacks = append(s.Stacks, stack)
+}
+
+// removeStack removes the stack and the volumes of its services
+func (s *Statefile) removeStack(stackName string) {
+	stacks := make([]StateStack, 0, len(s.Stacks))
+	removedVolumes := make(map[string]struct{})
+
+	for _, stack := range s.Stacks {
+		if stack.Name != stackName {
+			stacks = append(stacks, stack)
+			continue
+		}
+
+		for _, service := range stack.Services {
+			for _, volume := range service.Volumes {
+				removedVolumes[volume.PhysicalName] = struct{}{}
+			}
+		}
+	}
+
+	volumes := make([]string, 0, len(s.Volumes))
+	for _, volume := range s.Volumes {
+		if _, ok := removedVolumes[volume]; !ok {
+			volumes = append(volumes, volume)
+		}
+	}
+
+	s.Stacks = stacks
+	s.Volumes = volumes
+}
+
+// addVolumes adds ephemeral physical volumes that are not tracked yet
+func (s *Statefile) addVolumes(volumes docker.EnsuredVolumes) {
+	for _, volume := range volumes {
+		if volume.Persistent {
+			continue
+		}
+
+		found := false
+		for _, existing := range s.Volumes {
+			if existing == volume.PhysicalName {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			s.Volumes = append(s.Volumes, volume.PhysicalName)
+		}
+	}
+}
+
+// setService replaces the service in a stack or adds it if it does not exist yet
+func (s *Statefile) setService(stackName string, service StateService) {
+	for i := range s.Stacks {
+		if s.Stacks[i].Name != stackName {
+			continue
+		}
+
+		for j := range s.Stacks[i].Services {
+			if s.Stacks[i].Services[j].Name == service.Name {
+				s.Stacks[i].Services[j] = service
+				return
+			}
+		}
+
+		s.Stacks[i].Services = append(s.Stacks[i].Services, service)
+		return
+	}
+}
+
+// setNetworks sets the network of each stack, adding stacks that do not exist yet
+func (s *Statefile) setNetworks(networks docker.EnsuredNetworks) {
+	for _, network := range networks {
+		stack := s.GetStack(network.Stack)
+		if stack == nil {
+			stack = &StateStack{Name: network.Stack, Services: make([]StateService, 0)}
+		}
+
+		stack.Network = network.PhysicalName
+		s.setStack(*stack)
+	}
+}
+
+// ensuredNetworks returns the networks of all stacks in the state file
+func (s *Statefile) ensuredNetworks() docker.EnsuredNetworks {
+	networks := make(docker.EnsuredNetworks, 0, len(s.Stacks))
+	for _, stack := range s.Stacks {
+		networks = append(networks, docker.EnsuredNetwork{
+			Stack:        stack.Name,
+			PhysicalName: stack.Network,
+		})
+	}
+	return networks
+}
+
+// ensuredVolumes returns the volumes of all services in the state file
+func (s *Statefile) ensuredVolumes() docker.EnsuredVolumes {
+	volumes := make(docker.EnsuredVolumes, 0)
+	for _, stack := range s.Stacks {
+		for _, service := range stack.Services {
+			for _, volume := range service.Volumes {
+				volumes = append(volumes, docker.EnsuredVolume{
+					Stack:        stack.Name,
+					Service:      service.Name,
+					VolumeName:   volume.Name,
+					PhysicalName: volume.PhysicalName,
+					Persistent:   volume.Persistent,
+				})
+			}
+		}
+	}
+	return volumes
 }
 
 func getStatefilePath(cwd string) string {
 	return filepath.Join(cwd, ".atlas", "state.json")
 }
 
-func refreshState(ctx context.Context, rootDir string, stateFile *Statefile) error {
+// refreshState updates the containers of the state with their current infos and drops containers and stacks that
+// no longer exist
+func refreshState(ctx context.Context, stateFile *Statefile) error {
 	newStacks := make([]StateStack, 0)
 
 	for _, stack := range stateFile.Stacks {
@@ -89,11 +235,8 @@ func refreshState(ctx context.Context, rootDir string, stateFile *Statefile) err
 				continue
 			}
 
-			currentServices = append(currentServices, StateService{
-				Name:           service.Name,
-				ContainerName:  service.ContainerName,
-				ContainerInfos: infos,
-			})
+			service.ContainerInfos = infos
+			currentServices = append(currentServices, service)
 		}
 
 		stack.Services = currentServices
@@ -104,30 +247,99 @@ func refreshState(ctx context.Context, rootDir string, stateFile *Statefile) err
 			return fmt.Errorf("could not get network id: %w", err)
 		}
 
-		if networkId != "" && len(currentServices) > 0 {
+		// Stacks without containers are kept while their network exists, so that it is removed with the stack
+		if networkId != "" || len(currentServices) > 0 {
 			newStacks = append(newStacks, stack)
 		}
 	}
 
 	stateFile.Stacks = newStacks
 
-	err := writeStateFileRaw(rootDir, stateFile)
+	return nil
+}
+
+// readState reads the state file and refreshes it with the current state of containers. Resources labeled with the
+// root directory that are missing from the state file are added, so that a lost, corrupted, or outdated state file can
+// be recovered.
+func readState(ctx context.Context, rootDir, version string, logger logrus.FieldLogger) (*Statefile, error) {
+	ctx, unlock, err := lockState(ctx, rootDir, logger)
 	if err != nil {
-		return fmt.Errorf("could not write state file: %w", err)
+		return nil, err
 	}
+	defer unlock()
 
-	return nil
+	stateFile, err := loadStatefile(rootDir, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	stateFile, err = discoverState(ctx, rootDir, version, stateFile, logger)
+	if err != nil || stateFile == nil {
+		return nil, err
+	}
+
+	err = writeStateFileRaw(rootDir, stateFile)
+	if err != nil {
+		return nil, fmt.Errorf("could not write state file: %w", err)
+	}
+
+	return stateFile, nil
 }
 
-func readState(ctx context.Context, rootDir, version string, logger logrus.FieldLogger) (*Statefile, error) {
-	stateFile := Statefile{}
+// previewState returns the state readState would return without writing the state file or backing up a corrupted
+// one, for commands that must not change the workspace like atlas plan
+func previewState(ctx context.Context, rootDir, version string, logger logrus.FieldLogger) (*Statefile, error) {
+	stateFile, err := decodeStatefile(rootDir, logger, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return discoverState(ctx, rootDir, version, stateFile, logger)
+}
 
+// discoverState adds labeled resources of the root directory missing from stateFile, which may be nil, and refreshes
+// the result. It returns nil if there is neither a state file nor labeled resources.
+func discoverState(ctx context.Context, rootDir, version string, stateFile *Statefile, logger logrus.FieldLogger) (*Statefile, error) {
+	resources, err := docker.FindLabeledResources(ctx, rootDir)
+	if err != nil {
+		return nil, fmt.Errorf("could not find labeled resources: %w", err)
+	}
+
+	discovered := stateFromResources(version, resources)
+
+	if stateFile == nil {
+		if len(discovered.Stacks) == 0 && len(discovered.Volumes) == 0 {
+			return nil, nil
+		}
+
+		logger.Infof("Recovered %d stacks from container labels\n", len(discovered.Stacks))
+		stateFile = discovered
+	} else {
+		stateFile.merge(discovered)
+	}
+
+	err = refreshState(ctx, stateFile)
+	if err != nil {
+		return nil, fmt.Errorf("could not refresh state: %w", err)
+	}
+
+	return stateFile, nil
+}
+
+// loadStatefile reads the state file and migrates it to the current schema version, returning nil if it does not
+// exist or is corrupted
+func loadStatefile(rootDir string, logger logrus.FieldLogger) (*Statefile, error) {
+	return decodeStatefile(rootDir, logger, true)
+}
+
+// decodeStatefile reads the state file and migrates it to the current schema version, returning nil if it does not
+// exist or is corrupted. Corrupted state files are only backed up if backupCorrupted is set.
+func decodeStatefile(rootDir string, logger logrus.FieldLogger, backupCorrupted bool) (*Statefile, error) {
 	stateFilePath := getStatefilePath(rootDir)
 
 	_, err := os.Stat(stateFilePath)
 	if err != nil {
 		if os.IsNotExist(err) {
-
 			return nil, nil
 		}
 
@@ -139,101 +351,218 @@ func readState(ctx context.Context, rootDir, version string, logger logrus.Field
 		return nil, fmt.Errorf("could not read state file: %w", err)
 	}
 
-	err = json.Unmarshal(marshalled, &stateFile)
+	state := make(rawState)
+	err = json.Unmarshal(marshalled, &state)
 	if err != nil {
-		return nil, fmt.Errorf("could not unmarshal state file: %w", err)
+		return nil, recoverCorruptedStatefile(rootDir, marshalled, err, backupCorrupted, logger)
 	}
 
-	if stateFile.Version != version {
-		_ = clearStatefile(rootDir)
-		logger.Warnf("Found an existing state file from older (current: %s, stored: %s) version. Please clean remaining resources manually or run atlas down --all to remove all containers and volumes.\n", version, stateFile.Version)
-		return nil, nil
+	schemaVersion, err := migrateState(state)
+	if err != nil {
+		return nil, err
 	}
 
-	err = refreshState(ctx, rootDir, &stateFile)
+	stateFile := Statefile{}
+	err = decodeState(state, &stateFile)
 	if err != nil {
-		return nil, fmt.Errorf("could not refresh state: %w", err)
+		return nil, recoverCorruptedStatefile(rootDir, marshalled, err, backupCorrupted, logger)
+	}
+
+	if schemaVersion != stateSchemaVersion {
+		logger.Infof("Migrated state file from schema version %d to %d\n", schemaVersion, stateSchemaVersion)
 	}
 
 	return &stateFile, nil
 }
 
-func clearStatefile(rootDir string) error {
+// recoverCorruptedStatefile moves a state file that could not be decoded out of the way if backup is set, so resources are
+// recovered from labels
+func recoverCorruptedStatefile(rootDir string, data []byte, err error, backup bool, logger logrus.FieldLogger) error {
 	stateFilePath := getStatefilePath(rootDir)
 
-	err := os.Remove(stateFilePath)
-	if err != nil {
-		return fmt.Errorf("could not remove state file: %w", err)
+	reason := fmt.Sprintf("is corrupted (%s)", err.Error())
+	if isTruncatedJSON(data, err) {
+		reason = "is truncated"
+	}
+
+	if !backup {
+		logger.Warnf("State file %s, recovering resources from labels\n", reason)
+		return nil
+	}
+
+	// Keep the broken state file for inspection
+	backupPath := stateFilePath + ".corrupted"
+	if renameErr := os.Rename(stateFilePath, backupPath); renameErr != nil {
+		_ = clearStatefile(rootDir)
+		backupPath = "-"
 	}
 
+	logger.Warnf("State file %s, recovering resources from labels (backup: %s)\n", reason, backupPath)
 	return nil
 }
 
-func writeState(ctx context.Context, rootDir, version string, stacks []atlasfile.StackConfig, volumes docker.EnsuredVolumes, networks docker.EnsuredNetworks) error {
-	stateStacks := make([]StateStack, len(stacks))
+// stateFromResources builds a state file from labeled resources. Services are only added if the network of their stack exists.
+func stateFromResources(version string, resources *docker.LabeledResources) *Statefile {
+	stateFile := &Statefile{SchemaVersion: stateSchemaVersion, Version: version}
 
-	for i := range stacks {
-		services := make([]StateService, len(stacks[i].Services))
-		stack := stacks[i]
+	networks := make(map[string]string)
+	for _, network := range resources.Networks {
+		networks[network.Stack] = network.Name
+	}
 
-		{
-			g, ctx := errgroup.WithContext(ctx)
-			for j := range stack.Services {
-				j := j
-				svc := stack.Services[j]
+	for _, network := range resources.Networks {
+		stateFile.setStack(StateStack{Name: network.Stack, Network: network.Name, Services: make([]StateService, 0)})
+	}
 
-				g.Go(func() error {
-					containerName := stack.GetContainerName(svc.Name)
-					containerInfos, err := docker.GetContainerInfo(ctx, containerName)
-					if err != nil {
-						return fmt.Errorf("could not get container infos: %w", err)
-					}
+	for _, container := range resources.Containers {
+		if _, ok := networks[container.Stack]; !ok {
+			continue
+		}
 
-					services[j] = StateService{
-						Name:           svc.Name,
-						ContainerName:  containerName,
-						ContainerInfos: containerInfos,
-					}
+		stateFile.setService(container.Stack, StateService{
+			Name:           container.Service,
+			ContainerName:  container.Name,
+			ContainerInfos: container.Infos,
+			ConfigHash:     container.ConfigHash,
+		})
+	}
 
-					return nil
-				})
+	for _, volume := range resources.Volumes {
+		for i := range stateFile.Stacks {
+			if stateFile.Stacks[i].Name != volume.Stack {
+				continue
 			}
 
-			err := g.Wait()
-			if err != nil {
-				return fmt.Errorf("could not write container state: %w", err)
+			for j := range stateFile.Stacks[i].Services {
+				service := &stateFile.Stacks[i].Services[j]
+				if service.Name == volume.Service {
+					service.Volumes = append(service.Volumes, StateVolume{Name: volume.Volume, PhysicalName: volume.Name, Persistent: volume.Persistent})
+				}
 			}
 		}
 
-		stateStacks[i] = StateStack{
-			Name:     stack.Name,
-			Services: services,
-			Network:  networks.Get(stack.Name),
+		if !volume.Persistent {
+			stateFile.Volumes = append(stateFile.Volumes, volume.Name)
 		}
 	}
 
-	volumeNames := make([]string, len(volumes))
-	for i := range volumes {
-		volumeNames[i] = volumes[i].PhysicalName
+	return stateFile
+}
+
+// merge adds stacks, services, and ephemeral volumes of other that are missing from the state file
+func (s *Statefile) merge(other *Statefile) {
+	for _, stack := range other.Stacks {
+		existing := s.GetStack(stack.Name)
+		if existing == nil {
+			s.setStack(stack)
+			continue
+		}
+
+		if existing.Network != stack.Network {
+			continue
+		}
+
+		for _, service := range stack.Services {
+			if existing.GetService(service.Name) == nil {
+				s.setService(stack.Name, service)
+			}
+		}
+	}
+
+	for _, volume := range other.Volumes {
+		found := false
+		for _, existing := range s.Volumes {
+			if existing == volume {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			s.Volumes = append(s.Volumes, volume)
+		}
+	}
+}
+
+func clearStatefile(rootDir string) error {
+	stateFilePath := getStatefilePath(rootDir)
+
+	err := os.Remove(stateFilePath)
+	if err != nil {
+		return fmt.Errorf("could not remove state file: %w", err)
+	}
+
+	return nil
+}
+
+// stateVolumes returns the volumes ensured for a stack service
+func stateVolumes(volumes docker.EnsuredVolumes, stackName, serviceName string) []StateVolume {
+	var serviceVolumes []StateVolume
+	for _, volume := range volumes {
+		if volume.Stack == stackName && volume.Service == serviceName {
+			serviceVolumes = append(serviceVolumes, StateVolume{
+				Name:         volume.VolumeName,
+				PhysicalName: volume.PhysicalName,
+				Persistent:   volume.Persistent,
+			})
+		}
 	}
+	return serviceVolumes
+}
 
-	stateFile := Statefile{
-		Version: version,
-		Stacks:  stateStacks,
-		Volumes: volumeNames,
+// isTruncatedJSON returns true if data failed to unmarshal because it ended early, e.g. when a write was interrupted
+func isTruncatedJSON(data []byte, err error) bool {
+	if len(bytes.TrimSpace(data)) == 0 || errors.Is(err, io.ErrUnexpectedEOF) {
+		return true
 	}
 
-	return writeStateFileRaw(rootDir, &stateFile)
+	// encoding/json does not export a sentinel for this error
+	var syntaxErr *json.SyntaxError
+	return errors.As(err, &syntaxErr) && syntaxErr.Error() == "unexpected end of JSON input"
 }
 
+// writeStateFileRaw writes the state file to a temporary file first and renames it afterwards, so readers
+// never see a partially written state file. Callers must hold the state lock.
 func writeStateFileRaw(rootDir string, stateFile *Statefile) error {
+	stateFile.SchemaVersion = stateSchemaVersion
+
 	marshalled, err := json.Marshal(stateFile)
 	if err != nil {
 		return fmt.Errorf("could not marshal state file: %w", err)
 	}
 
-	err = os.WriteFile(getStatefilePath(rootDir), marshalled, 0644)
+	stateFilePath := getStatefilePath(rootDir)
+
+	err = os.MkdirAll(filepath.Dir(stateFilePath), 0755)
+	if err != nil {
+		return fmt.Errorf("could not create directory: %w", err)
+	}
+
+	tmpFile, err := os.CreateTemp(filepath.Dir(stateFilePath), "state.json.tmp-*")
+	if err != nil {
+		return fmt.Errorf("could not create temporary state file: %w", err)
+	}
+
+	_, err = tmpFile.Write(marshalled)
+	if err == nil {
+		err = tmpFile.Sync()
+	}
+
+	closeErr := tmpFile.Close()
+	if err == nil {
+		err = closeErr
+	}
+
+	if err == nil {
+		err = os.Chmod(tmpFile.Name(), 0644)
+	}
+
+	if err == nil {
+		err = os.Rename(tmpFile.Name(), stateFilePath)
+	}
+
 	if err != nil {
+		_ = os.Remove(tmpFile.Name())
 		return fmt.Errorf("could not write state file: %w", err)
 	}
 
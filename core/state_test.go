@@ -0,0 +1,134 @@
+package atlas
+
+import (
+	"encoding/json"
+	"github.com/brunoscheufler/atlas/docker"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestStateFromResources(t *testing.T) {
+	resources := &docker.LabeledResources{
+		Containers: []docker.LabeledContainer{
+			{Name: "atlas-local-db-1", Stack: "local", Service: "db", ConfigHash: "a"},
+			{Name: "atlas-local-api-1", Stack: "local", Service: "api", ConfigHash: "b"},
+			{Name: "atlas-other-api-1", Stack: "other", Service: "api"},
+		},
+		Networks: []docker.LabeledNetwork{
+			{Name: "atlas-local-1", Stack: "local"},
+		},
+		Volumes: []docker.LabeledVolume{
+			{Name: "atlas-local-db-data-1", Stack: "local", Service: "db", Volume: "data", Persistent: true},
+			{Name: "atlas-local-api-cache-1", Stack: "local", Service: "api", Volume: "cache"},
+			{Name: "atlas-gone-db-data-1", Stack: "gone", Service: "db", Volume: "data", Persistent: true},
+		},
+	}
+
+	// Stack other is skipped because its network is missing
+	assert.Equal(t, &Statefile{
+		SchemaVersion: stateSchemaVersion,
+		Version:       "1.0.0",
+		Stacks: []StateStack{
+			{
+				Name:    "local",
+				Network: "atlas-local-1",
+				Services: []StateService{
+					{
+						Name:          "db",
+						ContainerName: "atlas-local-db-1",
+						ConfigHash:    "a",
+						Volumes:       []StateVolume{{Name: "data", PhysicalName: "atlas-local-db-data-1", Persistent: true}},
+					},
+					{
+						Name:          "api",
+						ContainerName: "atlas-local-api-1",
+						ConfigHash:    "b",
+						Volumes:       []StateVolume{{Name: "cache", PhysicalName: "atlas-local-api-cache-1"}},
+					},
+				},
+			},
+		},
+		Volumes: []string{"atlas-local-api-cache-1"},
+	}, stateFromResources("1.0.0", resources))
+}
+
+func TestStatefileMerge(t *testing.T) {
+	statefile := &Statefile{
+		Stacks: []StateStack{
+			{Name: "local", Network: "atlas-local-1", Services: []StateService{{Name: "db", ContainerName: "atlas-local-db-1"}}},
+		},
+		Volumes: []string{"atlas-local-db-data-1"},
+	}
+
+	statefile.merge(&Statefile{
+		Stacks: []StateStack{
+			{Name: "local", Network: "atlas-local-1", Services: []StateService{{Name: "db", ContainerName: "atlas-local-db-2"}, {Name: "api", ContainerName: "atlas-local-api-1"}}},
+			{Name: "other", Network: "atlas-other-1", Services: []StateService{{Name: "api", ContainerName: "atlas-other-api-1"}}},
+		},
+		Volumes: []string{"atlas-local-db-data-1", "atlas-local-api-cache-1"},
+	})
+
+	assert.Equal(t, &Statefile{
+		Stacks: []StateStack{
+			{Name: "local", Network: "atlas-local-1", Services: []StateService{{Name: "db", ContainerName: "atlas-local-db-1"}, {Name: "api", ContainerName: "atlas-local-api-1"}}},
+			{Name: "other", Network: "atlas-other-1", Services: []StateService{{Name: "api", ContainerName: "atlas-other-api-1"}}},
+		},
+		Volumes: []string{"atlas-local-db-data-1", "atlas-local-api-cache-1"},
+	}, statefile)
+}
+
+func TestIsTruncatedJSON(t *testing.T) {
+	unmarshal := func(data string) error {
+		var stateFile Statefile
+		return json.Unmarshal([]byte(data), &stateFile)
+	}
+
+	for _, data := range []string{"", "  ", `{"version":"1.0.0","stacks":[`, `{"version":"1.0`} {
+		assert.True(t, isTruncatedJSON([]byte(data), unmarshal(data)), data)
+	}
+
+	for _, data := range []string{`{"version":1}`, `{"version":"1.0.0"}}`, `not json`} {
+		assert.False(t, isTruncatedJSON([]byte(data), unmarshal(data)), data)
+	}
+}
+
+func TestLoadStatefile(t *testing.T) {
+	rootDir := t.TempDir()
+	logger := logrus.New()
+
+	stateFile := &Statefile{SchemaVersion: stateSchemaVersion, Version: "1.0.0", Stacks: []StateStack{{Name: "local"}}}
+	if err := writeStateFileRaw(rootDir, stateFile); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := loadStatefile(rootDir, logger)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, stateFile, loaded)
+
+	// No temporary files are left behind
+	entries, err := os.ReadDir(filepath.Join(rootDir, ".atlas"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Len(t, entries, 1)
+
+	// A truncated state file is moved aside
+	statePath := getStatefilePath(rootDir)
+	if err := os.WriteFile(statePath, []byte(`{"version":"1.0.0","sta`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err = loadStatefile(rootDir, logger)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Nil(t, loaded)
+
+	assert.NoFileExists(t, statePath)
+	assert.FileExists(t, statePath+".corrupted")
+}
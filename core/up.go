@@ -2,15 +2,39 @@ package atlas
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"github.com/brunoscheufler/atlas/atlasfile"
 	"github.com/brunoscheufler/atlas/docker"
+	"github.com/brunoscheufler/atlas/graph"
 	"github.com/brunoscheufler/atlas/helper"
 	"github.com/sirupsen/logrus"
 	"golang.org/x/sync/errgroup"
+	"strings"
+	"time"
 )
 
-func Up(ctx context.Context, logger logrus.FieldLogger, version, cwd string, stackNames []string) error {
+// DefaultWaitTimeout is the default maximum duration to wait for services
+const DefaultWaitTimeout = 2 * time.Minute
+
+type UpOptions struct {
+	// Services limits up to services of a single stack, which must already be up. Only their containers are recreated.
+	Services []string
+
+	// DependencyTimeout limits how long a service waits for each of its dependencies to meet its condition.
+	// Services wait for their dependencies without a limit if it is zero.
+	DependencyTimeout time.Duration
+
+	// Wait blocks until all services are healthy, or running if they have no healthcheck
+	Wait        bool
+	WaitTimeout time.Duration
+
+	// DryRun prints the changes up would make instead of making them, as JSON if JSON is set
+	DryRun bool
+	JSON   bool
+}
+
+func Up(ctx context.Context, logger logrus.FieldLogger, version, cwd string, stackNames []string, evalOptions atlasfile.EvalOptions, options UpOptions) error {
 	logger.WithFields(
 		logrus.Fields{
 			"version": version,
@@ -26,18 +50,25 @@ func Up(ctx context.Context, logger logrus.FieldLogger, version, cwd string, sta
 
 	logger.WithField("cwd", cwd).Debugf("Found root directory")
 
-	mergedFile, err := atlasfile.Collect(ctx, logger, version, cwd)
+	mergedFile, err := atlasfile.Collect(ctx, logger, atlasfile.NewEvalContext(version, cwd, stackNames, evalOptions))
 	if err != nil {
 		return fmt.Errorf("could not collect atlas files: %w", err)
 	}
 
 	if !docker.IsRunning(ctx) {
-		return fmt.Errorf("docker is not running")
+		return fmt.Errorf("%s is not running", docker.RuntimeName())
 	}
 
-	err = Down(ctx, logger, cwd, version, stackNames, false)
-	if err != nil {
-		return fmt.Errorf("could not down: %w", err)
+	if options.DryRun {
+		return printUpPlan(ctx, logger, version, cwd, mergedFile, stackNames, options.Services, options.JSON)
+	}
+
+	if len(options.Services) > 0 {
+		if len(stackNames) != 1 {
+			return fmt.Errorf("exactly one stack must be specified when starting individual services")
+		}
+
+		return upServices(ctx, logger, version, cwd, mergedFile, stackNames[0], options.Services, false, options)
 	}
 
 	stacks, err := mergedFile.GetStacks(stackNames)
@@ -51,73 +82,365 @@ func Up(ctx context.Context, logger logrus.FieldLogger, version, cwd string, sta
 	}
 
 	immediateArtifacts, err := getImmediateArtifactsNeededByServices(services, mergedFile)
+	if err != nil {
+		return fmt.Errorf("could not get artifacts: %w", err)
+	}
 
 	// Build artifacts
 	artifactGraph, err := buildArtifactGraphWithImmediate(mergedFile, immediateArtifacts)
-
-	layers, err := artifactGraph.TopologicalSortWithLayers()
 	if err != nil {
-		return fmt.Errorf("could not topologically sort artifacts: %w", err)
+		return fmt.Errorf("could not build artifact graph: %w", err)
 	}
 
-	err = buildArtifacts(ctx, logger, mergedFile, layers, cwd)
+	err = buildArtifacts(ctx, logger, mergedFile, artifactGraph, cwd)
 	if err != nil {
 		return fmt.Errorf("could not build artifacts: %w", err)
 	}
 
-	ensuredNetworks, err := docker.EnsureNetworks(ctx, logger, stacks, mergedFile)
+	// Hold the state lock until the state file is written
+	ctx, unlock, err := lockState(ctx, cwd, logger)
 	if err != nil {
-		return fmt.Errorf("could not ensure networks: %w", err)
+		return err
 	}
+	defer unlock()
 
-	ensuredVolumes, err := docker.EnsureVolumes(ctx, logger, stacks, mergedFile)
+	statefile, err := readState(ctx, cwd, version, logger)
 	if err != nil {
-		return fmt.Errorf("could not ensure volumes: %w", err)
+		return fmt.Errorf("could not read state file: %w", err)
+	}
+
+	if statefile == nil {
+		statefile = &Statefile{}
+	}
+	statefile.Version = version
+
+	labels := docker.Labels{Root: cwd, Version: version}
+
+	// Remove stacks that are no longer declared when reconciling all stacks
+	if stackNames == nil {
+		for _, stateStack := range statefile.Stacks {
+			if mergedFile.GetStack(stateStack.Name) != nil {
+				continue
+			}
+
+			err = removeStack(ctx, logger, cwd, stateStack)
+			if err != nil {
+				return writePartialState(cwd, statefile, fmt.Errorf("could not remove stack %s: %w", stateStack.Name, err))
+			}
+
+			err = deleteStackVolumes(ctx, logger, cwd, stateStack, false)
+			if err != nil {
+				return writePartialState(cwd, statefile, err)
+			}
+
+			statefile.removeStack(stateStack.Name)
+		}
+	}
+
+	// Resources are recorded as soon as they are created, and the state file is written when a later step fails, so
+	// that a failed up never leaves resources behind that down cannot find
+	ensuredNetworks, err := docker.EnsureNetworks(ctx, logger, labels, stacks, mergedFile, statefile.ensuredNetworks())
+	statefile.setNetworks(ensuredNetworks)
+	if err != nil {
+		return writePartialState(cwd, statefile, fmt.Errorf("could not ensure networks: %w", err))
+	}
+
+	ensuredVolumes, err := docker.EnsureVolumes(ctx, logger, labels, stacks, mergedFile, statefile.ensuredVolumes())
+	statefile.addVolumes(ensuredVolumes)
+	if err != nil {
+		return writePartialState(cwd, statefile, fmt.Errorf("could not ensure volumes: %w", err))
+	}
+
+	// Services may join networks of stacks that are already up
+	for _, network := range statefile.ensuredNetworks() {
+		if ensuredNetworks.Get(network.Stack) == "" {
+			ensuredNetworks = append(ensuredNetworks, network)
+		}
 	}
 
 	for i := range stacks {
 		logger.Infof("Launching stack %s\n", stacks[i].Name)
 
-		err := startStack(ctx, logger, &stacks[i], mergedFile, services, ensuredVolumes, ensuredNetworks)
+		stateStack := statefile.GetStack(stacks[i].Name)
+
+		stateServices, err := reconcileStack(ctx, logger, labels, &stacks[i], mergedFile, stateStack, ensuredVolumes, ensuredNetworks, options.DependencyTimeout)
+		if err != nil && stateStack != nil {
+			// Services that were not reconciled before the error keep their previous containers
+			for _, service := range stateStack.Services {
+				if !hasStateService(stateServices, service.Name) {
+					stateServices = append(stateServices, service)
+				}
+			}
+		}
+
+		statefile.setStack(StateStack{
+			Name:     stacks[i].Name,
+			Network:  ensuredNetworks.Get(stacks[i].Name),
+			Services: stateServices,
+		})
+
 		if err != nil {
-			return fmt.Errorf("could not start stack %q: %w", stacks[i].Name, err)
+			return writePartialState(cwd, statefile, fmt.Errorf("could not start stack %q: %w", stacks[i].Name, err))
 		}
 	}
 
-	err = writeState(ctx, cwd, version, stacks, ensuredVolumes, ensuredNetworks)
+	err = writeStateFileRaw(cwd, statefile)
 	if err != nil {
 		return fmt.Errorf("could not write state: %w", err)
 	}
 
+	if options.Wait {
+		err = waitForStacks(ctx, logger, stacks, options.WaitTimeout)
+		if err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
-func startStack(
+// writePartialState writes the state file after up failed with err, so that resources created before the error are
+// tracked, and returns err
+func writePartialState(cwd string, statefile *Statefile, err error) error {
+	writeErr := writeStateFileRaw(cwd, statefile)
+	if writeErr != nil {
+		return fmt.Errorf("%w (could not write state: %v)", err, writeErr)
+	}
+
+	return err
+}
+
+func hasStateService(services []StateService, serviceName string) bool {
+	for _, service := range services {
+		if service.Name == serviceName {
+			return true
+		}
+	}
+	return false
+}
+
+// reconcileStack starts services in layers of the service graph, starting all services of a layer in parallel.
+// Containers of services whose config did not change are kept, all others are recreated once the dependencies
+// of the service met their condition. Containers of services that were removed from the stack are deleted.
+func reconcileStack(
 	ctx context.Context,
 	logger logrus.FieldLogger,
+	labels docker.Labels,
 	stack *atlasfile.StackConfig,
 	file *atlasfile.Atlasfile,
-	services []atlasfile.ServiceConfig,
+	stateStack *StateStack,
 	ensuredVolumes docker.EnsuredVolumes,
 	ensuredNetworks docker.EnsuredNetworks,
-) error {
-	for j := range stack.Services {
-		stackService := &stack.Services[j]
-		service := file.GetService(stackService.Name)
+	dependencyTimeout time.Duration,
+) ([]StateService, error) {
+	serviceGraph, err := file.BuildServiceGraph(stack)
+	if err != nil {
+		return nil, fmt.Errorf("could not build service graph: %w", err)
+	}
+
+	layers, err := serviceGraph.TopologicalSortWithLayers()
+	if err != nil {
+		return nil, fmt.Errorf("could not topologically sort services: %w", err)
+	}
+
+	stateServices := make([]StateService, 0, len(stack.Services))
+
+	for _, layer := range layers {
+		results := make([]*StateService, len(layer))
+
+		g, ctx := errgroup.WithContext(ctx)
+
+		for i, serviceName := range layer {
+			i, serviceName := i, serviceName
+
+			var existing *StateService
+			if stateStack != nil {
+				existing = stateStack.GetService(serviceName)
+			}
+
+			g.Go(func() error {
+				result, err := reconcileService(ctx, logger, labels, stack, file, serviceName, existing, ensuredVolumes, ensuredNetworks, dependencyTimeout)
+				if err != nil {
+					return err
+				}
+
+				results[i] = result
+
+				return nil
+			})
+		}
 
-		logger.WithField("stack", stack.Name).Infoln(fmt.Sprintf("Starting %s", services[j].Name))
+		err := g.Wait()
 
-		containerName := helper.RandomizedName(fmt.Sprintf("atlas-%s-%s", stack.Name, service.Name))
+		// Record containers started in this layer even if others failed
+		for _, result := range results {
+			if result != nil {
+				stack.SetContainerName(result.Name, result.ContainerName)
+				stateServices = append(stateServices, *result)
+			}
+		}
 
-		err := docker.CreateServiceContainer(ctx, logger, stack, service, stackService, file, ensuredVolumes, ensuredNetworks, containerName)
 		if err != nil {
-			return fmt.Errorf("could not create service container: %w", err)
+			return stateServices, err
+		}
+	}
+
+	if stateStack != nil {
+		for _, service := range stateStack.Services {
+			if stack.GetService(service.Name) != nil {
+				continue
+			}
+
+			logger.WithField("stack", stack.Name).Infoln(fmt.Sprintf("Removing %s", service.Name))
+
+			err = docker.DeleteContainer(ctx, logger, service.ContainerName)
+			if err != nil {
+				return stateServices, fmt.Errorf("could not remove container of service %s: %w", service.Name, err)
+			}
 		}
+	}
 
-		stack.SetContainerName(service.Name, containerName)
+	for i := range stateServices {
+		stateServices[i].ContainerInfos, err = docker.GetContainerInfo(ctx, stateServices[i].ContainerName)
+		if err != nil {
+			return stateServices, fmt.Errorf("could not get container infos: %w", err)
+		}
 	}
 
-	return nil
+	return stateServices, nil
+}
+
+// reconcileService keeps the existing container of a service if its config did not change, and recreates it otherwise
+func reconcileService(
+	ctx context.Context,
+	logger logrus.FieldLogger,
+	labels docker.Labels,
+	stack *atlasfile.StackConfig,
+	file *atlasfile.Atlasfile,
+	serviceName string,
+	existing *StateService,
+	ensuredVolumes docker.EnsuredVolumes,
+	ensuredNetworks docker.EnsuredNetworks,
+	dependencyTimeout time.Duration,
+) (*StateService, error) {
+	config, hash, err := getServiceContainerConfig(ctx, stack, file, serviceName, ensuredVolumes, ensuredNetworks)
+	if err != nil {
+		return nil, err
+	}
+
+	if existing != nil && existing.ConfigHash == hash {
+		if existing.ContainerInfos != nil && existing.ContainerInfos.State != "running" {
+			logger.WithField("stack", stack.Name).Infoln(fmt.Sprintf("Starting %s", serviceName))
+
+			err = docker.StartContainer(ctx, existing.ContainerName)
+			if err != nil {
+				return nil, fmt.Errorf("could not start container of service %s: %w", serviceName, err)
+			}
+		} else {
+			logger.WithField("stack", stack.Name).Infoln(fmt.Sprintf("%s is up to date", serviceName))
+		}
+
+		return &StateService{
+			Name:          serviceName,
+			ContainerName: existing.ContainerName,
+			ConfigHash:    hash,
+			Volumes:       stateVolumes(ensuredVolumes, stack.Name, serviceName),
+		}, nil
+	}
+
+	if existing != nil {
+		logger.WithField("stack", stack.Name).Infoln(fmt.Sprintf("Removing outdated %s", serviceName))
+
+		err = docker.DeleteContainer(ctx, logger, existing.ContainerName)
+		if err != nil {
+			return nil, fmt.Errorf("could not remove container of service %s: %w", serviceName, err)
+		}
+	}
+
+	return createService(ctx, logger, labels, stack, file, serviceName, config, ensuredVolumes, dependencyTimeout)
+}
+
+// getServiceContainerConfig returns the container config of a stack service and its hash
+func getServiceContainerConfig(
+	ctx context.Context,
+	stack *atlasfile.StackConfig,
+	file *atlasfile.Atlasfile,
+	serviceName string,
+	ensuredVolumes docker.EnsuredVolumes,
+	ensuredNetworks docker.EnsuredNetworks,
+) (*docker.ContainerConfig, string, error) {
+	config, err := docker.GetServiceContainerConfig(stack, file.GetService(serviceName), stack.GetService(serviceName), file, ensuredVolumes, ensuredNetworks)
+	if err != nil {
+		return nil, "", fmt.Errorf("could not get container config of service %s: %w", serviceName, err)
+	}
+
+	hash, err := getContainerConfigHash(ctx, config)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return config, hash, nil
+}
+
+func getContainerConfigHash(ctx context.Context, config *docker.ContainerConfig) (string, error) {
+	imageId, err := docker.GetImageId(ctx, config.Image)
+	if err != nil {
+		return "", fmt.Errorf("could not get image id: %w", err)
+	}
+
+	return config.Hash(imageId)
+}
+
+// createService waits up to dependencyTimeout for each dependency of a stack service to meet its condition and creates
+// the labeled container of the service
+func createService(
+	ctx context.Context,
+	logger logrus.FieldLogger,
+	labels docker.Labels,
+	stack *atlasfile.StackConfig,
+	file *atlasfile.Atlasfile,
+	serviceName string,
+	config *docker.ContainerConfig,
+	ensuredVolumes docker.EnsuredVolumes,
+	dependencyTimeout time.Duration,
+) (*StateService, error) {
+	stackService := stack.GetService(serviceName)
+
+	for _, dependency := range file.GetServiceDependencies(stackService) {
+		logger.WithField("stack", stack.Name).Infoln(fmt.Sprintf("Waiting for %s to be %s before starting %s", dependency.Service, dependency.Condition, serviceName))
+
+		err := waitForDependency(ctx, stack.GetContainerName(dependency.Service), dependency.Condition, dependencyTimeout)
+		if err != nil {
+			return nil, fmt.Errorf("dependency %s of service %s did not become %s: %w", dependency.Service, serviceName, dependency.Condition, err)
+		}
+	}
+
+	logger.WithField("stack", stack.Name).Infoln(fmt.Sprintf("Starting %s", serviceName))
+
+	containerName := helper.RandomizedName(fmt.Sprintf("atlas-%s-%s", stack.Name, serviceName))
+
+	// The image must exist locally for its ID to be part of the hash
+	err := docker.PullImage(ctx, logger, config.Image)
+	if err != nil {
+		return nil, err
+	}
+
+	hash, err := getContainerConfigHash(ctx, config)
+	if err != nil {
+		return nil, err
+	}
+
+	err = docker.CreateContainer(ctx, logger, containerName, config, labels.Service(stack.Name, serviceName, hash))
+	if err != nil {
+		return nil, fmt.Errorf("could not create service container: %w", err)
+	}
+
+	return &StateService{
+		Name:          serviceName,
+		ContainerName: containerName,
+		ConfigHash:    hash,
+		Volumes:       stateVolumes(ensuredVolumes, stack.Name, serviceName),
+	}, nil
 }
 
 func getImmediateArtifactsNeededByServices(services []atlasfile.ServiceConfig, file *atlasfile.Atlasfile) ([]atlasfile.ArtifactConfig, error) {
@@ -169,12 +492,37 @@ func getRequiredServices(stack atlasfile.StackConfig, file *atlasfile.Atlasfile)
 	return services
 }
 
-func buildArtifacts(ctx context.Context, logger logrus.FieldLogger, file *atlasfile.Atlasfile, layers [][]string, cwd string) error {
+// buildArtifacts builds artifacts in layers of the artifact graph, building all artifacts of a layer in parallel. An
+// artifact is only built if the hash of its inputs, including the hashes of its dependencies, differs from the hash
+// its existing image was built from.
+func buildArtifacts(ctx context.Context, logger logrus.FieldLogger, file *atlasfile.Atlasfile, artifactGraph *graph.Graph[string], cwd string) error {
+	layers, err := artifactGraph.TopologicalSortWithLayers()
+	if err != nil {
+		return fmt.Errorf("could not topologically sort artifacts: %w", err)
+	}
+
+	hashes := make(map[string]string)
+	built := make([]string, 0)
+	cached := make([]string, 0)
+
 	for _, layer := range layers {
+		type buildResult struct {
+			hash   string
+			cached bool
+		}
+
+		results := make([]buildResult, len(layer))
+
 		g, ctx := errgroup.WithContext(ctx)
 
-		for _, artifactName := range layer {
-			artifactName := artifactName
+		for i, artifactName := range layer {
+			i, artifactName := i, artifactName
+
+			// Dependencies were built in previous layers
+			dependencyHashes := make(map[string]string)
+			for _, dependency := range artifactGraph.NodesWithEdgeToN(artifactName) {
+				dependencyHashes[dependency] = hashes[dependency]
+			}
 
 			g.Go(func() error {
 				artifact := file.GetArtifact(artifactName)
@@ -182,7 +530,24 @@ func buildArtifacts(ctx context.Context, logger logrus.FieldLogger, file *atlasf
 					return fmt.Errorf("could not find artifact %s", artifactName)
 				}
 
-				err := docker.BuildArtifact(ctx, logger, artifact, cwd)
+				hash, err := docker.HashArtifactInputs(artifact, dependencyHashes)
+				if err != nil {
+					return err
+				}
+
+				isCached, err := docker.IsArtifactCached(ctx, artifact, hash)
+				if err != nil {
+					return fmt.Errorf("could not check cached image of artifact %s: %w", artifact.Name, err)
+				}
+
+				results[i] = buildResult{hash: hash, cached: isCached}
+
+				if isCached {
+					logger.WithField("artifact", artifact.Name).Infoln("Artifact is up to date")
+					return nil
+				}
+
+				err = docker.BuildArtifact(ctx, logger, artifact, cwd, hash)
 				if err != nil {
 					return fmt.Errorf("could not build artifact %s: %w", artifact.Name, err)
 				}
@@ -195,9 +560,48 @@ func buildArtifacts(ctx context.Context, logger logrus.FieldLogger, file *atlasf
 		if err != nil {
 			return err
 		}
+
+		for i, artifactName := range layer {
+			hashes[artifactName] = results[i].hash
+			if results[i].cached {
+				cached = append(cached, artifactName)
+			} else {
+				built = append(built, artifactName)
+			}
+		}
+	}
+
+	if len(built)+len(cached) > 0 {
+		logger.Infoln(formatBuildSummary(built, cached))
 	}
 
 	return nil
 }
 
-// TODO support caching -> only build when artifact inputs changed
+// formatBuildSummary returns a summary of built and cached artifacts, e.g. "Artifacts: 1 built (api), 1 cached (base)"
+func formatBuildSummary(built, cached []string) string {
+	format := func(count int, names []string, label string) string {
+		if len(names) == 0 {
+			return fmt.Sprintf("%d %s", count, label)
+		}
+		return fmt.Sprintf("%d %s (%s)", count, label, strings.Join(names, ", "))
+	}
+
+	return fmt.Sprintf("Artifacts: %s, %s", format(len(built), built, "built"), format(len(cached), cached, "cached"))
+}
+
+// waitForDependency waits until the container of a dependency meets condition, for at most timeout unless it is zero
+func waitForDependency(ctx context.Context, containerName string, condition atlasfile.ServiceDependencyCondition, timeout time.Duration) error {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	err := docker.WaitForContainer(ctx, containerName, condition)
+	if errors.Is(err, context.DeadlineExceeded) {
+		return fmt.Errorf("timed out after %s: %w", timeout, err)
+	}
+
+	return err
+}
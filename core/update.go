@@ -8,6 +8,7 @@ import (
 	"github.com/brunoscheufler/atlas/helper"
 	"github.com/sirupsen/logrus"
 	"path/filepath"
+	"strings"
 )
 
 func Update(ctx context.Context, logger logrus.FieldLogger, cwd string) error {
@@ -33,12 +34,12 @@ func Update(ctx context.Context, logger logrus.FieldLogger, cwd string) error {
 			logger.Infoln(fmt.Sprintf("Updating Go Atlasfile (%s)", relPath))
 
 			// Run go get -u && go mo tidy
-			err := exec.RunCommand(ctx, logger, "go get -u", exec.RunCommandOptions{Cwd: path, LogPrefix: relPath})
+			err := exec.RunProgram(ctx, logger, "go", []string{"get", "-u"}, exec.RunCommandOptions{Cwd: path, LogPrefix: relPath})
 			if err != nil {
 				return fmt.Errorf("could not run go get -u: %w", err)
 			}
 
-			err = exec.RunCommand(ctx, logger, "go mod tidy", exec.RunCommandOptions{Cwd: path, LogPrefix: relPath})
+			err = exec.RunProgram(ctx, logger, "go", []string{"mod", "tidy"}, exec.RunCommandOptions{Cwd: path, LogPrefix: relPath})
 			if err != nil {
 				return fmt.Errorf("could not run go mod tidy: %w", err)
 			}
@@ -48,7 +49,15 @@ func Update(ctx context.Context, logger logrus.FieldLogger, cwd string) error {
 
 		// Check if package.json exists
 		if helper.FileExists(filepath.Join(path, "package.json")) {
-			return fmt.Errorf("not implemented yet")
+			pm := atlasfile.DetectNodePackageManager(path)
+
+			logger.Infoln(fmt.Sprintf("Updating TypeScript Atlasfile using %s (%s)", pm.Name, relPath))
+
+			command := append(append([]string{}, pm.UpgradeCommand...), atlasfile.TypeScriptSdkPackage)
+			err := exec.RunProgram(ctx, logger, command[0], command[1:], exec.RunCommandOptions{Cwd: path, LogPrefix: relPath})
+			if err != nil {
+				return fmt.Errorf("could not run %s: %w", strings.Join(command, " "), err)
+			}
 		}
 	}
 
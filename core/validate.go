@@ -0,0 +1,28 @@
+package atlas
+
+import (
+	"context"
+	"fmt"
+	"github.com/brunoscheufler/atlas/atlasfile"
+	"github.com/sirupsen/logrus"
+)
+
+// Validate collects all Atlasfiles in the workspace and reports any problems without touching Docker
+func Validate(ctx context.Context, logger logrus.FieldLogger, version, cwd string, evalOptions atlasfile.EvalOptions) error {
+	cwd, err := atlasfile.FindRootDir(cwd)
+	if err != nil {
+		return fmt.Errorf("could not find root directory: %w", err)
+	}
+
+	logger.WithField("cwd", cwd).Debugf("Found root directory")
+
+	// Collect validates the merged Atlasfile
+	mergedFile, err := atlasfile.Collect(ctx, logger, atlasfile.NewEvalContext(version, cwd, nil, evalOptions))
+	if err != nil {
+		return fmt.Errorf("could not collect atlas files: %w", err)
+	}
+
+	fmt.Printf("Atlasfiles are valid (%d artifacts, %d services, %d stacks)\n", len(mergedFile.Artifacts), len(mergedFile.Services), len(mergedFile.Stacks))
+
+	return nil
+}
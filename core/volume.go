@@ -1 +1,357 @@
 package atlas
+
+import (
+	"context"
+	"fmt"
+	"github.com/brunoscheufler/atlas/atlasfile"
+	"github.com/brunoscheufler/atlas/docker"
+	"github.com/sirupsen/logrus"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/tabwriter"
+)
+
+// VolumeRm removes persistent volumes of a service, which must not be up
+func VolumeRm(ctx context.Context, logger logrus.FieldLogger, version, cwd string, stackName, serviceName string, volumeNames []string) error {
+	cwd, err := atlasfile.FindRootDir(cwd)
+	if err != nil {
+		return fmt.Errorf("could not find root directory: %w", err)
+	}
+
+	if !docker.IsRunning(ctx) {
+		return fmt.Errorf("%s is not running", docker.RuntimeName())
+	}
+
+	// Hold the state lock until the volumes are removed, so the service cannot be started in between
+	ctx, unlock, err := lockState(ctx, cwd, logger)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	statefile, err := readState(ctx, cwd, version, logger)
+	if err != nil {
+		return fmt.Errorf("could not read state file: %w", err)
+	}
+
+	if statefile != nil {
+		if stack := statefile.GetStack(stackName); stack != nil && stack.GetService(serviceName) != nil {
+			return fmt.Errorf("service %s in stack %s is up, run atlas down -s %s first", serviceName, stackName, stackName)
+		}
+	}
+
+	for _, volumeName := range volumeNames {
+		physicalName := docker.PersistentVolumeName(cwd, stackName, serviceName, volumeName)
+
+		exists, err := docker.VolumeExists(ctx, physicalName)
+		if err != nil {
+			return err
+		}
+
+		if !exists {
+			return fmt.Errorf("could not find persistent volume %s of service %s in stack %s", volumeName, serviceName, stackName)
+		}
+
+		err = docker.DeleteVolume(ctx, logger, physicalName)
+		if err != nil {
+			return fmt.Errorf("could not delete volume: %w", err)
+		}
+
+		logger.WithField("stack", stackName).Infof("Removed volume %s of %s\n", volumeName, serviceName)
+	}
+
+	return nil
+}
+
+// VolumeSnapshot stores the contents of a service volume as named snapshot in .atlas/snapshots
+func VolumeSnapshot(ctx context.Context, logger logrus.FieldLogger, version, cwd string, stackName, serviceName, volumeName, snapshotName string) error {
+	if err := validateSnapshotName(snapshotName); err != nil {
+		return err
+	}
+
+	return withVolume(ctx, logger, version, cwd, stackName, serviceName, volumeName, false, func(rootDir, physicalName string) error {
+		err := docker.ExportVolume(ctx, logger, physicalName, getSnapshotPath(rootDir, stackName, serviceName, volumeName, snapshotName))
+		if err != nil {
+			return fmt.Errorf("could not create snapshot: %w", err)
+		}
+
+		logger.WithField("stack", stackName).Infof("Created snapshot %s of volume %s of %s\n", snapshotName, volumeName, serviceName)
+
+		return nil
+	})
+}
+
+// VolumeRestore replaces the contents of a service volume with a snapshot created by VolumeSnapshot
+func VolumeRestore(ctx context.Context, logger logrus.FieldLogger, version, cwd string, stackName, serviceName, volumeName, snapshotName string) error {
+	if err := validateSnapshotName(snapshotName); err != nil {
+		return err
+	}
+
+	return withVolume(ctx, logger, version, cwd, stackName, serviceName, volumeName, true, func(rootDir, physicalName string) error {
+		snapshotPath := getSnapshotPath(rootDir, stackName, serviceName, volumeName, snapshotName)
+		if _, err := os.Stat(snapshotPath); err != nil {
+			return fmt.Errorf("could not find snapshot %s of volume %s of service %s in stack %s", snapshotName, volumeName, serviceName, stackName)
+		}
+
+		err := docker.ImportVolume(ctx, logger, physicalName, snapshotPath)
+		if err != nil {
+			return fmt.Errorf("could not restore snapshot: %w", err)
+		}
+
+		logger.WithField("stack", stackName).Infof("Restored snapshot %s of volume %s of %s\n", snapshotName, volumeName, serviceName)
+
+		return nil
+	})
+}
+
+// VolumeExport writes the contents of a service volume as tar archive to path
+func VolumeExport(ctx context.Context, logger logrus.FieldLogger, version, cwd string, stackName, serviceName, volumeName, path string) error {
+	path, err := filepath.Abs(path)
+	if err != nil {
+		return fmt.Errorf("could not get absolute path: %w", err)
+	}
+
+	return withVolume(ctx, logger, version, cwd, stackName, serviceName, volumeName, false, func(_, physicalName string) error {
+		return docker.ExportVolume(ctx, logger, physicalName, path)
+	})
+}
+
+// VolumeImport replaces the contents of a service volume with the tar archive at path
+func VolumeImport(ctx context.Context, logger logrus.FieldLogger, version, cwd string, stackName, serviceName, volumeName, path string) error {
+	path, err := filepath.Abs(path)
+	if err != nil {
+		return fmt.Errorf("could not get absolute path: %w", err)
+	}
+
+	return withVolume(ctx, logger, version, cwd, stackName, serviceName, volumeName, true, func(_, physicalName string) error {
+		return docker.ImportVolume(ctx, logger, physicalName, path)
+	})
+}
+
+// withVolume resolves the physical name of a service volume and calls fn with the service container stopped, so that
+// volume contents are consistent. Volumes of services that are up are taken from the state file, all others must be
+// persistent. Missing persistent volumes are created if create is set.
+func withVolume(
+	ctx context.Context,
+	logger logrus.FieldLogger,
+	version, cwd string,
+	stackName, serviceName, volumeName string,
+	create bool,
+	fn func(rootDir, physicalName string) error,
+) error {
+	cwd, err := atlasfile.FindRootDir(cwd)
+	if err != nil {
+		return fmt.Errorf("could not find root directory: %w", err)
+	}
+
+	if !docker.IsRunning(ctx) {
+		return fmt.Errorf("%s is not running", docker.RuntimeName())
+	}
+
+	// Hold the state lock until the container is started again, so it is not recreated while fn runs
+	ctx, unlock, err := lockState(ctx, cwd, logger)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	statefile, err := readState(ctx, cwd, version, logger)
+	if err != nil {
+		return fmt.Errorf("could not read state file: %w", err)
+	}
+
+	var service *StateService
+	if statefile != nil {
+		if stack := statefile.GetStack(stackName); stack != nil {
+			service = stack.GetService(serviceName)
+		}
+	}
+
+	var physicalName string
+	if service != nil {
+		for _, volume := range service.Volumes {
+			if volume.Name == volumeName {
+				physicalName = volume.PhysicalName
+			}
+		}
+
+		if physicalName == "" {
+			return fmt.Errorf("service %s in stack %s has no volume %s", serviceName, stackName, volumeName)
+		}
+	} else {
+		physicalName = docker.PersistentVolumeName(cwd, stackName, serviceName, volumeName)
+
+		exists, err := docker.VolumeExists(ctx, physicalName)
+		if err != nil {
+			return err
+		}
+
+		if !exists && !create {
+			return fmt.Errorf("could not find persistent volume %s of service %s in stack %s", volumeName, serviceName, stackName)
+		}
+
+		if !exists {
+			err = docker.CreateVolume(ctx, logger, physicalName, docker.Labels{Root: cwd, Version: version}.Volume(stackName, serviceName, volumeName, true))
+			if err != nil {
+				return fmt.Errorf("could not create volume: %w", err)
+			}
+		}
+	}
+
+	if service != nil && service.ContainerInfos != nil && service.ContainerInfos.State == "running" {
+		logger.WithField("stack", stackName).Infof("Stopping %s\n", serviceName)
+
+		err = docker.StopContainer(ctx, service.ContainerName)
+		if err != nil {
+			return err
+		}
+
+		defer func() {
+			logger.WithField("stack", stackName).Infof("Starting %s\n", serviceName)
+
+			if err := docker.StartContainer(ctx, service.ContainerName); err != nil {
+				logger.WithError(err).Errorf("Could not start %s again, run atlas start -s %s %s\n", serviceName, stackName, serviceName)
+			}
+		}()
+	}
+
+	return fn(cwd, physicalName)
+}
+
+// VolumeLs prints the volumes of services that are up and all volumes with snapshots, together with their snapshots
+func VolumeLs(ctx context.Context, logger logrus.FieldLogger, version, cwd string, stackNames []string) error {
+	cwd, err := atlasfile.FindRootDir(cwd)
+	if err != nil {
+		return fmt.Errorf("could not find root directory: %w", err)
+	}
+
+	if !docker.IsRunning(ctx) {
+		return fmt.Errorf("%s is not running", docker.RuntimeName())
+	}
+
+	statefile, err := readState(ctx, cwd, version, logger)
+	if err != nil {
+		return fmt.Errorf("could not read state file: %w", err)
+	}
+
+	volumes := make(map[string]*listedVolume)
+
+	if statefile != nil {
+		for _, stack := range statefile.Stacks {
+			for _, service := range stack.Services {
+				for _, volume := range service.Volumes {
+					ref := strings.Join([]string{stack.Name, service.Name, volume.Name}, "/")
+					volumes[ref] = &listedVolume{physicalName: volume.PhysicalName, persistent: volume.Persistent}
+				}
+			}
+		}
+	}
+
+	snapshots, err := listSnapshots(cwd)
+	if err != nil {
+		return err
+	}
+
+	for ref, names := range snapshots {
+		volume, ok := volumes[ref]
+		if !ok {
+			parts := strings.Split(ref, "/")
+
+			volume = &listedVolume{physicalName: docker.PersistentVolumeName(cwd, parts[0], parts[1], parts[2]), persistent: true}
+
+			exists, err := docker.VolumeExists(ctx, volume.physicalName)
+			if err != nil {
+				return err
+			}
+
+			if !exists {
+				volume.physicalName = "-"
+			}
+
+			volumes[ref] = volume
+		}
+
+		volume.snapshots = names
+	}
+
+	selectedStacks := make(map[string]struct{}, len(stackNames))
+	for _, stackName := range stackNames {
+		selectedStacks[stackName] = struct{}{}
+	}
+
+	refs := make([]string, 0, len(volumes))
+	for ref := range volumes {
+		if _, ok := selectedStacks[strings.SplitN(ref, "/", 2)[0]]; len(stackNames) > 0 && !ok {
+			continue
+		}
+
+		refs = append(refs, ref)
+	}
+	sort.Strings(refs)
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "VOLUME\tNAME\tLIFECYCLE\tSNAPSHOTS")
+
+	for _, ref := range refs {
+		volume := volumes[ref]
+
+		lifecycle := atlasfile.VolumeLifecycleEphemeral
+		if volume.persistent {
+			lifecycle = atlasfile.VolumeLifecyclePersistent
+		}
+
+		snapshotNames := "-"
+		if len(volume.snapshots) > 0 {
+			snapshotNames = strings.Join(volume.snapshots, ", ")
+		}
+
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", ref, volume.physicalName, lifecycle, snapshotNames)
+	}
+
+	return w.Flush()
+}
+
+type listedVolume struct {
+	physicalName string
+	persistent   bool
+	snapshots    []string
+}
+
+func getSnapshotsDir(rootDir string) string {
+	return filepath.Join(rootDir, ".atlas", "snapshots")
+}
+
+func getSnapshotPath(rootDir, stackName, serviceName, volumeName, snapshotName string) string {
+	return filepath.Join(getSnapshotsDir(rootDir), stackName, serviceName, volumeName, snapshotName+".tar")
+}
+
+// listSnapshots returns the snapshot names of volumes by stack/service/volume reference
+func listSnapshots(rootDir string) (map[string][]string, error) {
+	matches, err := filepath.Glob(filepath.Join(getSnapshotsDir(rootDir), "*", "*", "*", "*.tar"))
+	if err != nil {
+		return nil, fmt.Errorf("could not list snapshots: %w", err)
+	}
+
+	snapshots := make(map[string][]string)
+	for _, match := range matches {
+		relPath, err := filepath.Rel(getSnapshotsDir(rootDir), match)
+		if err != nil {
+			return nil, fmt.Errorf("could not get relative path: %w", err)
+		}
+
+		ref := filepath.ToSlash(filepath.Dir(relPath))
+		snapshots[ref] = append(snapshots[ref], strings.TrimSuffix(filepath.Base(relPath), ".tar"))
+	}
+
+	return snapshots, nil
+}
+
+func validateSnapshotName(name string) error {
+	if name == "" || name == "." || name == ".." || strings.ContainsAny(name, `/\`) {
+		return fmt.Errorf("invalid snapshot name %q", name)
+	}
+
+	return nil
+}
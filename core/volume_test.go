@@ -0,0 +1,87 @@
+package atlas
+
+import (
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestListSnapshots(t *testing.T) {
+	rootDir := t.TempDir()
+
+	for _, snapshot := range [][]string{
+		{"local", "db", "data", "fixtures"},
+		{"local", "db", "data", "empty"},
+		{"local", "cache", "data", "warm"},
+	} {
+		snapshotPath := getSnapshotPath(rootDir, snapshot[0], snapshot[1], snapshot[2], snapshot[3])
+		if err := os.MkdirAll(filepath.Dir(snapshotPath), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(snapshotPath, nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Unfinished exports are not listed
+	if err := os.WriteFile(getSnapshotPath(rootDir, "local", "db", "data", "partial")+".tmp", nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	snapshots, err := listSnapshots(rootDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, map[string][]string{
+		"local/db/data":    {"empty", "fixtures"},
+		"local/cache/data": {"warm"},
+	}, snapshots)
+}
+
+func TestValidateSnapshotName(t *testing.T) {
+	if err := validateSnapshotName("fixtures-2022"); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, name := range []string{"", ".", "..", "../state", `a\b`} {
+		assert.Error(t, validateSnapshotName(name), name)
+	}
+}
+
+func TestWithVolumeHoldsStateLock(t *testing.T) {
+	lt := newUpLifecycleTest(t)
+
+	other, err := os.OpenFile(getStateLockPath(lt.cwd), os.O_RDWR, 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer other.Close()
+
+	err = withVolume(lt.ctx, lt.logger, "test", lt.cwd, "local", "db", "data", false, func(_, _ string) error {
+		// Other processes cannot recreate the container while it is stopped
+		locked, err := tryLockFile(other)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.False(t, locked)
+		assert.False(t, lt.container("local", "db").Running)
+
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.True(t, lt.container("local", "db").Running)
+
+	locked, err := tryLockFile(other)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.True(t, locked)
+
+	if err := unlockFile(other); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,102 @@
+package atlas
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/brunoscheufler/atlas/atlasfile"
+	"github.com/brunoscheufler/atlas/docker"
+	"github.com/sirupsen/logrus"
+	"strings"
+	"sync"
+	"time"
+)
+
+// waitLogLines is the number of container log lines included for each service that did not become ready
+const waitLogLines = 20
+
+type waitFailure struct {
+	stack         string
+	service       string
+	containerName string
+	err           error
+}
+
+// waitForStacks blocks until all services in stacks are healthy, or running if they have no healthcheck,
+// and reports every service that did not become ready within timeout including its last logs
+func waitForStacks(ctx context.Context, logger logrus.FieldLogger, stacks []atlasfile.StackConfig, timeout time.Duration) error {
+	waitCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var failures []*waitFailure
+	for _, stack := range stacks {
+		for _, stackService := range stack.Services {
+			failures = append(failures, &waitFailure{
+				stack:         stack.Name,
+				service:       stackService.Name,
+				containerName: stack.GetContainerName(stackService.Name),
+			})
+		}
+	}
+
+	var wg sync.WaitGroup
+	for _, failure := range failures {
+		failure := failure
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			condition := atlasfile.ServiceDependencyStarted
+
+			hasHealthcheck, err := docker.HasHealthcheck(waitCtx, failure.containerName)
+			if err != nil {
+				failure.err = err
+				return
+			}
+
+			if hasHealthcheck {
+				condition = atlasfile.ServiceDependencyHealthy
+			}
+
+			logger.WithField("stack", failure.stack).Debugf("Waiting for %s to be %s", failure.service, condition)
+
+			err = docker.WaitForContainer(waitCtx, failure.containerName, condition)
+			if errors.Is(err, context.DeadlineExceeded) {
+				err = fmt.Errorf("did not become %s within %s", condition, timeout)
+			}
+			failure.err = err
+		}()
+	}
+	wg.Wait()
+
+	var report strings.Builder
+	failed := 0
+
+	for _, failure := range failures {
+		if failure.err == nil {
+			continue
+		}
+		failed++
+
+		report.WriteString(fmt.Sprintf("\n%s/%s: %s\n", failure.stack, failure.service, failure.err.Error()))
+
+		logs, err := docker.GetContainerLogs(ctx, failure.containerName, waitLogLines)
+		if err != nil {
+			report.WriteString(fmt.Sprintf("  could not get logs: %s\n", err.Error()))
+			continue
+		}
+
+		for _, line := range strings.Split(strings.TrimRight(logs, "\n"), "\n") {
+			report.WriteString(fmt.Sprintf("  | %s\n", line))
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d services did not become ready:\n%s", failed, len(failures), report.String())
+	}
+
+	logger.Infof("All %d services are ready\n", len(failures))
+
+	return nil
+}
@@ -0,0 +1,238 @@
+package docker
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"github.com/docker/distribution/reference"
+	"github.com/docker/docker/api/types"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// dockerHubServer is the key the Docker CLI stores Docker Hub credentials under
+const dockerHubServer = "https://index.docker.io/v1/"
+
+// dockerConfigFile contains the parts of the Docker CLI configuration used to authenticate with registries. Podman
+// auth files use the same format.
+type dockerConfigFile struct {
+	Auths map[string]struct {
+		Auth          string `json:"auth"`
+		IdentityToken string `json:"identitytoken"`
+	} `json:"auths"`
+
+	CredsStore  string            `json:"credsStore"`
+	CredHelpers map[string]string `json:"credHelpers"`
+}
+
+func getDockerConfigPath() (string, error) {
+	if dir := os.Getenv("DOCKER_CONFIG"); dir != "" {
+		return filepath.Join(dir, "config.json"), nil
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("could not get home directory: %w", err)
+	}
+
+	return filepath.Join(home, ".docker", "config.json"), nil
+}
+
+// getRegistryServer returns the registry of an image as the Docker CLI stores credentials for it
+func getRegistryServer(imageName string) (string, error) {
+	named, err := reference.ParseNormalizedNamed(imageName)
+	if err != nil {
+		return "", fmt.Errorf("invalid image name %s: %w", imageName, err)
+	}
+
+	domain := reference.Domain(named)
+	if domain == "docker.io" {
+		return dockerHubServer, nil
+	}
+
+	return domain, nil
+}
+
+// getConfigAuthKeys returns the keys credentials for server may be stored under. The Docker CLI stores Docker Hub
+// credentials under its index URL, while Podman uses the registry domain.
+func getConfigAuthKeys(server string) []string {
+	if server == dockerHubServer {
+		return []string{dockerHubServer, "docker.io", "index.docker.io"}
+	}
+	return []string{server}
+}
+
+// getRegistryAuth returns the encoded credentials for the registry of an image, stored in the first of configPaths
+// that has credentials for the registry, or one of its credential helpers. An empty string is returned if there are
+// no credentials.
+func getRegistryAuth(ctx context.Context, configPaths []string, imageName string) (string, error) {
+	server, err := getRegistryServer(imageName)
+	if err != nil {
+		return "", err
+	}
+
+	for _, configPath := range configPaths {
+		authConfig, err := getConfigCredentials(ctx, configPath, server)
+		if err != nil {
+			return "", err
+		}
+
+		if authConfig == nil {
+			continue
+		}
+
+		encoded, err := json.Marshal(authConfig)
+		if err != nil {
+			return "", fmt.Errorf("could not marshal credentials: %w", err)
+		}
+
+		return base64.URLEncoding.EncodeToString(encoded), nil
+	}
+
+	return "", nil
+}
+
+// getConfigCredentials reads credentials for server from a Docker CLI or Podman auth configuration file and
+// returns nil if there are none
+func getConfigCredentials(ctx context.Context, configPath, server string) (*types.AuthConfig, error) {
+	if configPath == "" {
+		return nil, nil
+	}
+
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("could not read %s: %w", configPath, err)
+	}
+
+	var config dockerConfigFile
+	err = json.Unmarshal(data, &config)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse %s: %w", configPath, err)
+	}
+
+	authConfig := &types.AuthConfig{ServerAddress: server}
+
+	credHelper := config.CredsStore
+	if helper, ok := config.CredHelpers[server]; ok {
+		credHelper = helper
+	}
+
+	if credHelper != "" {
+		found, err := getHelperCredentials(ctx, credHelper, authConfig)
+		if err != nil {
+			return nil, err
+		}
+
+		if !found {
+			return nil, nil
+		}
+
+		return authConfig, nil
+	}
+
+	for _, key := range getConfigAuthKeys(server) {
+		auth, ok := config.Auths[key]
+		if !ok {
+			continue
+		}
+
+		if auth.Auth != "" {
+			decoded, err := base64.StdEncoding.DecodeString(auth.Auth)
+			if err != nil {
+				return nil, fmt.Errorf("invalid credentials for %s: %w", server, err)
+			}
+
+			username, password, _ := strings.Cut(string(decoded), ":")
+			authConfig.Username = username
+			authConfig.Password = password
+		}
+
+		authConfig.IdentityToken = auth.IdentityToken
+		return authConfig, nil
+	}
+
+	return nil, nil
+}
+
+// getConfigServers returns the registries with credentials or a credential helper in a Docker CLI or Podman auth
+// configuration file
+func getConfigServers(configPath string) ([]string, error) {
+	if configPath == "" {
+		return nil, nil
+	}
+
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("could not read %s: %w", configPath, err)
+	}
+
+	var config dockerConfigFile
+	err = json.Unmarshal(data, &config)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse %s: %w", configPath, err)
+	}
+
+	servers := make([]string, 0, len(config.Auths)+len(config.CredHelpers))
+	for server := range config.Auths {
+		servers = append(servers, server)
+	}
+	for server := range config.CredHelpers {
+		if _, ok := config.Auths[server]; !ok {
+			servers = append(servers, server)
+		}
+	}
+	sort.Strings(servers)
+
+	return servers, nil
+}
+
+// getHelperCredentials reads credentials for authConfig.ServerAddress from a docker-credential-<helper> binary and
+// returns false if the helper has no credentials for the server
+func getHelperCredentials(ctx context.Context, helper string, authConfig *types.AuthConfig) (bool, error) {
+	cmd := exec.CommandContext(ctx, "docker-credential-"+helper, "get")
+	cmd.Stdin = strings.NewReader(authConfig.ServerAddress)
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	err := cmd.Run()
+	if err != nil {
+		// The helper protocol reports missing credentials on stdout
+		if strings.Contains(stdout.String(), "credentials not found") {
+			return false, nil
+		}
+		return false, fmt.Errorf("could not get credentials from docker-credential-%s: %w: %s", helper, err, strings.TrimSpace(stderr.String()+stdout.String()))
+	}
+
+	var credentials struct {
+		Username string `json:"Username"`
+		Secret   string `json:"Secret"`
+	}
+
+	err = json.Unmarshal(stdout.Bytes(), &credentials)
+	if err != nil {
+		return false, fmt.Errorf("could not parse credentials from docker-credential-%s: %w", helper, err)
+	}
+
+	// Identity tokens are stored with a placeholder username
+	if credentials.Username == "<token>" {
+		authConfig.IdentityToken = credentials.Secret
+	} else {
+		authConfig.Username = credentials.Username
+		authConfig.Password = credentials.Secret
+	}
+
+	return true, nil
+}
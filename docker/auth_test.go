@@ -0,0 +1,87 @@
+package docker
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"github.com/docker/docker/api/types"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestGetRegistryServer(t *testing.T) {
+	for imageName, server := range map[string]string{
+		"postgres:14":                   dockerHubServer,
+		"atlas/api":                     dockerHubServer,
+		"ghcr.io/brunoscheufler/atlas":  "ghcr.io",
+		"localhost:5000/atlas-api:test": "localhost:5000",
+	} {
+		actual, err := getRegistryServer(imageName)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, server, actual, imageName)
+	}
+}
+
+func TestGetRegistryAuth(t *testing.T) {
+	configDir := t.TempDir()
+	configPaths := []string{filepath.Join(configDir, "auth.json"), filepath.Join(configDir, "config.json")}
+
+	// No config file
+	auth, err := getRegistryAuth(context.Background(), configPaths, "postgres:14")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "", auth)
+
+	config := `{"auths": {"ghcr.io": {"auth": "` + base64.StdEncoding.EncodeToString([]byte("atlas:se:cret")) + `"}}}`
+	if err := os.WriteFile(filepath.Join(configDir, "config.json"), []byte(config), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	auth, err = getRegistryAuth(context.Background(), configPaths, "ghcr.io/brunoscheufler/atlas")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	decoded, err := base64.URLEncoding.DecodeString(auth)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var authConfig types.AuthConfig
+	if err := json.Unmarshal(decoded, &authConfig); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, types.AuthConfig{Username: "atlas", Password: "se:cret", ServerAddress: "ghcr.io"}, authConfig)
+
+	// No credentials for the registry
+	auth, err = getRegistryAuth(context.Background(), configPaths, "postgres:14")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "", auth)
+
+	// Podman stores Docker Hub credentials under the registry domain, earlier files take precedence
+	podmanConfig := `{"auths": {"docker.io": {"auth": "` + base64.StdEncoding.EncodeToString([]byte("podman:secret")) + `"}}}`
+	if err := os.WriteFile(configPaths[0], []byte(podmanConfig), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	auth, err = getRegistryAuth(context.Background(), configPaths, "postgres:14")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	decoded, err = base64.URLEncoding.DecodeString(auth)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(decoded, &authConfig); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, types.AuthConfig{Username: "podman", Password: "secret", ServerAddress: dockerHubServer}, authConfig)
+}
@@ -1,65 +1,180 @@
 package docker
 
 import (
+	"archive/tar"
 	"context"
 	"fmt"
 	"github.com/brunoscheufler/atlas/atlasfile"
-	"github.com/brunoscheufler/atlas/exec"
+	"github.com/docker/docker/pkg/archive"
+	"github.com/docker/docker/pkg/stringid"
 	"github.com/sirupsen/logrus"
+	"io"
+	"os"
 	"path/filepath"
 	"strings"
+	"time"
 )
 
-func BuildArtifact(ctx context.Context, logger logrus.FieldLogger, artifact *atlasfile.ArtifactConfig, cwd string) error {
+// getArtifactContextDir returns the build context of an artifact, relative to the Atlasfile declaring it
+func getArtifactContextDir(artifact *atlasfile.ArtifactConfig) string {
 	artifactDir := filepath.Dir(artifact.GetDirpath())
 	if artifact.Build.Context != "" {
 		artifactDir = filepath.Join(artifactDir, artifact.Build.Context)
 	}
+	return artifactDir
+}
 
-	relPath, err := filepath.Rel(cwd, artifactDir)
+// getArtifactDockerfile returns the Dockerfile of an artifact, or an empty string to use the Dockerfile in the root
+// of the build context
+func getArtifactDockerfile(artifact *atlasfile.ArtifactConfig, contextDir string) string {
+	if artifact.Build.Dockerfile == "" {
+		return ""
+	}
+	return filepath.Join(contextDir, artifact.Build.Dockerfile)
+}
+
+// resolveArtifactPath returns path, relative to the Atlasfile declaring an artifact, as absolute path
+func resolveArtifactPath(artifact *atlasfile.ArtifactConfig, path string) string {
+	if filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(filepath.Dir(artifact.GetDirpath()), path)
+}
+
+// getBuildContext returns the build context sent to the builder as tar archive without files excluded by .dockerignore,
+// and the path of the Dockerfile within it. Like the Docker CLI, the Dockerfile and .dockerignore are always sent, and
+// a Dockerfile outside of the context directory is added to the archive.
+func getBuildContext(contextDir, dockerfile string) (io.ReadCloser, string, error) {
+	patterns, err := getDockerignorePatterns(contextDir, dockerfile)
 	if err != nil {
-		return fmt.Errorf("could not get relative path: %w", err)
+		return nil, "", err
 	}
 
-	logger.WithFields(logrus.Fields{
-		"artifact": artifact.Name,
-		"dirpath":  relPath,
-		"context":  artifact.Build.Context,
-	}).Debugf("Building artifact")
+	if dockerfile == "" {
+		dockerfile = filepath.Join(contextDir, "Dockerfile")
+	}
 
-	logger.WithField("dir", relPath).WithField("artifact", artifact.Name).Infoln("Building artifact")
+	relDockerfile, err := filepath.Rel(contextDir, dockerfile)
+	if err != nil {
+		return nil, "", fmt.Errorf("could not get relative path of Dockerfile: %w", err)
+	}
+
+	external := relDockerfile == ".." || strings.HasPrefix(relDockerfile, ".."+string(filepath.Separator))
+	if !external {
+		patterns = append(patterns, "!.dockerignore", "!"+relDockerfile)
+	}
+
+	buildContext, err := archive.TarWithOptions(contextDir, &archive.TarOptions{ExcludePatterns: patterns})
+	if err != nil {
+		return nil, "", fmt.Errorf("could not archive build context: %w", err)
+	}
+
+	if !external {
+		return buildContext, filepath.ToSlash(relDockerfile), nil
+	}
+
+	contents, err := os.ReadFile(dockerfile)
+	if err != nil {
+		_ = buildContext.Close()
+		return nil, "", fmt.Errorf("could not read Dockerfile: %w", err)
+	}
+
+	name := ".dockerfile." + stringid.GenerateRandomID()[:20]
+	return archive.ReplaceFileTarWrapper(buildContext, map[string]archive.TarModifierFunc{
+		name: func(_ string, _ *tar.Header, _ io.Reader) (*tar.Header, []byte, error) {
+			return &tar.Header{Name: name, Mode: 0600, Size: int64(len(contents)), ModTime: time.Now(), Typeflag: tar.TypeReg}, contents, nil
+		},
+	}), name, nil
+}
 
-	imageName := atlasfile.BuildImageName(artifact)
+// getBuildOptions returns the options to build the image of an artifact with, labeled with the hash of its inputs
+func getBuildOptions(artifact *atlasfile.ArtifactConfig, inputHash string) BuildOptions {
+	contextDir := getArtifactContextDir(artifact)
 
-	args := []string{
-		"build",
-		"-t",
-		imageName,
+	labels := make(map[string]string, len(artifact.Build.Labels)+1)
+	for key, value := range artifact.Build.Labels {
+		labels[key] = value
 	}
+	labels[LabelBuildHash] = inputHash
 
-	if artifact.Build.Dockerfile != "" {
-		dockerfilePath := filepath.Join(artifactDir, artifact.Build.Dockerfile)
-		args = append(args, "-f", dockerfilePath)
+	options := BuildOptions{
+		Name:       artifact.Name,
+		Image:      atlasfile.BuildImageName(artifact),
+		ContextDir: contextDir,
+		Dockerfile: getArtifactDockerfile(artifact, contextDir),
+		BuildArgs:  artifact.Build.BuildArgs,
+		Target:     artifact.Build.Target,
+		Labels:     labels,
+		Platform:   artifact.Build.Platform,
 	}
 
-	if artifact.Build.BuildArgs != nil {
-		for key, value := range artifact.Build.BuildArgs {
-			args = append(args, "--build-arg", fmt.Sprintf("%s=%s", key, value))
+	for _, secret := range artifact.Build.Secrets {
+		buildSecret := BuildSecret{Id: secret.Id, Env: secret.Env}
+		if secret.File != "" {
+			buildSecret.Src = resolveArtifactPath(artifact, secret.File)
 		}
+		options.Secrets = append(options.Secrets, buildSecret)
 	}
 
-	if artifact.Build.Target != "" {
-		args = append(args, "--target", artifact.Build.Target)
+	// SSH entries are either an agent id or id=<socket or keys>
+	for _, ssh := range artifact.Build.SSH {
+		id, paths, ok := strings.Cut(ssh, "=")
+		if !ok {
+			options.SSH = append(options.SSH, ssh)
+			continue
+		}
+
+		resolved := strings.Split(paths, ",")
+		for i, path := range resolved {
+			resolved[i] = resolveArtifactPath(artifact, path)
+		}
+		options.SSH = append(options.SSH, id+"="+strings.Join(resolved, ","))
+	}
+
+	// Caches are validated with the Atlasfile
+	for _, cache := range artifact.Build.CacheFrom {
+		if image, ok := atlasfile.CacheFromImage(cache); ok {
+			options.CacheFrom = append(options.CacheFrom, image)
+		}
 	}
 
-	args = append(args, artifactDir)
+	for _, cache := range artifact.Build.CacheTo {
+		if cache == atlasfile.CacheToInline {
+			options.InlineCache = true
+		}
+	}
+
+	return options
+}
+
+// IsArtifactCached returns true if the image of an artifact exists and was built from inputs with inputHash
+func IsArtifactCached(ctx context.Context, artifact *atlasfile.ArtifactConfig, inputHash string) (bool, error) {
+	labels, err := getRuntime().GetImageLabels(ctx, atlasfile.BuildImageName(artifact))
+	if err != nil {
+		return false, err
+	}
+
+	return labels != nil && labels[LabelBuildHash] == inputHash, nil
+}
+
+// BuildArtifact builds the image of an artifact, labeled with the hash of its inputs
+func BuildArtifact(ctx context.Context, logger logrus.FieldLogger, artifact *atlasfile.ArtifactConfig, cwd, inputHash string) error {
+	artifactDir := getArtifactContextDir(artifact)
+
+	relPath, err := filepath.Rel(cwd, artifactDir)
+	if err != nil {
+		return fmt.Errorf("could not get relative path: %w", err)
+	}
+
+	logger.WithFields(logrus.Fields{
+		"artifact": artifact.Name,
+		"dirpath":  relPath,
+		"context":  artifact.Build.Context,
+	}).Debugf("Building artifact")
+
+	logger.WithField("dir", relPath).WithField("artifact", artifact.Name).Infoln("Building artifact")
 
-	err = exec.RunCommand(ctx, logger, fmt.Sprintf("docker %s", strings.Join(args, " ")),
-		exec.RunCommandOptions{
-			Cwd:        artifactDir,
-			LogVisible: true,
-			LogPrefix:  artifact.Name,
-		})
+	err = getRuntime().BuildImage(ctx, logger, getBuildOptions(artifact, inputHash))
 	if err != nil {
 		return fmt.Errorf("could not build artifact %s: %w", artifact.Name, err)
 	}
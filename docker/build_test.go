@@ -0,0 +1,151 @@
+package docker
+
+import (
+	"archive/tar"
+	"github.com/brunoscheufler/atlas/atlasfile"
+	"github.com/docker/docker/api/types"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestGetBuildOptions(t *testing.T) {
+	artifact := &atlasfile.ArtifactConfig{
+		Name: "api",
+		Build: atlasfile.BuildOptions{
+			Context: "api",
+			Labels:  map[string]string{"team": "backend", LabelBuildHash: "overridden"},
+			Secrets: []atlasfile.BuildSecret{
+				{Id: "npmrc", File: "secrets/.npmrc"},
+				{Id: "token", Env: "GITHUB_TOKEN"},
+			},
+			SSH:       []string{"default", "github=keys/github,/etc/ssh/deploy"},
+			CacheFrom: []string{"example.com/base:cache", "type=registry,ref=example.com/api:cache"},
+			CacheTo:   []string{atlasfile.CacheToInline},
+			Platform:  "linux/arm64",
+		},
+	}
+
+	options := getBuildOptions(artifact, "abc")
+
+	assert.Equal(t, "api", options.ContextDir)
+	assert.Equal(t, map[string]string{"team": "backend", LabelBuildHash: "abc"}, options.Labels)
+	assert.Equal(t, []BuildSecret{
+		{Id: "npmrc", Src: "secrets/.npmrc"},
+		{Id: "token", Env: "GITHUB_TOKEN"},
+	}, options.Secrets)
+	assert.Equal(t, []string{"default", "github=keys/github,/etc/ssh/deploy"}, options.SSH)
+	assert.Equal(t, []string{"example.com/base:cache", "example.com/api:cache"}, options.CacheFrom)
+	assert.True(t, options.InlineCache)
+	assert.Equal(t, "linux/arm64", options.Platform)
+}
+
+func TestGetImageBuildOptions(t *testing.T) {
+	docker := newDockerRuntime()
+	podman := newPodmanRuntime()
+
+	options := BuildOptions{
+		Image:      "api:latest",
+		ContextDir: "/workspace/api",
+		BuildArgs:  map[string]string{"VERSION": "1"},
+		Labels:     map[string]string{LabelBuildHash: "abc"},
+	}
+
+	getImageBuildOptions := func(r *engineRuntime) types.ImageBuildOptions {
+		buildOptions, err := r.getImageBuildOptions(options)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return buildOptions
+	}
+
+	// Without BuildKit options, the default builder is used
+	version := "1"
+	buildOptions := getImageBuildOptions(docker)
+	assert.Equal(t, types.ImageBuildOptions{
+		Tags:      []string{"api:latest"},
+		BuildArgs: map[string]*string{"VERSION": &version},
+		Labels:    map[string]string{LabelBuildHash: "abc"},
+		Remove:    true,
+	}, buildOptions)
+
+	options.Secrets = []BuildSecret{{Id: "npmrc", Src: "/workspace/.npmrc"}}
+	options.SSH = []string{"default"}
+	options.Platform = "linux/amd64"
+	options.CacheFrom = []string{"example.com/api:cache", "example.com/base:cache"}
+	options.InlineCache = true
+
+	buildOptions = getImageBuildOptions(docker)
+	assert.Equal(t, types.BuilderBuildKit, buildOptions.Version)
+	assert.Equal(t, "linux/amd64", buildOptions.Platform)
+	assert.Equal(t, []string{"example.com/api:cache", "example.com/base:cache"}, buildOptions.CacheFrom)
+	assert.Equal(t, "1", *buildOptions.BuildArgs["BUILDKIT_INLINE_CACHE"])
+
+	_, err := podman.getImageBuildOptions(options)
+	assert.ErrorContains(t, err, "not supported by podman")
+}
+
+func TestGetBuildContext(t *testing.T) {
+	root := t.TempDir()
+	writeFile := func(path, contents string) {
+		path = filepath.Join(root, path)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(contents), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	writeFile("api/main.go", "package main")
+	writeFile("api/node_modules/dep.js", "")
+	writeFile("api/Dockerfile", "FROM scratch")
+	writeFile("api/.dockerignore", "node_modules\nDockerfile\n")
+	writeFile("docker/api.Dockerfile", "FROM alpine")
+
+	readBuildContext := func(dockerfile string) (map[string]string, string) {
+		buildContext, name, err := getBuildContext(filepath.Join(root, "api"), dockerfile)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer buildContext.Close()
+
+		files := make(map[string]string)
+		reader := tar.NewReader(buildContext)
+		for {
+			header, err := reader.Next()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			contents, err := io.ReadAll(reader)
+			if err != nil {
+				t.Fatal(err)
+			}
+			files[header.Name] = string(contents)
+		}
+
+		return files, name
+	}
+
+	// The Dockerfile and .dockerignore are sent even if they are excluded
+	files, name := readBuildContext("")
+	assert.Equal(t, "Dockerfile", name)
+	assert.Equal(t, map[string]string{
+		".dockerignore": "node_modules\nDockerfile\n",
+		"Dockerfile":    "FROM scratch",
+		"main.go":       "package main",
+	}, files)
+
+	// Dockerfiles outside of the context are added to it
+	files, name = readBuildContext(filepath.Join(root, "docker", "api.Dockerfile"))
+	assert.True(t, strings.HasPrefix(name, ".dockerfile."))
+	assert.Equal(t, "FROM alpine", files[name])
+	assert.NotContains(t, files, "Dockerfile")
+}
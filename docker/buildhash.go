@@ -0,0 +1,189 @@
+package docker
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"github.com/brunoscheufler/atlas/atlasfile"
+	"github.com/moby/patternmatcher"
+	"github.com/moby/patternmatcher/ignorefile"
+	"hash"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// buildHashVersion is part of every build hash, changing it invalidates all cached artifacts
+const buildHashVersion = "1"
+
+// HashArtifactInputs returns a hash of everything the image of an artifact is built from: the files of its build
+// context that are not excluded by .dockerignore, its Dockerfile and build options, and the
+// hashes of the artifacts it depends on, keyed by artifact name. Secrets are hashed by source, not by value, and
+// caches do not affect the image.
+func HashArtifactInputs(artifact *atlasfile.ArtifactConfig, dependencyHashes map[string]string) (string, error) {
+	contextDir := getArtifactContextDir(artifact)
+
+	dockerfile := getArtifactDockerfile(artifact, contextDir)
+	if dockerfile == "" {
+		dockerfile = filepath.Join(contextDir, "Dockerfile")
+	}
+
+	h := sha256.New()
+	fmt.Fprintf(h, "atlas build %s\n", buildHashVersion)
+	fmt.Fprintf(h, "target %q\n", artifact.Build.Target)
+
+	for _, key := range sortedKeys(artifact.Build.BuildArgs) {
+		fmt.Fprintf(h, "arg %q=%q\n", key, artifact.Build.BuildArgs[key])
+	}
+
+	// Options are only hashed if set, so adding them does not invalidate existing images
+	if artifact.Build.Platform != "" {
+		fmt.Fprintf(h, "platform %q\n", artifact.Build.Platform)
+	}
+
+	for _, key := range sortedKeys(artifact.Build.Labels) {
+		fmt.Fprintf(h, "label %q=%q\n", key, artifact.Build.Labels[key])
+	}
+
+	for _, secret := range artifact.Build.Secrets {
+		fmt.Fprintf(h, "secret %q file=%q env=%q\n", secret.Id, secret.File, secret.Env)
+	}
+
+	for _, ssh := range artifact.Build.SSH {
+		fmt.Fprintf(h, "ssh %q\n", ssh)
+	}
+
+	for _, name := range sortedKeys(dependencyHashes) {
+		fmt.Fprintf(h, "dependency %q %s\n", name, dependencyHashes[name])
+	}
+
+	dockerfileContents, err := os.ReadFile(dockerfile)
+	if err != nil {
+		return "", fmt.Errorf("could not read Dockerfile: %w", err)
+	}
+	fmt.Fprintf(h, "dockerfile %d\n", len(dockerfileContents))
+	h.Write(dockerfileContents)
+
+	err = hashBuildContext(h, contextDir, dockerfile)
+	if err != nil {
+		return "", fmt.Errorf("could not hash build context of artifact %s: %w", artifact.Name, err)
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// getDockerignorePatterns returns the exclude patterns of a build. Like BuildKit, a <Dockerfile>.dockerignore next to
+// the Dockerfile takes precedence over the .dockerignore in the root of the build context. dockerfile is empty for
+// the Dockerfile in the root of the build context.
+func getDockerignorePatterns(contextDir, dockerfile string) ([]string, error) {
+	paths := []string{filepath.Join(contextDir, ".dockerignore")}
+	if dockerfile != "" {
+		paths = append([]string{dockerfile + ".dockerignore"}, paths...)
+	}
+
+	for _, path := range paths {
+		file, err := os.Open(path)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, fmt.Errorf("could not open %s: %w", path, err)
+		}
+
+		patterns, err := ignorefile.ReadAll(file)
+		_ = file.Close()
+		if err != nil {
+			return nil, fmt.Errorf("could not read %s: %w", path, err)
+		}
+
+		return patterns, nil
+	}
+
+	return nil, nil
+}
+
+// hashBuildContext writes the paths, modes, and contents of all files in contextDir that would be sent to the
+// builder to h, in lexical order
+func hashBuildContext(h hash.Hash, contextDir, dockerfile string) error {
+	patterns, err := getDockerignorePatterns(contextDir, dockerfile)
+	if err != nil {
+		return err
+	}
+
+	matcher, err := patternmatcher.New(patterns)
+	if err != nil {
+		return fmt.Errorf("invalid .dockerignore: %w", err)
+	}
+
+	return filepath.WalkDir(contextDir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		relPath, err := filepath.Rel(contextDir, path)
+		if err != nil {
+			return err
+		}
+
+		if relPath == "." {
+			return nil
+		}
+
+		excluded, err := matcher.MatchesOrParentMatches(relPath)
+		if err != nil {
+			return err
+		}
+
+		if excluded {
+			// Exclusion patterns may include files below an excluded directory
+			if entry.IsDir() && !matcher.Exclusions() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+
+		name := filepath.ToSlash(relPath)
+
+		switch {
+		case entry.IsDir():
+			fmt.Fprintf(h, "dir %q %o\n", name, info.Mode().Perm())
+		case info.Mode()&fs.ModeSymlink != 0:
+			target, err := os.Readlink(path)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(h, "symlink %q %q\n", name, target)
+		case info.Mode().IsRegular():
+			fmt.Fprintf(h, "file %q %o %d\n", name, info.Mode().Perm(), info.Size())
+
+			file, err := os.Open(path)
+			if err != nil {
+				return err
+			}
+
+			_, err = io.Copy(h, file)
+			_ = file.Close()
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,96 @@
+package docker
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestHashBuildContext(t *testing.T) {
+	dir := t.TempDir()
+
+	writeFile := func(name, contents string) {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(contents), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	hashContext := func() string {
+		h := sha256.New()
+		err := hashBuildContext(h, dir, filepath.Join(dir, "Dockerfile"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return hex.EncodeToString(h.Sum(nil))
+	}
+
+	writeFile("Dockerfile", "FROM scratch\nCOPY . .\n")
+	writeFile("main.go", "package main\n")
+	writeFile("node_modules/dep/index.js", "module.exports = 1\n")
+	writeFile("logs/debug.log", "started\n")
+	writeFile("logs/keep.txt", "kept\n")
+	writeFile(".dockerignore", "# dependencies\nnode_modules\nlogs\n!logs/keep.txt\n")
+
+	initial := hashContext()
+	assert.Equal(t, initial, hashContext(), "hash must be stable")
+
+	// Ignored files do not change the hash
+	writeFile("node_modules/dep/index.js", "module.exports = 2\n")
+	writeFile("logs/debug.log", "restarted\n")
+	assert.Equal(t, initial, hashContext())
+
+	// Files included by exclusion patterns do
+	writeFile("logs/keep.txt", "changed\n")
+	changed := hashContext()
+	assert.NotEqual(t, initial, changed)
+
+	writeFile("main.go", "package main\n\nfunc main() {}\n")
+	assert.NotEqual(t, changed, hashContext())
+	changed = hashContext()
+
+	// File modes and new files are part of the hash
+	if err := os.Chmod(filepath.Join(dir, "main.go"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	assert.NotEqual(t, changed, hashContext())
+	changed = hashContext()
+
+	writeFile("pkg/util.go", "package pkg\n")
+	assert.NotEqual(t, changed, hashContext())
+	changed = hashContext()
+
+	// A Dockerfile-specific ignore file takes precedence over .dockerignore
+	writeFile("Dockerfile.dockerignore", "pkg\n")
+	assert.NotEqual(t, changed, hashContext())
+	writeFile("node_modules/dep/index.js", "module.exports = 3\n")
+	withSpecificIgnore := hashContext()
+	writeFile("node_modules/dep/index.js", "module.exports = 4\n")
+	assert.NotEqual(t, withSpecificIgnore, hashContext())
+}
+
+func TestGetDockerignorePatterns(t *testing.T) {
+	dir := t.TempDir()
+
+	patterns, err := getDockerignorePatterns(dir, filepath.Join(dir, "Dockerfile"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Empty(t, patterns)
+
+	if err := os.WriteFile(filepath.Join(dir, ".dockerignore"), []byte("# comment\n/dist\n\n*.log\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	patterns, err = getDockerignorePatterns(dir, filepath.Join(dir, "Dockerfile"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, []string{"dist", "*.log"}, patterns)
+}
@@ -0,0 +1,187 @@
+package docker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/client"
+	"github.com/docker/docker/pkg/jsonmessage"
+	controlapi "github.com/moby/buildkit/api/services/control"
+	"github.com/moby/buildkit/session"
+	"github.com/moby/buildkit/session/auth"
+	"github.com/moby/buildkit/session/secrets/secretsprovider"
+	"github.com/moby/buildkit/session/sshforward/sshprovider"
+	"github.com/opencontainers/go-digest"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"io"
+	"net"
+	"strings"
+)
+
+// buildKitTraceID marks progress messages of BuildKit builds, which carry an encoded status update
+const buildKitTraceID = "moby.buildkit.trace"
+
+// startBuildSession starts a BuildKit session serving registry credentials, secrets, and SSH agents of a build to the
+// builder. The session is closed when ctx is done.
+func startBuildSession(ctx context.Context, logger logrus.FieldLogger, cli *client.Client, authConfigPaths []string, options BuildOptions) (*session.Session, error) {
+	sess, err := session.NewSession(ctx, options.Name, "")
+	if err != nil {
+		return nil, fmt.Errorf("could not create build session: %w", err)
+	}
+
+	sess.Allow(&buildAuthProvider{authConfigPaths: authConfigPaths})
+
+	if len(options.Secrets) > 0 {
+		sources := make([]secretsprovider.Source, 0, len(options.Secrets))
+		for _, secret := range options.Secrets {
+			sources = append(sources, secretsprovider.Source{ID: secret.Id, FilePath: secret.Src, Env: secret.Env})
+		}
+
+		store, err := secretsprovider.NewStore(sources)
+		if err != nil {
+			return nil, fmt.Errorf("could not read build secrets: %w", err)
+		}
+
+		sess.Allow(secretsprovider.NewSecretProvider(store))
+	}
+
+	// SSH entries are either an agent id or id=<socket or keys>
+	if len(options.SSH) > 0 {
+		configs := make([]sshprovider.AgentConfig, 0, len(options.SSH))
+		for _, ssh := range options.SSH {
+			id, paths, ok := strings.Cut(ssh, "=")
+			config := sshprovider.AgentConfig{ID: id}
+			if ok {
+				config.Paths = strings.Split(paths, ",")
+			}
+			configs = append(configs, config)
+		}
+
+		provider, err := sshprovider.NewSSHAgentProvider(configs)
+		if err != nil {
+			return nil, fmt.Errorf("could not forward SSH agent: %w", err)
+		}
+
+		sess.Allow(provider)
+	}
+
+	go func() {
+		err := sess.Run(ctx, func(ctx context.Context, proto string, meta map[string][]string) (net.Conn, error) {
+			return cli.DialHijack(ctx, "/session", proto, meta)
+		})
+		if err != nil {
+			logger.WithError(err).Debugln("Build session ended")
+		}
+	}()
+
+	return sess, nil
+}
+
+// buildAuthProvider serves registry credentials from the runtime's auth configuration files to BuildKit, which
+// fetches tokens on its own
+type buildAuthProvider struct {
+	auth.UnimplementedAuthServer
+
+	authConfigPaths []string
+}
+
+func (p *buildAuthProvider) Register(server *grpc.Server) {
+	auth.RegisterAuthServer(server, p)
+}
+
+func (p *buildAuthProvider) Credentials(ctx context.Context, req *auth.CredentialsRequest) (*auth.CredentialsResponse, error) {
+	server := req.Host
+	if server == "registry-1.docker.io" {
+		server = dockerHubServer
+	}
+
+	for _, configPath := range p.authConfigPaths {
+		authConfig, err := getConfigCredentials(ctx, configPath, server)
+		if err != nil {
+			return nil, err
+		}
+
+		if authConfig == nil {
+			continue
+		}
+
+		if authConfig.IdentityToken != "" {
+			return &auth.CredentialsResponse{Secret: authConfig.IdentityToken}, nil
+		}
+
+		return &auth.CredentialsResponse{Username: authConfig.Username, Secret: authConfig.Password}, nil
+	}
+
+	return &auth.CredentialsResponse{}, nil
+}
+
+// getBuildAuthConfigs returns the credentials of all registries in the auth configuration files, which the legacy
+// builder uses to pull base images. Like for pulls, the first file with credentials for a registry is used.
+func getBuildAuthConfigs(ctx context.Context, configPaths []string) (map[string]types.AuthConfig, error) {
+	authConfigs := make(map[string]types.AuthConfig)
+
+	for _, configPath := range configPaths {
+		servers, err := getConfigServers(configPath)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, server := range servers {
+			if _, ok := authConfigs[server]; ok {
+				continue
+			}
+
+			authConfig, err := getConfigCredentials(ctx, configPath, server)
+			if err != nil {
+				return nil, err
+			}
+
+			if authConfig != nil {
+				authConfigs[server] = *authConfig
+			}
+		}
+	}
+
+	return authConfigs, nil
+}
+
+// writeBuildOutput writes the progress of a build to w and returns the first error reported by the builder. BuildKit
+// reports progress as encoded status updates, of which the names of started steps and their logs are written.
+func writeBuildOutput(r io.Reader, w io.Writer) error {
+	started := make(map[digest.Digest]struct{})
+
+	return jsonmessage.DisplayJSONMessagesStream(r, w, 0, false, func(message jsonmessage.JSONMessage) {
+		if message.ID != buildKitTraceID || message.Aux == nil {
+			return
+		}
+
+		var data []byte
+		if err := json.Unmarshal(*message.Aux, &data); err != nil {
+			return
+		}
+
+		var status controlapi.StatusResponse
+		if err := status.Unmarshal(data); err != nil {
+			return
+		}
+
+		for _, vertex := range status.Vertexes {
+			if _, ok := started[vertex.Digest]; ok || vertex.Started == nil {
+				continue
+			}
+			started[vertex.Digest] = struct{}{}
+
+			if vertex.Cached {
+				fmt.Fprintf(w, "%s (cached)\n", vertex.Name)
+				continue
+			}
+			fmt.Fprintln(w, vertex.Name)
+		}
+
+		for _, log := range status.Logs {
+			_, _ = w.Write(log.Msg)
+		}
+	})
+}
@@ -0,0 +1,56 @@
+package docker
+
+import (
+	"bytes"
+	"encoding/json"
+	controlapi "github.com/moby/buildkit/api/services/control"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWriteBuildOutput(t *testing.T) {
+	now := time.Now()
+	status := controlapi.StatusResponse{
+		Vertexes: []*controlapi.Vertex{
+			{Digest: "sha256:a", Name: "[1/2] FROM alpine", Started: &now, Cached: true},
+			{Digest: "sha256:b", Name: "[2/2] RUN make", Started: &now},
+			{Digest: "sha256:c", Name: "exporting to image"},
+		},
+		Logs: []*controlapi.VertexLog{{Vertex: "sha256:b", Msg: []byte("compiling\n")}},
+	}
+
+	data, err := status.Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	aux, err := json.Marshal(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	trace, err := json.Marshal(map[string]interface{}{"id": buildKitTraceID, "aux": json.RawMessage(aux)})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	stream := strings.Join([]string{
+		`{"stream":"Step 1/2 : FROM alpine\n"}`,
+		string(trace),
+		string(trace),
+	}, "\n")
+
+	var output bytes.Buffer
+	err = writeBuildOutput(strings.NewReader(stream), &output)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Steps are written once, when they start
+	assert.Equal(t, "Step 1/2 : FROM alpine\n[1/2] FROM alpine (cached)\n[2/2] RUN make\ncompiling\ncompiling\n", output.String())
+
+	err = writeBuildOutput(strings.NewReader(`{"errorDetail":{"message":"RUN make failed"},"error":"RUN make failed"}`), &output)
+	assert.ErrorContains(t, err, "RUN make failed")
+}
@@ -3,79 +3,57 @@ package docker
 import (
 	"context"
 	"fmt"
-	"github.com/brunoscheufler/atlas/exec"
 	"github.com/sirupsen/logrus"
+	"golang.org/x/sync/errgroup"
 )
 
-func CleanupAll(ctx context.Context, logger logrus.FieldLogger) error {
-
+// RemoveResources removes containers, then volumes, then networks
+func RemoveResources(ctx context.Context, logger logrus.FieldLogger, resources *LabeledResources) error {
 	logger.Infoln("Cleaning up containers")
-	err := exec.RunCommand(ctx, logger, "docker container stop $(docker container ls -q --filter Name=atlas-) || true", exec.RunCommandOptions{})
-	if err != nil {
-		return fmt.Errorf("could not remove containers: %w", err)
+
+	g, groupCtx := errgroup.WithContext(ctx)
+	for _, container := range resources.Containers {
+		container := container
+		g.Go(func() error {
+			return DeleteContainer(groupCtx, logger, container.Name)
+		})
 	}
 
-	err = exec.RunCommand(ctx, logger, "docker container rm -f -v $(docker container ls -a -q --filter Name=atlas-) || true", exec.RunCommandOptions{})
+	err := g.Wait()
 	if err != nil {
 		return fmt.Errorf("could not remove containers: %w", err)
 	}
 
 	logger.Infoln("Cleaning up volumes")
-	err = exec.RunCommand(ctx, logger, "docker volume rm -f $(docker volume ls -q --filter Name=atlas-) || true", exec.RunCommandOptions{})
-	if err != nil {
-		return fmt.Errorf("could not remove volumes: %w", err)
+	for _, volume := range resources.Volumes {
+		err = DeleteVolume(ctx, logger, volume.Name)
+		if err != nil {
+			return fmt.Errorf("could not remove volumes: %w", err)
+		}
 	}
 
 	logger.Infoln("Cleaning up networks")
-	err = exec.RunCommand(ctx, logger, "docker network rm $(docker network ls -q --filter Name=atlas-) || true", exec.RunCommandOptions{})
-	if err != nil {
-		return fmt.Errorf("could not remove networks: %w", err)
+	for _, network := range resources.Networks {
+		err = DeleteNetwork(ctx, logger, network.Name)
+		if err != nil {
+			return fmt.Errorf("could not remove networks: %w", err)
+		}
 	}
 
 	return nil
 }
 
+// DeleteContainer stops and removes a container and its anonymous volumes, if it exists
 func DeleteContainer(ctx context.Context, logger logrus.FieldLogger, containerName string) error {
-	existingContainer, err := GetContainerInfo(ctx, containerName)
-	if err != nil {
-		return fmt.Errorf("could not get container info: %w", err)
-	}
-
-	if existingContainer == nil {
-		return nil
-	}
-
-	err = exec.RunCommand(ctx, logger, fmt.Sprintf("docker container stop %s && docker container rm -f -v %s", containerName, containerName), exec.RunCommandOptions{})
-	if err != nil {
-		return fmt.Errorf("could not delete network: %w", err)
-	}
-
-	return nil
+	return getRuntime().RemoveContainer(ctx, logger, containerName)
 }
 
+// DeleteNetwork removes a network, if it exists
 func DeleteNetwork(ctx context.Context, logger logrus.FieldLogger, networkName string) error {
-	existingId, err := GetNetworkId(ctx, networkName)
-	if err != nil {
-		return fmt.Errorf("could not get network id: %w", err)
-	}
-
-	if existingId == "" {
-		return nil
-	}
-
-	err = exec.RunCommand(ctx, logger, fmt.Sprintf("docker network rm %s", networkName), exec.RunCommandOptions{})
-	if err != nil {
-		return fmt.Errorf("could not delete network: %w", err)
-	}
-
-	return nil
+	return getRuntime().RemoveNetwork(ctx, logger, networkName)
 }
 
+// DeleteVolume removes a volume, if it exists
 func DeleteVolume(ctx context.Context, logger logrus.FieldLogger, volumeName string) error {
-	err := exec.RunCommand(ctx, logger, fmt.Sprintf("docker volume rm -f %s", volumeName), exec.RunCommandOptions{})
-	if err != nil {
-		return fmt.Errorf("could not delete volume %s: %w", volumeName, err)
-	}
-
-	return nil
+	return getRuntime().RemoveVolume(ctx, logger, volumeName)
 }
@@ -1,44 +1,162 @@
 package docker
 
 import (
+	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"github.com/brunoscheufler/atlas/atlasfile"
-	"github.com/brunoscheufler/atlas/exec"
 	"github.com/brunoscheufler/atlas/helper"
 	"github.com/docker/docker/api/types"
-	"github.com/docker/docker/api/types/filters"
-	"github.com/docker/docker/client"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/network"
+	"github.com/docker/go-connections/nat"
 	"github.com/sirupsen/logrus"
 	"path/filepath"
+	"sort"
 	"strings"
 	"time"
 )
 
-func CreateServiceContainer(
-	ctx context.Context,
-	logger logrus.FieldLogger,
+// ContainerConfig is the effective configuration of a service container
+type ContainerConfig struct {
+	Hostname string `json:"hostname"`
+	Image    string `json:"image"`
+
+	Entrypoint []string `json:"entrypoint,omitempty"`
+	Command    []string `json:"command,omitempty"`
+
+	// Env contains KEY=value pairs, sorted by key
+	Env []string `json:"env,omitempty"`
+
+	Restart string `json:"restart"`
+
+	// Mounts contains host paths or volume names mounted into the container, as source:target
+	Mounts []string `json:"mounts,omitempty"`
+
+	Network string `json:"network,omitempty"`
+
+	// Ports contains published ports, as hostPort:containerPort/protocol
+	Ports []string `json:"ports,omitempty"`
+
+	Healthcheck *container.HealthConfig `json:"healthcheck,omitempty"`
+
+	Interactive bool `json:"interactive,omitempty"`
+	TTY         bool `json:"tty,omitempty"`
+
+	// JoinNetworks are connected to the container after it was created
+	JoinNetworks []string `json:"joinNetworks"`
+}
+
+// Hash returns a hash of the config and the ID of the image the container is created from, which changes
+// whenever the container would be created differently
+func (c *ContainerConfig) Hash(imageId string) (string, error) {
+	marshaled, err := json.Marshal(c)
+	if err != nil {
+		return "", fmt.Errorf("could not marshal container config: %w", err)
+	}
+
+	h := sha256.New()
+	h.Write(marshaled)
+	h.Write([]byte(imageId))
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// Args returns docker run arguments equivalent to the config, excluding the container name. Containers are created
+// using the Engine API, the arguments are only used to show what would be created.
+func (c *ContainerConfig) Args() []string {
+	args := []string{"--hostname", c.Hostname, "--restart", c.Restart}
+
+	for _, env := range c.Env {
+		key, value, _ := strings.Cut(env, "=")
+		args = append(args, "-e", fmt.Sprintf("%s=%q", key, value))
+	}
+
+	for _, mount := range c.Mounts {
+		args = append(args, "-v", mount)
+	}
+
+	if c.Network != "" {
+		args = append(args, "--network", c.Network)
+	}
+
+	for _, port := range c.Ports {
+		args = append(args, "-p", port)
+	}
+
+	if len(c.Entrypoint) > 0 {
+		args = append(args, "--entrypoint", strings.Join(c.Entrypoint, " "))
+	}
+
+	if c.Healthcheck != nil {
+		healthCmd := c.Healthcheck.Test[1]
+		if c.Healthcheck.Test[0] == "CMD" {
+			quoted := make([]string, 0, len(c.Healthcheck.Test)-1)
+			for _, arg := range c.Healthcheck.Test[1:] {
+				quoted = append(quoted, helper.ShellQuote(arg))
+			}
+			healthCmd = strings.Join(quoted, " ")
+		}
+
+		args = append(args, "--health-cmd", fmt.Sprintf("%q", healthCmd))
+
+		if c.Healthcheck.Interval != 0 {
+			args = append(args, "--health-interval", c.Healthcheck.Interval.String())
+		}
+
+		if c.Healthcheck.Timeout != 0 {
+			args = append(args, "--health-timeout", c.Healthcheck.Timeout.String())
+		}
+
+		if c.Healthcheck.Retries != 0 {
+			args = append(args, "--health-retries", fmt.Sprint(c.Healthcheck.Retries))
+		}
+
+		if c.Healthcheck.StartPeriod != 0 {
+			args = append(args, "--health-start-period", c.Healthcheck.StartPeriod.String())
+		}
+	}
+
+	if c.Interactive {
+		args = append(args, "-i")
+	}
+
+	if c.TTY {
+		args = append(args, "-t")
+	}
+
+	args = append(args, c.Image)
+	args = append(args, c.Command...)
+
+	return args
+}
+
+// GetServiceContainerConfig resolves the configuration of a stack service container. Values are ordered
+// deterministically so that the config hash only changes if the configuration changed.
+func GetServiceContainerConfig(
 	stack *atlasfile.StackConfig,
 	service *atlasfile.ServiceConfig,
 	stackService *atlasfile.StackService,
 	file *atlasfile.Atlasfile,
 	ensuredVolumes EnsuredVolumes,
 	ensuredNetworks EnsuredNetworks,
-	containerName string,
-) error {
-	args := []string{
-		"run",
-		"-d",
-		"--name",
-		containerName,
-		"--hostname",
-		service.Name,
+) (*ContainerConfig, error) {
+	config := &ContainerConfig{
+		Hostname:    service.Name,
+		Entrypoint:  service.Entrypoint,
+		Command:     service.Command,
+		Restart:     string(service.Restart),
+		Network:     ensuredNetworks.Get(stack.Name),
+		Interactive: service.Interactive,
+		TTY:         service.TTY,
 	}
 
-	if string(service.Restart) == "" {
-		service.Restart = atlasfile.ContainerRestartsAlways
+	if config.Restart == "" {
+		config.Restart = atlasfile.ContainerRestartsAlways
 	}
-	args = append(args, "--restart", string(service.Restart))
 
 	envVars := make(map[string]string)
 
@@ -48,7 +166,7 @@ func CreateServiceContainer(
 
 			readVars, err := helper.ReadEnvFile(filePath)
 			if err != nil {
-				return fmt.Errorf("could not read environment file %s: %w", file, err)
+				return nil, fmt.Errorf("could not read environment file %s: %w", file, err)
 			}
 
 			for k, v := range readVars {
@@ -69,138 +187,254 @@ func CreateServiceContainer(
 		}
 	}
 
-	for key, value := range envVars {
-		args = append(args, "-e", fmt.Sprintf("%s=%q", key, value))
+	envKeys := make([]string, 0, len(envVars))
+	for key := range envVars {
+		envKeys = append(envKeys, key)
+	}
+	sort.Strings(envKeys)
+
+	for _, key := range envKeys {
+		config.Env = append(config.Env, fmt.Sprintf("%s=%s", key, envVars[key]))
 	}
 
 	if service.Volumes != nil {
 		for _, volume := range service.Volumes {
 			volName := volume.GetVolumeNameOrHostPath(filepath.Dir(service.GetDirpath()), ensuredVolumes.Get(stack.Name, service.Name, volume.HostPathOrVolumeName))
-			args = append(args, "-v", fmt.Sprintf("%s:%s", volName, volume.ContainerPath))
+			config.Mounts = append(config.Mounts, fmt.Sprintf("%s:%s", volName, volume.ContainerPath))
 		}
 	}
 
-	netName := ensuredNetworks.Get(stack.Name)
-	if netName != "" {
-		args = append(args, "--network", netName)
-	}
-
 	if stackService.ExposePorts != nil {
 		for _, expose := range stackService.ExposePorts {
 			servicePortRequest := atlasfile.GetServicePort(service.Ports, expose.ContainerPort)
 			if servicePortRequest == nil {
-				return fmt.Errorf("could not find port %d in service %s", expose.ContainerPort, service.Name)
+				return nil, fmt.Errorf("could not find port %d in service %s", expose.ContainerPort, service.Name)
 			}
 
-			args = append(args, "-p", fmt.Sprintf("%d:%d/%s", expose.HostPort, expose.ContainerPort, servicePortRequest.Protocol))
+			config.Ports = append(config.Ports, fmt.Sprintf("%d:%d/%s", expose.HostPort, expose.ContainerPort, servicePortRequest.Protocol))
 		}
 	}
 
-	if service.Entrypoint != nil {
-		args = append(args, "--entrypoint", strings.Join(service.Entrypoint, " "))
-	}
-
-	if service.Interactive {
-		args = append(args, "-i")
-	}
+	if service.Healthcheck != nil {
+		healthcheck, err := getHealthConfig(service.Healthcheck)
+		if err != nil {
+			return nil, fmt.Errorf("invalid healthcheck of service %s: %w", service.Name, err)
+		}
 
-	if service.TTY {
-		args = append(args, "-t")
+		config.Healthcheck = healthcheck
 	}
 
 	imageName, err := file.GetServiceImage(service)
 	if err != nil {
-		return fmt.Errorf("could not get service image: %w", err)
-	}
-	args = append(args, imageName)
-
-	if service.Command != nil {
-		args = append(args, service.Command...)
-	}
-
-	err = exec.RunCommand(ctx, logger, fmt.Sprintf("docker %s", strings.Join(args, " ")), exec.RunCommandOptions{})
-	if err != nil {
-		return fmt.Errorf("could not create container %s: %w", containerName, err)
+		return nil, fmt.Errorf("could not get service image: %w", err)
 	}
+	config.Image = imageName
 
 	if stackService.JoinStackNetworks != nil {
 		for _, stackName := range stackService.JoinStackNetworks {
 			netName := ensuredNetworks.Get(stackName)
 			if netName == "" {
-				return fmt.Errorf("could not find network for stack %s", stackName)
+				return nil, fmt.Errorf("could not find network for stack %s", stackName)
 			}
 
-			err = exec.RunCommand(ctx, logger, fmt.Sprintf("docker network connect %s %s", netName, containerName), exec.RunCommandOptions{})
-			if err != nil {
-				return fmt.Errorf("could not connect container %s to network %s: %w", containerName, netName, err)
-			}
+			config.JoinNetworks = append(config.JoinNetworks, netName)
 		}
 	}
 
-	return nil
+	return config, nil
 }
 
-type ContainerInfos struct {
-	FetchedAt string `json:"fetchedAt"`
-	Id        string `json:"id"`
-	Name      string `json:"name"`
-	Status    string `json:"status"`
-	State     string `json:"state"`
+func getHealthConfig(healthcheck *atlasfile.Healthcheck) (*container.HealthConfig, error) {
+	config := &container.HealthConfig{
+		Test:    healthcheck.Test(),
+		Retries: healthcheck.Retries,
+	}
+
+	for _, duration := range []struct {
+		value  string
+		target *time.Duration
+	}{
+		{healthcheck.Interval, &config.Interval},
+		{healthcheck.Timeout, &config.Timeout},
+		{healthcheck.StartPeriod, &config.StartPeriod},
+	} {
+		if duration.value == "" {
+			continue
+		}
+
+		parsed, err := time.ParseDuration(duration.value)
+		if err != nil {
+			return nil, err
+		}
+
+		*duration.target = parsed
+	}
+
+	return config, nil
 }
 
-func GetContainerInfo(ctx context.Context, containerName string) (*ContainerInfos, error) {
-	cli, err := client.NewClientWithOpts(client.FromEnv)
+// createOptions returns the Engine API configuration to create a container from config
+func (c *ContainerConfig) createOptions(labels map[string]string) (*container.Config, *container.HostConfig, *network.NetworkingConfig, error) {
+	exposedPorts, portBindings, err := nat.ParsePortSpecs(c.Ports)
 	if err != nil {
-		return nil, fmt.Errorf("could not create docker client: %w", err)
+		return nil, nil, nil, fmt.Errorf("could not parse ports: %w", err)
 	}
 
-	containers, err := cli.ContainerList(ctx, types.ContainerListOptions{
-		All: true,
-		Filters: filters.NewArgs(
-			filters.Arg("name", containerName),
-		),
-	})
-	if err != nil {
-		return nil, fmt.Errorf("could not list containers: %w", err)
+	containerConfig := &container.Config{
+		Hostname:     c.Hostname,
+		Image:        c.Image,
+		Entrypoint:   c.Entrypoint,
+		Cmd:          c.Command,
+		Env:          c.Env,
+		Labels:       labels,
+		ExposedPorts: exposedPorts,
+		Healthcheck:  c.Healthcheck,
+		OpenStdin:    c.Interactive,
+		Tty:          c.TTY,
+	}
+
+	hostConfig := &container.HostConfig{
+		Binds:         c.Mounts,
+		PortBindings:  portBindings,
+		RestartPolicy: container.RestartPolicy{Name: c.Restart},
 	}
 
-	if len(containers) == 0 {
-		return nil, nil
+	networkingConfig := &network.NetworkingConfig{}
+	if c.Network != "" {
+		hostConfig.NetworkMode = container.NetworkMode(c.Network)
+		networkingConfig.EndpointsConfig = map[string]*network.EndpointSettings{c.Network: {}}
 	}
 
-	return &ContainerInfos{
-		FetchedAt: time.Now().Format(time.RFC3339),
-		Id:        containers[0].ID,
-		Name:      containers[0].Names[0],
-		Status:    containers[0].Status,
-		State:     containers[0].State,
-	}, nil
+	return containerConfig, hostConfig, networkingConfig, nil
 }
 
-func StartContainer(ctx context.Context, containerName string) error {
-	cli, err := client.NewClientWithOpts(client.FromEnv)
+// CreateContainer creates and starts a labeled container from config
+func CreateContainer(ctx context.Context, logger logrus.FieldLogger, containerName string, config *ContainerConfig, labels map[string]string) error {
+	return getRuntime().CreateContainer(ctx, logger, containerName, config, labels)
+}
+
+// PullImage pulls an image if it does not exist locally, using the registry credentials of the runtime
+func PullImage(ctx context.Context, logger logrus.FieldLogger, imageName string) error {
+	imageId, err := GetImageId(ctx, imageName)
 	if err != nil {
-		return fmt.Errorf("could not create docker client: %w", err)
+		return err
 	}
 
-	err = cli.ContainerStart(ctx, containerName, types.ContainerStartOptions{})
-	if err != nil {
-		return fmt.Errorf("could not start container %s: %w", containerName, err)
+	if imageId != "" {
+		return nil
 	}
 
-	return nil
+	return getRuntime().PullImage(ctx, logger, imageName)
+}
+
+// GetImageId returns the ID of a local image or an empty string if the image does not exist locally
+func GetImageId(ctx context.Context, imageName string) (string, error) {
+	return getRuntime().GetImageId(ctx, imageName)
+}
+
+type ContainerInfos struct {
+	FetchedAt string `json:"fetchedAt"`
+	Id        string `json:"id"`
+	Name      string `json:"name"`
+	Status    string `json:"status"`
+	State     string `json:"state"`
+}
+
+func GetContainerInfo(ctx context.Context, containerName string) (*ContainerInfos, error) {
+	return getRuntime().GetContainerInfo(ctx, containerName)
+}
+
+func StartContainer(ctx context.Context, containerName string) error {
+	return getRuntime().StartContainer(ctx, containerName)
 }
 
 func StopContainer(ctx context.Context, containerName string) error {
-	cli, err := client.NewClientWithOpts(client.FromEnv)
+	return getRuntime().StopContainer(ctx, containerName)
+}
+
+// WaitForContainer blocks until the container meets the dependency condition or fails to ever meet it. If ctx is done
+// first, the returned error wraps ctx.Err() and includes the last state of the container.
+func WaitForContainer(ctx context.Context, containerName string, condition atlasfile.ServiceDependencyCondition) error {
+	for {
+		state, err := getRuntime().InspectContainer(ctx, containerName)
+		if err != nil {
+			return err
+		}
+
+		switch condition {
+		case atlasfile.ServiceDependencyStarted:
+			if state.Running {
+				return nil
+			}
+
+			// Only completed dependencies may exit, the container of a started dependency crashed
+			if state.Status == "exited" || state.Status == "dead" {
+				return fmt.Errorf("container %s exited with code %d", containerName, state.ExitCode)
+			}
+		case atlasfile.ServiceDependencyHealthy:
+			if !state.Healthcheck {
+				return fmt.Errorf("container %s has no healthcheck", containerName)
+			}
+
+			switch state.Health {
+			case types.Healthy:
+				return nil
+			case types.Unhealthy:
+				return fmt.Errorf("container %s is unhealthy", containerName)
+			}
+
+			if state.Status == "exited" || state.Status == "dead" {
+				return fmt.Errorf("container %s exited with code %d before becoming healthy", containerName, state.ExitCode)
+			}
+		case atlasfile.ServiceDependencyCompleted:
+			if state.Status == "exited" {
+				if state.ExitCode != 0 {
+					return fmt.Errorf("container %s exited with code %d", containerName, state.ExitCode)
+				}
+				return nil
+			}
+
+			if state.Status == "dead" {
+				return fmt.Errorf("container %s is dead", containerName)
+			}
+		default:
+			return fmt.Errorf("unknown condition %q", condition)
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("container %s is %s: %w", containerName, describeContainerState(state), ctx.Err())
+		case <-time.After(500 * time.Millisecond):
+		}
+	}
+}
+
+// describeContainerState returns the status of a container together with its health, e.g. "running (health: starting)"
+func describeContainerState(state *ContainerState) string {
+	if state.Health != "" {
+		return fmt.Sprintf("%s (health: %s)", state.Status, state.Health)
+	}
+	return state.Status
+}
+
+// HasHealthcheck returns true if the container was created with a healthcheck, either configured by Atlas or the image
+func HasHealthcheck(ctx context.Context, containerName string) (bool, error) {
+	state, err := getRuntime().InspectContainer(ctx, containerName)
 	if err != nil {
-		return fmt.Errorf("could not create docker client: %w", err)
+		return false, err
 	}
 
-	err = cli.ContainerStop(ctx, containerName, nil)
+	return state.Healthcheck, nil
+}
+
+// GetContainerLogs returns the last lines of combined stdout and stderr output of the container
+func GetContainerLogs(ctx context.Context, containerName string, tail int) (string, error) {
+	var output bytes.Buffer
+
+	err := getRuntime().ContainerLogs(ctx, containerName, LogsOptions{Tail: fmt.Sprint(tail)}, &output, &output)
 	if err != nil {
-		return fmt.Errorf("could not stop container %s: %w", containerName, err)
+		return "", err
 	}
 
-	return nil
+	return output.String(), nil
 }
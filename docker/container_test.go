@@ -0,0 +1,121 @@
+package docker
+
+import (
+	"github.com/brunoscheufler/atlas/atlasfile"
+	"github.com/docker/go-connections/nat"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestServiceContainerConfigHash(t *testing.T) {
+	service := atlasfile.ServiceConfig{
+		Name:  "db",
+		Image: "postgres:14",
+		Environment: map[string]string{
+			"POSTGRES_USER":     "atlas",
+			"POSTGRES_PASSWORD": "atlas",
+			"POSTGRES_DB":       "atlas",
+			"PGDATA":            "/var/lib/postgresql/data",
+		},
+	}
+
+	stack := atlasfile.StackConfig{
+		Name:     "local",
+		Services: []atlasfile.StackService{{Name: "db"}},
+	}
+
+	file := &atlasfile.Atlasfile{Services: []atlasfile.ServiceConfig{service}, Stacks: []atlasfile.StackConfig{stack}}
+	networks := EnsuredNetworks{{Stack: "local", PhysicalName: "atlas-local"}}
+
+	getHash := func(service atlasfile.ServiceConfig, imageId string) string {
+		config, err := GetServiceContainerConfig(&stack, &service, &stack.Services[0], file, EnsuredVolumes{}, networks)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		hash, err := config.Hash(imageId)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return hash
+	}
+
+	hash := getHash(service, "sha256:a")
+
+	// Environment variables are not ordered by map iteration
+	for i := 0; i < 10; i++ {
+		assert.Equal(t, hash, getHash(service, "sha256:a"))
+	}
+
+	assert.NotEqual(t, hash, getHash(service, "sha256:b"))
+
+	changed := service
+	changed.Environment = map[string]string{"POSTGRES_USER": "other"}
+	assert.NotEqual(t, hash, getHash(changed, "sha256:a"))
+}
+
+func TestContainerConfigCreateOptions(t *testing.T) {
+	service := atlasfile.ServiceConfig{
+		Name:  "api",
+		Image: "atlas-api",
+		Environment: map[string]string{
+			"PASSWORD": "a$b`c`\nd\"e",
+		},
+		Ports:       []atlasfile.PortRequest{{ContainerPort: 8080, Protocol: "tcp"}},
+		Healthcheck: &atlasfile.Healthcheck{HTTPGet: "http://localhost:8080", Interval: "2s"},
+	}
+
+	stack := atlasfile.StackConfig{
+		Name:     "local",
+		Services: []atlasfile.StackService{{Name: "api", ExposePorts: []atlasfile.PortExpose{{ContainerPort: 8080, HostPort: 80}}}},
+	}
+
+	file := &atlasfile.Atlasfile{Services: []atlasfile.ServiceConfig{service}, Stacks: []atlasfile.StackConfig{stack}}
+	networks := EnsuredNetworks{{Stack: "local", PhysicalName: "atlas-local"}}
+
+	config, err := GetServiceContainerConfig(&stack, &service, &stack.Services[0], file, EnsuredVolumes{}, networks)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	containerConfig, hostConfig, networkingConfig, err := config.createOptions(map[string]string{LabelStack: "local"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Values are passed verbatim
+	assert.Equal(t, []string{"PASSWORD=a$b`c`\nd\"e"}, containerConfig.Env)
+	assert.Equal(t, map[string]string{LabelStack: "local"}, containerConfig.Labels)
+	assert.Equal(t, "CMD-SHELL", containerConfig.Healthcheck.Test[0])
+	assert.Equal(t, 2*time.Second, containerConfig.Healthcheck.Interval)
+	assert.Contains(t, containerConfig.ExposedPorts, nat.Port("8080/tcp"))
+
+	assert.Equal(t, []nat.PortBinding{{HostPort: "80"}}, hostConfig.PortBindings["8080/tcp"])
+	assert.Equal(t, "always", hostConfig.RestartPolicy.Name)
+	assert.Equal(t, "atlas-local", string(hostConfig.NetworkMode))
+	assert.Contains(t, networkingConfig.EndpointsConfig, "atlas-local")
+
+	assert.Equal(t, []string{
+		"--hostname", "api",
+		"--restart", "always",
+		"-e", `PASSWORD="a$b` + "`c`" + `\nd\"e"`,
+		"--network", "atlas-local",
+		"-p", "80:8080/tcp",
+		"--health-cmd", `"wget -q -O /dev/null 'http://localhost:8080' || curl -fsS -o /dev/null 'http://localhost:8080'"`,
+		"--health-interval", "2s",
+		"atlas-api",
+	}, config.Args())
+
+	// Commands are executed without a shell
+	service.Healthcheck = &atlasfile.Healthcheck{Command: []string{"pg_isready", "-d", "my db"}}
+
+	config, err = GetServiceContainerConfig(&stack, &service, &stack.Services[0], file, EnsuredVolumes{}, networks)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, []string{"CMD", "pg_isready", "-d", "my db"}, config.Healthcheck.Test)
+	assert.Contains(t, config.Args(), `"'pg_isready' '-d' 'my db'"`)
+}
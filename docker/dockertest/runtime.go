@@ -0,0 +1,805 @@
+// Package dockertest provides an in-memory container runtime for testing code that manages resources through the
+// docker package, without a container engine.
+package dockertest
+
+import (
+	"context"
+	"fmt"
+	"github.com/brunoscheufler/atlas/atlasfile"
+	"github.com/brunoscheufler/atlas/docker"
+	"github.com/sirupsen/logrus"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Operations of the runtime that failures can be injected into with FailOn
+const (
+	OpPing             = "Ping"
+	OpBuildImage       = "BuildImage"
+	OpPullImage        = "PullImage"
+	OpCreateContainer  = "CreateContainer"
+	OpStartContainer   = "StartContainer"
+	OpStopContainer    = "StopContainer"
+	OpRemoveContainer  = "RemoveContainer"
+	OpCreateNetwork    = "CreateNetwork"
+	OpRemoveNetwork    = "RemoveNetwork"
+	OpCreateVolume     = "CreateVolume"
+	OpRemoveVolume     = "RemoveVolume"
+	OpExportVolume     = "ExportVolume"
+	OpImportVolume     = "ImportVolume"
+	OpExec             = "Exec"
+	OpFindResources    = "FindResources"
+	OpGetContainerInfo = "GetContainerInfo"
+)
+
+type Container struct {
+	Id     string
+	Name   string
+	Config docker.ContainerConfig
+	Labels map[string]string
+
+	Running  bool
+	ExitCode int
+
+	// Started is false for containers that were created but never started
+	Started bool
+
+	// Health is the health status of a container with a healthcheck, set to healthy when the container starts unless
+	// SetStartHealth was called for it
+	Health string
+
+	// Logs are written to stdout when reading the logs of the container
+	Logs string
+}
+
+type Image struct {
+	Id     string
+	Name   string
+	Labels map[string]string
+}
+
+type Network struct {
+	Id     string
+	Name   string
+	Labels map[string]string
+}
+
+type Volume struct {
+	Name   string
+	Labels map[string]string
+
+	// Data is the archive the volume was last imported from, and written when exporting the volume
+	Data []byte
+}
+
+type failure struct {
+	op   string
+	name string
+	err  error
+}
+
+// Runtime implements docker.Runtime in memory. Containers are started when they are created and stay running until
+// they are stopped or SetExited is called. Networks and volumes cannot be removed while containers use them, like
+// with a real engine.
+type Runtime struct {
+	mu sync.Mutex
+
+	images     map[string]*Image
+	containers map[string]*Container
+	networks   map[string]*Network
+	volumes    map[string]*Volume
+
+	failures    []failure
+	startHealth map[string]string
+	calls       []string
+	nextId      int
+}
+
+// NewRuntime returns an empty runtime
+func NewRuntime() *Runtime {
+	return &Runtime{
+		images:      make(map[string]*Image),
+		containers:  make(map[string]*Container),
+		networks:    make(map[string]*Network),
+		volumes:     make(map[string]*Volume),
+		startHealth: make(map[string]string),
+	}
+}
+
+// FailOn makes op fail with err for resources whose name contains name, or for all resources if name is empty.
+// Failures apply until Reset is called.
+func (r *Runtime) FailOn(op, name string, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.failures = append(r.failures, failure{op: op, name: name, err: err})
+}
+
+// Reset removes all injected failures
+func (r *Runtime) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.failures = nil
+}
+
+// Calls returns all operations that were run, formatted as "<op> <name>"
+func (r *Runtime) Calls() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]string(nil), r.calls...)
+}
+
+// Container returns a copy of a container or nil if it does not exist
+func (r *Runtime) Container(name string) *Container {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	container, ok := r.containers[name]
+	if !ok {
+		return nil
+	}
+
+	c := *container
+	return &c
+}
+
+// Containers returns the sorted names of all containers
+func (r *Runtime) Containers() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return sortedKeys(r.containers)
+}
+
+// Networks returns the sorted names of all networks
+func (r *Runtime) Networks() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return sortedKeys(r.networks)
+}
+
+// Volumes returns the sorted names of all volumes
+func (r *Runtime) Volumes() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return sortedKeys(r.volumes)
+}
+
+// Image returns a copy of an image or nil if it does not exist
+func (r *Runtime) Image(name string) *Image {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	image, ok := r.images[name]
+	if !ok {
+		return nil
+	}
+
+	i := *image
+	return &i
+}
+
+// RemoveImage simulates an image being removed outside of Atlas, e.g. with docker rmi
+func (r *Runtime) RemoveImage(name string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.images, name)
+}
+
+// Volume returns a copy of a volume or nil if it does not exist
+func (r *Runtime) Volume(name string) *Volume {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	volume, ok := r.volumes[name]
+	if !ok {
+		return nil
+	}
+
+	v := *volume
+	return &v
+}
+
+// SetExited simulates a container exiting on its own with exitCode
+func (r *Runtime) SetExited(name string, exitCode int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if container, ok := r.containers[name]; ok {
+		container.Running = false
+		container.ExitCode = exitCode
+	}
+}
+
+// SetHealth simulates the healthcheck of a container reporting health, e.g. unhealthy
+func (r *Runtime) SetHealth(name, health string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if container, ok := r.containers[name]; ok {
+		container.Health = health
+	}
+}
+
+// SetStartHealth makes containers whose name contains name report health instead of healthy when they start, e.g.
+// starting to simulate a healthcheck that never passes
+func (r *Runtime) SetStartHealth(name, health string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.startHealth[name] = health
+}
+
+// SetLogs sets the logs returned for a container
+func (r *Runtime) SetLogs(name, logs string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if container, ok := r.containers[name]; ok {
+		container.Logs = logs
+	}
+}
+
+// RemoveContainerExternally simulates a container being removed outside of Atlas, e.g. with docker rm
+func (r *Runtime) RemoveContainerExternally(name string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.containers, name)
+}
+
+// record logs a call and returns the injected failure for it, if any. The caller must hold the lock.
+func (r *Runtime) record(op, name string) error {
+	r.calls = append(r.calls, strings.TrimSpace(op+" "+name))
+
+	for _, f := range r.failures {
+		if f.op == op && strings.Contains(name, f.name) {
+			return f.err
+		}
+	}
+
+	return nil
+}
+
+// getStartHealth returns the health a container reports when it starts. The caller must hold the lock.
+func (r *Runtime) getStartHealth(containerName string) string {
+	for name, health := range r.startHealth {
+		if strings.Contains(containerName, name) {
+			return health
+		}
+	}
+
+	return "healthy"
+}
+
+func (r *Runtime) newId() string {
+	r.nextId++
+	return fmt.Sprintf("%064x", r.nextId)
+}
+
+func (r *Runtime) Name() string {
+	return "fake"
+}
+
+func (r *Runtime) Ping(ctx context.Context) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.record(OpPing, "")
+}
+
+func (r *Runtime) BuildImage(ctx context.Context, logger logrus.FieldLogger, options docker.BuildOptions) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	err := r.record(OpBuildImage, options.Image)
+	if err != nil {
+		return err
+	}
+
+	// Every build produces a new image
+	r.images[options.Image] = &Image{Id: "sha256:" + r.newId(), Name: options.Image, Labels: copyLabels(options.Labels)}
+
+	return nil
+}
+
+func (r *Runtime) PullImage(ctx context.Context, logger logrus.FieldLogger, imageName string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	err := r.record(OpPullImage, imageName)
+	if err != nil {
+		return err
+	}
+
+	if _, ok := r.images[imageName]; !ok {
+		r.images[imageName] = &Image{Id: "sha256:" + r.newId(), Name: imageName, Labels: make(map[string]string)}
+	}
+
+	return nil
+}
+
+func (r *Runtime) GetImageId(ctx context.Context, imageName string) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	image, ok := r.images[imageName]
+	if !ok {
+		return "", nil
+	}
+
+	return image.Id, nil
+}
+
+func (r *Runtime) GetImageLabels(ctx context.Context, imageName string) (map[string]string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	image, ok := r.images[imageName]
+	if !ok {
+		return nil, nil
+	}
+
+	return copyLabels(image.Labels), nil
+}
+
+func (r *Runtime) CreateContainer(ctx context.Context, logger logrus.FieldLogger, containerName string, config *docker.ContainerConfig, labels map[string]string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	err := r.record(OpCreateContainer, containerName)
+	if err != nil {
+		return err
+	}
+
+	if _, ok := r.containers[containerName]; ok {
+		return fmt.Errorf("container name %s is already in use", containerName)
+	}
+
+	if _, ok := r.images[config.Image]; !ok {
+		return fmt.Errorf("no such image: %s", config.Image)
+	}
+
+	for _, network := range append([]string{config.Network}, config.JoinNetworks...) {
+		if _, ok := r.networks[network]; network != "" && !ok {
+			return fmt.Errorf("network %s not found", network)
+		}
+	}
+
+	// Named volumes are created on demand, like with a real engine
+	for _, mount := range config.Mounts {
+		source, _, _ := strings.Cut(mount, ":")
+		if _, ok := r.volumes[source]; !ok && !strings.ContainsAny(source, "/\\") {
+			r.volumes[source] = &Volume{Name: source}
+		}
+	}
+
+	container := &Container{
+		Id:      r.newId(),
+		Name:    containerName,
+		Config:  *config,
+		Labels:  copyLabels(labels),
+		Running: true,
+		Started: true,
+	}
+
+	if config.Healthcheck != nil {
+		container.Health = r.getStartHealth(containerName)
+	}
+
+	r.containers[containerName] = container
+
+	return nil
+}
+
+func (r *Runtime) StartContainer(ctx context.Context, containerName string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	err := r.record(OpStartContainer, containerName)
+	if err != nil {
+		return err
+	}
+
+	container, ok := r.containers[containerName]
+	if !ok {
+		return fmt.Errorf("no such container: %s", containerName)
+	}
+
+	container.Running = true
+	container.Started = true
+	container.ExitCode = 0
+	if container.Config.Healthcheck != nil {
+		container.Health = r.getStartHealth(containerName)
+	}
+
+	return nil
+}
+
+func (r *Runtime) StopContainer(ctx context.Context, containerName string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	err := r.record(OpStopContainer, containerName)
+	if err != nil {
+		return err
+	}
+
+	container, ok := r.containers[containerName]
+	if !ok {
+		return fmt.Errorf("no such container: %s", containerName)
+	}
+
+	container.Running = false
+	container.ExitCode = 0
+
+	return nil
+}
+
+func (r *Runtime) RemoveContainer(ctx context.Context, logger logrus.FieldLogger, containerName string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	err := r.record(OpRemoveContainer, containerName)
+	if err != nil {
+		return err
+	}
+
+	delete(r.containers, containerName)
+
+	return nil
+}
+
+func (r *Runtime) GetContainerInfo(ctx context.Context, containerName string) (*docker.ContainerInfos, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	err := r.record(OpGetContainerInfo, containerName)
+	if err != nil {
+		return nil, err
+	}
+
+	container, ok := r.containers[containerName]
+	if !ok {
+		return nil, nil
+	}
+
+	return container.infos(), nil
+}
+
+func (r *Runtime) InspectContainer(ctx context.Context, containerName string) (*docker.ContainerState, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	container, ok := r.containers[containerName]
+	if !ok {
+		return nil, fmt.Errorf("could not inspect container %s: no such container", containerName)
+	}
+
+	state := &docker.ContainerState{
+		Running:     container.Running,
+		Status:      container.state(),
+		ExitCode:    container.ExitCode,
+		TTY:         container.Config.TTY,
+		Healthcheck: container.Config.Healthcheck != nil,
+	}
+
+	if container.Started {
+		state.Health = container.Health
+	}
+
+	return state, nil
+}
+
+func (r *Runtime) ContainerLogs(ctx context.Context, containerName string, options docker.LogsOptions, stdout, stderr io.Writer) error {
+	r.mu.Lock()
+	container, ok := r.containers[containerName]
+	var logs string
+	if ok {
+		logs = container.Logs
+	}
+	r.mu.Unlock()
+
+	if !ok {
+		return fmt.Errorf("could not inspect container %s: no such container", containerName)
+	}
+
+	_, err := io.WriteString(stdout, logs)
+	return err
+}
+
+func (r *Runtime) Exec(ctx context.Context, containerName string, options docker.ExecOptions) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	err := r.record(OpExec, containerName)
+	if err != nil {
+		return 0, err
+	}
+
+	container, ok := r.containers[containerName]
+	if !ok {
+		return 0, fmt.Errorf("no such container: %s", containerName)
+	}
+
+	if !container.Running {
+		return 0, fmt.Errorf("container %s is not running", containerName)
+	}
+
+	return 0, nil
+}
+
+func (r *Runtime) CreateNetwork(ctx context.Context, logger logrus.FieldLogger, name string, labels map[string]string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	err := r.record(OpCreateNetwork, name)
+	if err != nil {
+		return err
+	}
+
+	if _, ok := r.networks[name]; ok {
+		return fmt.Errorf("network with name %s already exists", name)
+	}
+
+	r.networks[name] = &Network{Id: r.newId(), Name: name, Labels: copyLabels(labels)}
+
+	return nil
+}
+
+func (r *Runtime) RemoveNetwork(ctx context.Context, logger logrus.FieldLogger, name string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	err := r.record(OpRemoveNetwork, name)
+	if err != nil {
+		return err
+	}
+
+	if _, ok := r.networks[name]; !ok {
+		return nil
+	}
+
+	for _, container := range r.containers {
+		for _, network := range append([]string{container.Config.Network}, container.Config.JoinNetworks...) {
+			if network == name {
+				return fmt.Errorf("error while removing network %s: network has active endpoints", name)
+			}
+		}
+	}
+
+	delete(r.networks, name)
+
+	return nil
+}
+
+func (r *Runtime) GetNetworkId(ctx context.Context, name string) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	network, ok := r.networks[name]
+	if !ok {
+		return "", nil
+	}
+
+	return network.Id, nil
+}
+
+func (r *Runtime) CreateVolume(ctx context.Context, logger logrus.FieldLogger, name string, labels map[string]string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	err := r.record(OpCreateVolume, name)
+	if err != nil {
+		return err
+	}
+
+	// Creating an existing volume is a no-op, like with a real engine
+	if _, ok := r.volumes[name]; !ok {
+		r.volumes[name] = &Volume{Name: name, Labels: copyLabels(labels)}
+	}
+
+	return nil
+}
+
+func (r *Runtime) RemoveVolume(ctx context.Context, logger logrus.FieldLogger, name string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	err := r.record(OpRemoveVolume, name)
+	if err != nil {
+		return err
+	}
+
+	if _, ok := r.volumes[name]; !ok {
+		return nil
+	}
+
+	for _, container := range r.containers {
+		for _, mount := range container.Config.Mounts {
+			if source, _, _ := strings.Cut(mount, ":"); source == name {
+				return fmt.Errorf("remove %s: volume is in use by %s", name, container.Name)
+			}
+		}
+	}
+
+	delete(r.volumes, name)
+
+	return nil
+}
+
+func (r *Runtime) VolumeExists(ctx context.Context, name string) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	_, ok := r.volumes[name]
+	return ok, nil
+}
+
+func (r *Runtime) ExportVolume(ctx context.Context, logger logrus.FieldLogger, name string, w io.Writer) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	err := r.record(OpExportVolume, name)
+	if err != nil {
+		return err
+	}
+
+	volume, ok := r.volumes[name]
+	if !ok {
+		return fmt.Errorf("no such volume: %s", name)
+	}
+
+	_, err = w.Write(volume.Data)
+	return err
+}
+
+func (r *Runtime) ImportVolume(ctx context.Context, logger logrus.FieldLogger, name string, rd io.Reader) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	err := r.record(OpImportVolume, name)
+	if err != nil {
+		return err
+	}
+
+	volume, ok := r.volumes[name]
+	if !ok {
+		return fmt.Errorf("no such volume: %s", name)
+	}
+
+	data, err := io.ReadAll(rd)
+	if err != nil {
+		return fmt.Errorf("could not read archive: %w", err)
+	}
+
+	volume.Data = data
+
+	return nil
+}
+
+func (r *Runtime) FindResources(ctx context.Context, filter docker.ResourceFilter) (*docker.LabeledResources, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	err := r.record(OpFindResources, filter.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	resources := &docker.LabeledResources{}
+
+	for _, name := range sortedKeys(r.containers) {
+		container := r.containers[name]
+		if !matches(filter, name, container.Labels) {
+			continue
+		}
+
+		resources.Containers = append(resources.Containers, docker.LabeledContainer{
+			Name:       name,
+			Root:       container.Labels[docker.LabelRoot],
+			Stack:      container.Labels[docker.LabelStack],
+			Service:    container.Labels[docker.LabelService],
+			ConfigHash: container.Labels[docker.LabelConfigHash],
+			Infos:      container.infos(),
+		})
+	}
+
+	for _, name := range sortedKeys(r.networks) {
+		network := r.networks[name]
+		if !matches(filter, name, network.Labels) {
+			continue
+		}
+
+		resources.Networks = append(resources.Networks, docker.LabeledNetwork{
+			Name:  name,
+			Root:  network.Labels[docker.LabelRoot],
+			Stack: network.Labels[docker.LabelStack],
+		})
+	}
+
+	for _, name := range sortedKeys(r.volumes) {
+		volume := r.volumes[name]
+		if !matches(filter, name, volume.Labels) {
+			continue
+		}
+
+		resources.Volumes = append(resources.Volumes, docker.LabeledVolume{
+			Name:       name,
+			Root:       volume.Labels[docker.LabelRoot],
+			Stack:      volume.Labels[docker.LabelStack],
+			Service:    volume.Labels[docker.LabelService],
+			Volume:     volume.Labels[docker.LabelVolume],
+			Persistent: atlasfile.VolumeLifecycle(volume.Labels[docker.LabelLifecycle]) == atlasfile.VolumeLifecyclePersistent,
+		})
+	}
+
+	return resources, nil
+}
+
+func (c *Container) state() string {
+	if c.Running {
+		return "running"
+	}
+	if !c.Started {
+		return "created"
+	}
+	return "exited"
+}
+
+func (c *Container) infos() *docker.ContainerInfos {
+	status := "Up"
+	if !c.Running {
+		status = fmt.Sprintf("Exited (%d)", c.ExitCode)
+	}
+
+	return &docker.ContainerInfos{
+		FetchedAt: time.Now().Format(time.RFC3339),
+		Id:        c.Id,
+		Name:      "/" + c.Name,
+		Status:    status,
+		State:     c.state(),
+	}
+}
+
+// matches returns true if a resource has all labels of filter and its name contains the name of filter
+func matches(filter docker.ResourceFilter, name string, labels map[string]string) bool {
+	if !strings.Contains(name, filter.Name) {
+		return false
+	}
+
+	for key, value := range filter.Labels {
+		if labels[key] != value {
+			return false
+		}
+	}
+
+	return true
+}
+
+func copyLabels(labels map[string]string) map[string]string {
+	copied := make(map[string]string, len(labels))
+	for key, value := range labels {
+		copied[key] = value
+	}
+	return copied
+}
+
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+var _ docker.Runtime = (*Runtime)(nil)
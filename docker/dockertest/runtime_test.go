@@ -0,0 +1,121 @@
+package dockertest
+
+import (
+	"context"
+	"errors"
+	"github.com/brunoscheufler/atlas/docker"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestRuntime(t *testing.T) {
+	ctx := context.Background()
+	logger := logrus.New()
+	r := NewRuntime()
+
+	labels := map[string]string{docker.LabelRoot: "/workspace", docker.LabelStack: "local"}
+
+	err := r.CreateNetwork(ctx, logger, "atlas-local-1", labels)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	config := &docker.ContainerConfig{Image: "postgres:15", Network: "atlas-local-1", Mounts: []string{"data:/data"}}
+
+	err = r.CreateContainer(ctx, logger, "atlas-local-db-1", config, labels)
+	assert.ErrorContains(t, err, "no such image")
+
+	err = r.PullImage(ctx, logger, "postgres:15")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = r.CreateContainer(ctx, logger, "atlas-local-db-1", config, labels)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, []string{"data"}, r.Volumes())
+
+	infos, err := r.GetContainerInfo(ctx, "atlas-local-db-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "running", infos.State)
+
+	err = r.StopContainer(ctx, "atlas-local-db-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	infos, err = r.GetContainerInfo(ctx, "atlas-local-db-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "exited", infos.State)
+
+	// Resources in use cannot be removed
+	err = r.RemoveNetwork(ctx, logger, "atlas-local-1")
+	assert.ErrorContains(t, err, "active endpoints")
+
+	err = r.RemoveVolume(ctx, logger, "data")
+	assert.ErrorContains(t, err, "in use")
+
+	resources, err := r.FindResources(ctx, docker.ResourceFilter{Labels: map[string]string{docker.LabelRoot: "/workspace"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resources.Containers) != 1 {
+		t.Fatalf("expected one container, got %d", len(resources.Containers))
+	}
+	assert.Equal(t, "atlas-local-db-1", resources.Containers[0].Name)
+	assert.Len(t, resources.Networks, 1)
+	assert.Empty(t, resources.Volumes)
+
+	resources, err = r.FindResources(ctx, docker.ResourceFilter{Labels: map[string]string{docker.LabelRoot: "/other"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.True(t, resources.IsEmpty())
+
+	err = r.RemoveContainer(ctx, logger, "atlas-local-db-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Nil(t, r.Container("atlas-local-db-1"))
+
+	err = r.RemoveNetwork(ctx, logger, "atlas-local-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Empty(t, r.Networks())
+}
+
+func TestRuntimeFailOn(t *testing.T) {
+	ctx := context.Background()
+	logger := logrus.New()
+	r := NewRuntime()
+
+	r.FailOn(OpCreateVolume, "atlas-local-api", errors.New("disk full"))
+
+	err := r.CreateVolume(ctx, logger, "atlas-local-db-data", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = r.CreateVolume(ctx, logger, "atlas-local-api-cache", nil)
+	assert.EqualError(t, err, "disk full")
+
+	r.Reset()
+
+	err = r.CreateVolume(ctx, logger, "atlas-local-api-cache", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, []string{
+		"CreateVolume atlas-local-db-data",
+		"CreateVolume atlas-local-api-cache",
+		"CreateVolume atlas-local-api-cache",
+	}, r.Calls())
+}
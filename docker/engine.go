@@ -0,0 +1,751 @@
+package docker
+
+import (
+	"context"
+	"fmt"
+	"github.com/brunoscheufler/atlas/atlasfile"
+	"github.com/brunoscheufler/atlas/exec"
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
+	volumetypes "github.com/docker/docker/api/types/volume"
+	"github.com/docker/docker/client"
+	"github.com/docker/docker/pkg/jsonmessage"
+	"github.com/docker/docker/pkg/stdcopy"
+	"github.com/moby/term"
+	"github.com/sirupsen/logrus"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// engineRuntime manages resources using the Docker Engine API. Podman serves a compatible API, so both runtimes
+// share this implementation and only differ in how the API is reached, where credentials are stored, and which
+// build options are supported.
+type engineRuntime struct {
+	name string
+
+	// host is the API endpoint, the Docker environment variables are used if it is empty
+	host string
+
+	// buildKit is true if the API builds with BuildKit, which supports secrets, SSH forwarding, and caches
+	buildKit bool
+
+	// authConfigPaths are searched in order for registry credentials
+	authConfigPaths []string
+
+	clientOnce sync.Once
+	client     *client.Client
+	clientErr  error
+}
+
+func newDockerRuntime() *engineRuntime {
+	dockerConfigPath, _ := getDockerConfigPath()
+
+	return &engineRuntime{
+		name:            RuntimeDocker,
+		buildKit:        true,
+		authConfigPaths: []string{dockerConfigPath},
+	}
+}
+
+// getClient returns the API client shared by all operations of the runtime, which negotiates the API version
+func (r *engineRuntime) getClient() (*client.Client, error) {
+	r.clientOnce.Do(func() {
+		opts := []client.Opt{client.FromEnv, client.WithAPIVersionNegotiation()}
+		if r.host != "" {
+			opts = append(opts, client.WithHost(r.host))
+		}
+
+		r.client, r.clientErr = client.NewClientWithOpts(opts...)
+	})
+
+	if r.clientErr != nil {
+		return nil, fmt.Errorf("could not create %s client: %w", r.name, r.clientErr)
+	}
+
+	return r.client, nil
+}
+
+func (r *engineRuntime) Name() string {
+	return r.name
+}
+
+func (r *engineRuntime) Ping(ctx context.Context) error {
+	cli, err := r.getClient()
+	if err != nil {
+		return err
+	}
+
+	_, err = cli.Ping(ctx)
+	if err != nil {
+		return fmt.Errorf("could not reach %s: %w", r.name, err)
+	}
+
+	return nil
+}
+
+func (r *engineRuntime) BuildImage(ctx context.Context, logger logrus.FieldLogger, options BuildOptions) error {
+	cli, err := r.getClient()
+	if err != nil {
+		return err
+	}
+
+	buildOptions, err := r.getImageBuildOptions(options)
+	if err != nil {
+		return fmt.Errorf("could not build image %s: %w", options.Image, err)
+	}
+
+	buildContext, dockerfile, err := getBuildContext(options.ContextDir, options.Dockerfile)
+	if err != nil {
+		return fmt.Errorf("could not build image %s: %w", options.Image, err)
+	}
+	defer buildContext.Close()
+
+	buildOptions.Dockerfile = dockerfile
+
+	// BuildKit requests credentials, secrets, and SSH agents through a session, the legacy builder gets all credentials
+	// up front
+	if buildOptions.Version == types.BuilderBuildKit {
+		sessionCtx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		sess, err := startBuildSession(sessionCtx, logger, cli, r.authConfigPaths, options)
+		if err != nil {
+			return fmt.Errorf("could not build image %s: %w", options.Image, err)
+		}
+
+		buildOptions.SessionID = sess.ID()
+	} else {
+		buildOptions.AuthConfigs, err = getBuildAuthConfigs(ctx, r.authConfigPaths)
+		if err != nil {
+			return fmt.Errorf("could not get credentials to build image %s: %w", options.Image, err)
+		}
+	}
+
+	response, err := cli.ImageBuild(ctx, buildContext, buildOptions)
+	if err != nil {
+		return fmt.Errorf("could not build image %s: %w", options.Image, err)
+	}
+	defer response.Body.Close()
+
+	// Build errors are reported in the progress stream
+	err = writeBuildOutput(response.Body, exec.NewPrefixWriter(os.Stdout, options.Name))
+	if err != nil {
+		return fmt.Errorf("could not build image %s: %w", options.Image, err)
+	}
+
+	return nil
+}
+
+// getImageBuildOptions returns the API options of a build. Docker builds with BuildKit if BuildKit options are set, so
+// they also work where the legacy builder is the default.
+func (r *engineRuntime) getImageBuildOptions(options BuildOptions) (types.ImageBuildOptions, error) {
+	buildOptions := types.ImageBuildOptions{
+		Tags:      []string{options.Image},
+		BuildArgs: make(map[string]*string, len(options.BuildArgs)),
+		Target:    options.Target,
+		Labels:    options.Labels,
+		Platform:  options.Platform,
+		Remove:    true,
+	}
+
+	for key, value := range options.BuildArgs {
+		value := value
+		buildOptions.BuildArgs[key] = &value
+	}
+
+	if !options.usesBuildKit() {
+		return buildOptions, nil
+	}
+
+	// Podman builds without BuildKit sessions and imports caches differently
+	if !r.buildKit && (len(options.Secrets) > 0 || len(options.SSH) > 0 || len(options.CacheFrom) > 0 || options.InlineCache) {
+		return types.ImageBuildOptions{}, fmt.Errorf("secrets, ssh, cache_from, and cache_to are not supported by %s", r.name)
+	}
+
+	if r.buildKit {
+		buildOptions.Version = types.BuilderBuildKit
+	}
+
+	buildOptions.CacheFrom = options.CacheFrom
+
+	if options.InlineCache {
+		inline := "1"
+		buildOptions.BuildArgs["BUILDKIT_INLINE_CACHE"] = &inline
+	}
+
+	return buildOptions, nil
+}
+
+func (r *engineRuntime) PullImage(ctx context.Context, logger logrus.FieldLogger, imageName string) error {
+	cli, err := r.getClient()
+	if err != nil {
+		return err
+	}
+
+	registryAuth, err := getRegistryAuth(ctx, r.authConfigPaths, imageName)
+	if err != nil {
+		return fmt.Errorf("could not get credentials to pull image %s: %w", imageName, err)
+	}
+
+	logger.WithField("image", imageName).Infoln("Pulling image")
+
+	reader, err := cli.ImagePull(ctx, imageName, types.ImagePullOptions{RegistryAuth: registryAuth})
+	if err != nil {
+		return fmt.Errorf("could not pull image %s: %w", imageName, err)
+	}
+	defer reader.Close()
+
+	// Pull errors are reported in the progress stream
+	err = jsonmessage.DisplayJSONMessagesStream(reader, io.Discard, 0, false, nil)
+	if err != nil {
+		return fmt.Errorf("could not pull image %s: %w", imageName, err)
+	}
+
+	return nil
+}
+
+func (r *engineRuntime) GetImageId(ctx context.Context, imageName string) (string, error) {
+	cli, err := r.getClient()
+	if err != nil {
+		return "", err
+	}
+
+	image, _, err := cli.ImageInspectWithRaw(ctx, imageName)
+	if err != nil {
+		if client.IsErrNotFound(err) {
+			return "", nil
+		}
+		return "", fmt.Errorf("could not inspect image %s: %w", imageName, err)
+	}
+
+	return image.ID, nil
+}
+
+func (r *engineRuntime) GetImageLabels(ctx context.Context, imageName string) (map[string]string, error) {
+	cli, err := r.getClient()
+	if err != nil {
+		return nil, err
+	}
+
+	image, _, err := cli.ImageInspectWithRaw(ctx, imageName)
+	if err != nil {
+		if client.IsErrNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("could not inspect image %s: %w", imageName, err)
+	}
+
+	labels := make(map[string]string)
+	if image.Config != nil {
+		for key, value := range image.Config.Labels {
+			labels[key] = value
+		}
+	}
+
+	return labels, nil
+}
+
+func (r *engineRuntime) CreateContainer(ctx context.Context, logger logrus.FieldLogger, containerName string, config *ContainerConfig, labels map[string]string) error {
+	cli, err := r.getClient()
+	if err != nil {
+		return err
+	}
+
+	containerConfig, hostConfig, networkingConfig, err := config.createOptions(labels)
+	if err != nil {
+		return fmt.Errorf("could not create container %s: %w", containerName, err)
+	}
+
+	logger.WithField("container", containerName).Debugln("Creating container")
+
+	_, err = cli.ContainerCreate(ctx, containerConfig, hostConfig, networkingConfig, nil, containerName)
+	if err != nil {
+		return fmt.Errorf("could not create container %s: %w", containerName, err)
+	}
+
+	for _, netName := range config.JoinNetworks {
+		err = cli.NetworkConnect(ctx, netName, containerName, nil)
+		if err != nil {
+			return r.removeFailedContainer(logger, containerName, fmt.Errorf("could not connect container %s to network %s: %w", containerName, netName, err))
+		}
+	}
+
+	err = cli.ContainerStart(ctx, containerName, types.ContainerStartOptions{})
+	if err != nil {
+		return r.removeFailedContainer(logger, containerName, fmt.Errorf("could not start container %s: %w", containerName, err))
+	}
+
+	return nil
+}
+
+// removeFailedContainer removes a container that was created but could not be set up, so that it is not left behind
+// untracked, and returns err
+func (r *engineRuntime) removeFailedContainer(logger logrus.FieldLogger, containerName string, err error) error {
+	// The context of the failed operation may be canceled already
+	removeErr := r.RemoveContainer(context.Background(), logger, containerName)
+	if removeErr != nil {
+		return fmt.Errorf("%w (could not remove container: %v)", err, removeErr)
+	}
+
+	return err
+}
+
+func (r *engineRuntime) StartContainer(ctx context.Context, containerName string) error {
+	cli, err := r.getClient()
+	if err != nil {
+		return err
+	}
+
+	err = cli.ContainerStart(ctx, containerName, types.ContainerStartOptions{})
+	if err != nil {
+		return fmt.Errorf("could not start container %s: %w", containerName, err)
+	}
+
+	return nil
+}
+
+func (r *engineRuntime) StopContainer(ctx context.Context, containerName string) error {
+	cli, err := r.getClient()
+	if err != nil {
+		return err
+	}
+
+	err = cli.ContainerStop(ctx, containerName, nil)
+	if err != nil {
+		return fmt.Errorf("could not stop container %s: %w", containerName, err)
+	}
+
+	return nil
+}
+
+func (r *engineRuntime) RemoveContainer(ctx context.Context, logger logrus.FieldLogger, containerName string) error {
+	cli, err := r.getClient()
+	if err != nil {
+		return err
+	}
+
+	logger.WithField("container", containerName).Debugln("Deleting container")
+
+	err = cli.ContainerStop(ctx, containerName, nil)
+	if err != nil {
+		if client.IsErrNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("could not stop container %s: %w", containerName, err)
+	}
+
+	err = cli.ContainerRemove(ctx, containerName, types.ContainerRemoveOptions{RemoveVolumes: true, Force: true})
+	if err != nil && !client.IsErrNotFound(err) {
+		return fmt.Errorf("could not delete container %s: %w", containerName, err)
+	}
+
+	return nil
+}
+
+func (r *engineRuntime) GetContainerInfo(ctx context.Context, containerName string) (*ContainerInfos, error) {
+	cli, err := r.getClient()
+	if err != nil {
+		return nil, err
+	}
+
+	containers, err := cli.ContainerList(ctx, types.ContainerListOptions{
+		All: true,
+		Filters: filters.NewArgs(
+			filters.Arg("name", containerName),
+		),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not list containers: %w", err)
+	}
+
+	if len(containers) == 0 {
+		return nil, nil
+	}
+
+	return &ContainerInfos{
+		FetchedAt: time.Now().Format(time.RFC3339),
+		Id:        containers[0].ID,
+		Name:      containers[0].Names[0],
+		Status:    containers[0].Status,
+		State:     containers[0].State,
+	}, nil
+}
+
+func (r *engineRuntime) InspectContainer(ctx context.Context, containerName string) (*ContainerState, error) {
+	cli, err := r.getClient()
+	if err != nil {
+		return nil, err
+	}
+
+	inspected, err := cli.ContainerInspect(ctx, containerName)
+	if err != nil {
+		return nil, fmt.Errorf("could not inspect container %s: %w", containerName, err)
+	}
+
+	state := &ContainerState{
+		Running:  inspected.State.Running,
+		Status:   inspected.State.Status,
+		ExitCode: inspected.State.ExitCode,
+		TTY:      inspected.Config.Tty,
+	}
+
+	healthcheck := inspected.Config.Healthcheck
+	state.Healthcheck = healthcheck != nil && len(healthcheck.Test) > 0 && healthcheck.Test[0] != "NONE"
+
+	if inspected.State.Health != nil {
+		state.Health = inspected.State.Health.Status
+	}
+
+	return state, nil
+}
+
+func (r *engineRuntime) ContainerLogs(ctx context.Context, containerName string, options LogsOptions, stdout, stderr io.Writer) error {
+	cli, err := r.getClient()
+	if err != nil {
+		return err
+	}
+
+	inspected, err := cli.ContainerInspect(ctx, containerName)
+	if err != nil {
+		return fmt.Errorf("could not inspect container %s: %w", containerName, err)
+	}
+
+	reader, err := cli.ContainerLogs(ctx, containerName, types.ContainerLogsOptions{
+		ShowStdout: true,
+		ShowStderr: true,
+		Follow:     options.Follow,
+		Since:      options.Since,
+		Tail:       options.Tail,
+	})
+	if err != nil {
+		return fmt.Errorf("could not get logs of container %s: %w", containerName, err)
+	}
+	defer reader.Close()
+
+	// Logs of containers with a TTY are not multiplexed
+	if inspected.Config.Tty {
+		_, err = io.Copy(stdout, reader)
+	} else {
+		_, err = stdcopy.StdCopy(stdout, stderr, reader)
+	}
+	if err != nil && ctx.Err() == nil {
+		return fmt.Errorf("could not read logs of container %s: %w", containerName, err)
+	}
+
+	return nil
+}
+
+func (r *engineRuntime) Exec(ctx context.Context, containerName string, options ExecOptions) (int, error) {
+	cli, err := r.getClient()
+	if err != nil {
+		return 0, err
+	}
+
+	created, err := cli.ContainerExecCreate(ctx, containerName, types.ExecConfig{
+		Cmd:          options.Command,
+		AttachStdin:  options.Stdin != nil,
+		AttachStdout: true,
+		AttachStderr: true,
+		Tty:          options.TTY,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("could not create exec in container %s: %w", containerName, err)
+	}
+
+	attached, err := cli.ContainerExecAttach(ctx, created.ID, types.ExecStartCheck{Tty: options.TTY})
+	if err != nil {
+		return 0, fmt.Errorf("could not attach to exec in container %s: %w", containerName, err)
+	}
+	defer attached.Close()
+
+	if options.TTY {
+		if options.Stdin != nil {
+			if fd, isTerminal := term.GetFdInfo(options.Stdin); isTerminal {
+				state, err := term.SetRawTerminal(fd)
+				if err != nil {
+					return 0, fmt.Errorf("could not set terminal to raw mode: %w", err)
+				}
+				defer func() {
+					_ = term.RestoreTerminal(fd, state)
+				}()
+			}
+		}
+
+		if size, err := term.GetWinsize(os.Stdout.Fd()); err == nil {
+			_ = cli.ContainerExecResize(ctx, created.ID, types.ResizeOptions{Height: uint(size.Height), Width: uint(size.Width)})
+		}
+	}
+
+	if options.Stdin != nil {
+		go func() {
+			_, _ = io.Copy(attached.Conn, options.Stdin)
+			_ = attached.CloseWrite()
+		}()
+	}
+
+	// Output of commands with a TTY is not multiplexed
+	if options.TTY {
+		_, err = io.Copy(options.Stdout, attached.Reader)
+	} else {
+		_, err = stdcopy.StdCopy(options.Stdout, options.Stderr, attached.Reader)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("could not read exec output: %w", err)
+	}
+
+	inspected, err := cli.ContainerExecInspect(ctx, created.ID)
+	if err != nil {
+		return 0, fmt.Errorf("could not inspect exec in container %s: %w", containerName, err)
+	}
+
+	return inspected.ExitCode, nil
+}
+
+func (r *engineRuntime) CreateNetwork(ctx context.Context, logger logrus.FieldLogger, name string, labels map[string]string) error {
+	cli, err := r.getClient()
+	if err != nil {
+		return err
+	}
+
+	logger.WithField("network", name).Debugln("Creating network")
+
+	_, err = cli.NetworkCreate(ctx, name, types.NetworkCreate{CheckDuplicate: true, Labels: labels})
+	if err != nil {
+		return fmt.Errorf("could not create network %s: %w", name, err)
+	}
+
+	return nil
+}
+
+func (r *engineRuntime) RemoveNetwork(ctx context.Context, logger logrus.FieldLogger, name string) error {
+	cli, err := r.getClient()
+	if err != nil {
+		return err
+	}
+
+	logger.WithField("network", name).Debugln("Deleting network")
+
+	err = cli.NetworkRemove(ctx, name)
+	if err != nil && !client.IsErrNotFound(err) {
+		return fmt.Errorf("could not delete network %s: %w", name, err)
+	}
+
+	return nil
+}
+
+func (r *engineRuntime) GetNetworkId(ctx context.Context, name string) (string, error) {
+	cli, err := r.getClient()
+	if err != nil {
+		return "", err
+	}
+
+	networks, err := cli.NetworkList(ctx, types.NetworkListOptions{
+		Filters: filters.NewArgs(
+			filters.Arg("name", name),
+		),
+	})
+	if err != nil {
+		return "", fmt.Errorf("could not list networks: %w", err)
+	}
+
+	if len(networks) == 0 {
+		return "", nil
+	}
+
+	return networks[0].ID, nil
+}
+
+func (r *engineRuntime) CreateVolume(ctx context.Context, logger logrus.FieldLogger, name string, labels map[string]string) error {
+	cli, err := r.getClient()
+	if err != nil {
+		return err
+	}
+
+	logger.WithField("volume", name).Debugln("Creating volume")
+
+	_, err = cli.VolumeCreate(ctx, volumetypes.VolumeCreateBody{Name: name, Labels: labels})
+	if err != nil {
+		return fmt.Errorf("could not create volume %s: %w", name, err)
+	}
+
+	return nil
+}
+
+func (r *engineRuntime) RemoveVolume(ctx context.Context, logger logrus.FieldLogger, name string) error {
+	cli, err := r.getClient()
+	if err != nil {
+		return err
+	}
+
+	logger.WithField("volume", name).Debugln("Deleting volume")
+
+	err = cli.VolumeRemove(ctx, name, true)
+	if err != nil && !client.IsErrNotFound(err) {
+		return fmt.Errorf("could not delete volume %s: %w", name, err)
+	}
+
+	return nil
+}
+
+func (r *engineRuntime) VolumeExists(ctx context.Context, name string) (bool, error) {
+	cli, err := r.getClient()
+	if err != nil {
+		return false, err
+	}
+
+	_, err = cli.VolumeInspect(ctx, name)
+	if err != nil {
+		if client.IsErrNotFound(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("could not inspect volume %s: %w", name, err)
+	}
+
+	return true, nil
+}
+
+// withHelperContainer creates a throwaway container with the volume mounted at /volume, runs fn, and removes the container
+func (r *engineRuntime) withHelperContainer(ctx context.Context, logger logrus.FieldLogger, volumeName string, readOnly bool, cmd []string, fn func(cli *client.Client, containerId string) error) error {
+	cli, err := r.getClient()
+	if err != nil {
+		return err
+	}
+
+	err = PullImage(ctx, logger, volumeHelperImage)
+	if err != nil {
+		return err
+	}
+
+	bind := fmt.Sprintf("%s:/volume", volumeName)
+	if readOnly {
+		bind += ":ro"
+	}
+
+	created, err := cli.ContainerCreate(ctx, &container.Config{Image: volumeHelperImage, Cmd: cmd}, &container.HostConfig{Binds: []string{bind}}, nil, nil, "")
+	if err != nil {
+		return fmt.Errorf("could not create helper container: %w", err)
+	}
+
+	defer func() {
+		_ = cli.ContainerRemove(context.Background(), created.ID, types.ContainerRemoveOptions{Force: true})
+	}()
+
+	return fn(cli, created.ID)
+}
+
+func (r *engineRuntime) ExportVolume(ctx context.Context, logger logrus.FieldLogger, name string, w io.Writer) error {
+	return r.withHelperContainer(ctx, logger, name, true, nil, func(cli *client.Client, containerId string) error {
+		// Copying /volume/. archives the contents of the volume without the directory itself
+		reader, _, err := cli.CopyFromContainer(ctx, containerId, "/volume/.")
+		if err != nil {
+			return err
+		}
+		defer reader.Close()
+
+		_, err = io.Copy(w, reader)
+		return err
+	})
+}
+
+func (r *engineRuntime) ImportVolume(ctx context.Context, logger logrus.FieldLogger, name string, reader io.Reader) error {
+	clear := []string{"sh", "-c", "rm -rf /volume/* /volume/.[!.]* /volume/..?*"}
+
+	return r.withHelperContainer(ctx, logger, name, false, clear, func(cli *client.Client, containerId string) error {
+		waitCh, errCh := cli.ContainerWait(ctx, containerId, container.WaitConditionNextExit)
+
+		err := cli.ContainerStart(ctx, containerId, types.ContainerStartOptions{})
+		if err != nil {
+			return fmt.Errorf("could not clear volume: %w", err)
+		}
+
+		select {
+		case result := <-waitCh:
+			if result.StatusCode != 0 {
+				return fmt.Errorf("could not clear volume: exited with code %d", result.StatusCode)
+			}
+		case err := <-errCh:
+			return fmt.Errorf("could not clear volume: %w", err)
+		}
+
+		// Keep the ownership of files in the archive, services often run as non-root users
+		return cli.CopyToContainer(ctx, containerId, "/volume", reader, types.CopyToContainerOptions{CopyUIDGID: true})
+	})
+}
+
+func (r *engineRuntime) FindResources(ctx context.Context, filter ResourceFilter) (*LabeledResources, error) {
+	cli, err := r.getClient()
+	if err != nil {
+		return nil, err
+	}
+
+	args := filters.NewArgs()
+	for key, value := range filter.Labels {
+		args.Add("label", fmt.Sprintf("%s=%s", key, value))
+	}
+	if filter.Name != "" {
+		args.Add("name", filter.Name)
+	}
+
+	resources := &LabeledResources{}
+
+	containers, err := cli.ContainerList(ctx, types.ContainerListOptions{All: true, Filters: args})
+	if err != nil {
+		return nil, fmt.Errorf("could not list containers: %w", err)
+	}
+
+	for _, container := range containers {
+		name := strings.TrimPrefix(container.Names[0], "/")
+
+		resources.Containers = append(resources.Containers, LabeledContainer{
+			Name:       name,
+			Root:       container.Labels[LabelRoot],
+			Stack:      container.Labels[LabelStack],
+			Service:    container.Labels[LabelService],
+			ConfigHash: container.Labels[LabelConfigHash],
+			Infos: &ContainerInfos{
+				FetchedAt: time.Now().Format(time.RFC3339),
+				Id:        container.ID,
+				Name:      container.Names[0],
+				Status:    container.Status,
+				State:     container.State,
+			},
+		})
+	}
+
+	networks, err := cli.NetworkList(ctx, types.NetworkListOptions{Filters: args})
+	if err != nil {
+		return nil, fmt.Errorf("could not list networks: %w", err)
+	}
+
+	for _, network := range networks {
+		resources.Networks = append(resources.Networks, LabeledNetwork{
+			Name:  network.Name,
+			Root:  network.Labels[LabelRoot],
+			Stack: network.Labels[LabelStack],
+		})
+	}
+
+	volumes, err := cli.VolumeList(ctx, args)
+	if err != nil {
+		return nil, fmt.Errorf("could not list volumes: %w", err)
+	}
+
+	for _, volume := range volumes.Volumes {
+		resources.Volumes = append(resources.Volumes, LabeledVolume{
+			Name:       volume.Name,
+			Root:       volume.Labels[LabelRoot],
+			Stack:      volume.Labels[LabelStack],
+			Service:    volume.Labels[LabelService],
+			Volume:     volume.Labels[LabelVolume],
+			Persistent: atlasfile.VolumeLifecycle(volume.Labels[LabelLifecycle]) == atlasfile.VolumeLifecyclePersistent,
+		})
+	}
+
+	return resources, nil
+}
+
+var _ Runtime = (*engineRuntime)(nil)
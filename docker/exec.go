@@ -0,0 +1,23 @@
+package docker
+
+import (
+	"context"
+	"io"
+)
+
+type ExecOptions struct {
+	Command []string
+
+	// Stdin is attached if set
+	Stdin  io.Reader
+	Stdout io.Writer
+	Stderr io.Writer
+
+	// TTY allocates a pseudo-terminal, sized to the terminal connected to os.Stdout and put into raw mode if Stdin is set
+	TTY bool
+}
+
+// ExecInContainer runs a command in a running container and returns its exit code
+func ExecInContainer(ctx context.Context, containerName string, options ExecOptions) (int, error) {
+	return getRuntime().Exec(ctx, containerName, options)
+}
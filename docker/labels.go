@@ -0,0 +1,116 @@
+package docker
+
+import (
+	"context"
+	"github.com/brunoscheufler/atlas/atlasfile"
+)
+
+const (
+	// LabelRoot is the root directory of the workspace a resource belongs to
+	LabelRoot = "atlas.root"
+
+	LabelStack   = "atlas.stack"
+	LabelService = "atlas.service"
+	LabelVolume  = "atlas.volume"
+
+	// LabelVersion is the Atlas version that created a resource
+	LabelVersion = "atlas.version"
+
+	// LabelConfigHash is the hash of the container config a service container was created from
+	LabelConfigHash = "atlas.config-hash"
+
+	// LabelBuildHash is the hash of the inputs an artifact image was built from
+	LabelBuildHash = "atlas.build-hash"
+
+	// LabelLifecycle is the lifecycle of a volume, persistent volumes are kept when cleaning up all resources
+	LabelLifecycle = "atlas.lifecycle"
+)
+
+// Labels identify resources Atlas creates in a workspace, so they can be found again without the state file
+type Labels struct {
+	Root    string
+	Version string
+}
+
+func (l Labels) Stack(stackName string) map[string]string {
+	return map[string]string{
+		LabelRoot:    l.Root,
+		LabelVersion: l.Version,
+		LabelStack:   stackName,
+	}
+}
+
+func (l Labels) Service(stackName, serviceName, configHash string) map[string]string {
+	labels := l.Stack(stackName)
+	labels[LabelService] = serviceName
+	labels[LabelConfigHash] = configHash
+	return labels
+}
+
+func (l Labels) Volume(stackName, serviceName, volumeName string, persistent bool) map[string]string {
+	labels := l.Stack(stackName)
+	labels[LabelService] = serviceName
+	labels[LabelVolume] = volumeName
+	labels[LabelLifecycle] = string(atlasfile.VolumeLifecycleEphemeral)
+	if persistent {
+		labels[LabelLifecycle] = string(atlasfile.VolumeLifecyclePersistent)
+	}
+	return labels
+}
+
+type LabeledContainer struct {
+	Root       string
+	Name       string
+	Stack      string
+	Service    string
+	ConfigHash string
+	Infos      *ContainerInfos
+}
+
+type LabeledNetwork struct {
+	Root  string
+	Name  string
+	Stack string
+}
+
+type LabeledVolume struct {
+	Root       string
+	Name       string
+	Stack      string
+	Service    string
+	Volume     string
+	Persistent bool
+}
+
+type LabeledResources struct {
+	Containers []LabeledContainer
+	Networks   []LabeledNetwork
+	Volumes    []LabeledVolume
+}
+
+// IsEmpty returns true if there are no resources
+func (r *LabeledResources) IsEmpty() bool {
+	return len(r.Containers) == 0 && len(r.Networks) == 0 && len(r.Volumes) == 0
+}
+
+// WithoutPersistentVolumes returns the resources excluding persistent volumes
+func (r *LabeledResources) WithoutPersistentVolumes() *LabeledResources {
+	filtered := &LabeledResources{Containers: r.Containers, Networks: r.Networks}
+	for _, volume := range r.Volumes {
+		if !volume.Persistent {
+			filtered.Volumes = append(filtered.Volumes, volume)
+		}
+	}
+	return filtered
+}
+
+// FindLabeledResources returns all containers, networks, and volumes labeled with the workspace root directory
+func FindLabeledResources(ctx context.Context, rootDir string) (*LabeledResources, error) {
+	return getRuntime().FindResources(ctx, ResourceFilter{Labels: map[string]string{LabelRoot: rootDir}})
+}
+
+// FindAllResources returns all containers, networks, and volumes named like Atlas resources, across all workspaces
+// and including resources created by versions without labels
+func FindAllResources(ctx context.Context) (*LabeledResources, error) {
+	return getRuntime().FindResources(ctx, ResourceFilter{Name: "atlas-"})
+}
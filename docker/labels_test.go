@@ -0,0 +1,27 @@
+package docker
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestLabels(t *testing.T) {
+	labels := Labels{Root: "/home/atlas/my project", Version: "1.0.0"}
+
+	assert.Equal(t, map[string]string{
+		"atlas.config-hash": "abc",
+		"atlas.root":        "/home/atlas/my project",
+		"atlas.service":     "db",
+		"atlas.stack":       "local",
+		"atlas.version":     "1.0.0",
+	}, labels.Service("local", "db", "abc"))
+
+	assert.Equal(t, map[string]string{
+		"atlas.lifecycle": "persistent",
+		"atlas.root":      "/home/atlas/my project",
+		"atlas.service":   "db",
+		"atlas.stack":     "local",
+		"atlas.version":   "1.0.0",
+		"atlas.volume":    "data",
+	}, labels.Volume("local", "db", "data", true))
+}
@@ -0,0 +1,22 @@
+package docker
+
+import (
+	"context"
+	"io"
+)
+
+type LogsOptions struct {
+	Follow bool
+
+	// Since is a timestamp or duration relative to now (e.g. 10m)
+	Since string
+
+	// Tail is the number of lines to show from the end of the logs, or "all"
+	Tail string
+}
+
+// StreamContainerLogs copies container logs to stdout and stderr until all logs were read or,
+// when following, until ctx is canceled or the container stops
+func StreamContainerLogs(ctx context.Context, containerName string, options LogsOptions, stdout, stderr io.Writer) error {
+	return getRuntime().ContainerLogs(ctx, containerName, options, stdout, stderr)
+}
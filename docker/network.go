@@ -4,43 +4,17 @@ import (
 	"context"
 	"fmt"
 	"github.com/brunoscheufler/atlas/atlasfile"
-	"github.com/brunoscheufler/atlas/exec"
 	"github.com/brunoscheufler/atlas/helper"
-	"github.com/docker/docker/api/types"
-	"github.com/docker/docker/api/types/filters"
-	"github.com/docker/docker/client"
 	"github.com/sirupsen/logrus"
 )
 
-func CreateNetwork(ctx context.Context, logger logrus.FieldLogger, name string) error {
-	err := exec.RunCommand(ctx, logger, fmt.Sprintf("docker network create %s", name), exec.RunCommandOptions{})
-	if err != nil {
-		return fmt.Errorf("could not create network %s: %w", name, err)
-	}
-
-	return nil
+func CreateNetwork(ctx context.Context, logger logrus.FieldLogger, name string, labels map[string]string) error {
+	return getRuntime().CreateNetwork(ctx, logger, name, labels)
 }
 
+// GetNetworkId returns the ID of a network or an empty string if it does not exist
 func GetNetworkId(ctx context.Context, networkName string) (string, error) {
-	cli, err := client.NewClientWithOpts(client.FromEnv)
-	if err != nil {
-		return "", fmt.Errorf("could not create docker client: %w", err)
-	}
-
-	networks, err := cli.NetworkList(ctx, types.NetworkListOptions{
-		Filters: filters.NewArgs(
-			filters.Arg("name", networkName),
-		),
-	})
-	if err != nil {
-		return "", fmt.Errorf("could not list networks: %w", err)
-	}
-
-	if len(networks) == 0 {
-		return "", nil
-	}
-
-	return networks[0].ID, nil
+	return getRuntime().GetNetworkId(ctx, networkName)
 }
 
 type EnsuredNetwork struct {
@@ -59,16 +33,31 @@ func (e *EnsuredNetworks) Get(stackName string) string {
 	return ""
 }
 
-// EnsureNetworks creates networks where needed and returns a list of networks that were created.
-func EnsureNetworks(ctx context.Context, logger logrus.FieldLogger, stacks []atlasfile.StackConfig, a *atlasfile.Atlasfile) (EnsuredNetworks, error) {
+// EnsureNetworks returns the networks of stacks, reusing existing networks that still exist and creating missing ones.
+// On error, the networks ensured before the error are returned with it.
+func EnsureNetworks(ctx context.Context, logger logrus.FieldLogger, labels Labels, stacks []atlasfile.StackConfig, a *atlasfile.Atlasfile, existing EnsuredNetworks) (EnsuredNetworks, error) {
 	ensuredNetworks := make([]EnsuredNetwork, 0)
 
 	for _, stack := range stacks {
-		netName := helper.RandomizedName(fmt.Sprintf("atlas-%s", stack.Name))
+		netName := existing.Get(stack.Name)
+		if netName != "" {
+			networkId, err := GetNetworkId(ctx, netName)
+			if err != nil {
+				return ensuredNetworks, fmt.Errorf("could not get network id: %w", err)
+			}
+
+			if networkId == "" {
+				netName = ""
+			}
+		}
+
+		if netName == "" {
+			netName = helper.RandomizedName(fmt.Sprintf("atlas-%s", stack.Name))
 
-		err := CreateNetwork(ctx, logger, netName)
-		if err != nil {
-			return nil, fmt.Errorf("could not create network: %w", err)
+			err := CreateNetwork(ctx, logger, netName, labels.Stack(stack.Name))
+			if err != nil {
+				return ensuredNetworks, fmt.Errorf("could not create network: %w", err)
+			}
 		}
 
 		ensuredNetworks = append(ensuredNetworks, EnsuredNetwork{
@@ -0,0 +1,85 @@
+package docker
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// newPodmanRuntime returns a runtime using the Docker-compatible API of Podman. Podman does not run a daemon by
+// default, the API socket must be enabled (e.g. systemctl --user enable --now podman.socket) or set in CONTAINER_HOST.
+func newPodmanRuntime() *engineRuntime {
+	return &engineRuntime{
+		name:            RuntimePodman,
+		host:            getPodmanHost(),
+		authConfigPaths: getPodmanAuthConfigPaths(),
+	}
+}
+
+// getPodmanSocketPaths returns the locations of the Podman API socket, rootless first
+func getPodmanSocketPaths() []string {
+	paths := make([]string, 0)
+
+	runtimeDir := os.Getenv("XDG_RUNTIME_DIR")
+	if runtimeDir == "" {
+		runtimeDir = fmt.Sprintf("/run/user/%d", os.Getuid())
+	}
+	paths = append(paths, filepath.Join(runtimeDir, "podman", "podman.sock"))
+
+	// Podman machines on macOS forward the socket of the virtual machine
+	if home, err := os.UserHomeDir(); err == nil {
+		paths = append(paths, filepath.Join(home, ".local", "share", "containers", "podman", "machine", "podman.sock"))
+		paths = append(paths, filepath.Join(home, ".local", "share", "containers", "podman", "machine", "qemu", "podman.sock"))
+	}
+
+	paths = append(paths, "/run/podman/podman.sock")
+
+	return paths
+}
+
+// getPodmanHost returns the API endpoint of Podman, configured in CONTAINER_HOST or found at a default location
+func getPodmanHost() string {
+	if host := os.Getenv("CONTAINER_HOST"); host != "" {
+		// Podman uses unix:// for local sockets and ssh:// for remote connections, only the former is supported
+		if strings.HasPrefix(host, "unix://") {
+			return host
+		}
+	}
+
+	paths := getPodmanSocketPaths()
+	for _, path := range paths {
+		if _, err := os.Stat(path); err == nil {
+			return "unix://" + path
+		}
+	}
+
+	// Fail to connect to the default rootless socket
+	return "unix://" + paths[0]
+}
+
+// getPodmanAuthConfigPaths returns the files Podman reads registry credentials from, in order
+func getPodmanAuthConfigPaths() []string {
+	paths := make([]string, 0)
+
+	if authFile := os.Getenv("REGISTRY_AUTH_FILE"); authFile != "" {
+		paths = append(paths, authFile)
+	}
+
+	runtimeDir := os.Getenv("XDG_RUNTIME_DIR")
+	if runtimeDir == "" {
+		runtimeDir = fmt.Sprintf("/run/user/%d", os.Getuid())
+	}
+	paths = append(paths, filepath.Join(runtimeDir, "containers", "auth.json"))
+
+	if configDir, err := os.UserConfigDir(); err == nil {
+		paths = append(paths, filepath.Join(configDir, "containers", "auth.json"))
+	}
+
+	// Podman falls back to Docker credentials
+	if dockerConfigPath, err := getDockerConfigPath(); err == nil {
+		paths = append(paths, dockerConfigPath)
+	}
+
+	return paths
+}
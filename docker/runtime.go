@@ -0,0 +1,196 @@
+package docker
+
+import (
+	"context"
+	"fmt"
+	"github.com/sirupsen/logrus"
+	"io"
+	"sync"
+)
+
+const (
+	RuntimeDocker = "docker"
+	RuntimePodman = "podman"
+)
+
+// Runtime is a container engine that Atlas creates and manages resources with. Functions of this package use
+// the runtime selected with SelectRuntime, or detected by IsRunning.
+type Runtime interface {
+	// Name identifies the runtime, e.g. docker or podman
+	Name() string
+
+	// Ping returns an error if the runtime cannot be reached
+	Ping(ctx context.Context) error
+
+	BuildImage(ctx context.Context, logger logrus.FieldLogger, options BuildOptions) error
+	PullImage(ctx context.Context, logger logrus.FieldLogger, imageName string) error
+
+	// GetImageId returns the ID of a local image or an empty string if the image does not exist locally
+	GetImageId(ctx context.Context, imageName string) (string, error)
+
+	// GetImageLabels returns the labels of a local image or nil if the image does not exist locally
+	GetImageLabels(ctx context.Context, imageName string) (map[string]string, error)
+
+	// CreateContainer creates a labeled container from config, connects it to its networks, and starts it. If any
+	// step fails, no container is left behind.
+	CreateContainer(ctx context.Context, logger logrus.FieldLogger, containerName string, config *ContainerConfig, labels map[string]string) error
+	StartContainer(ctx context.Context, containerName string) error
+	StopContainer(ctx context.Context, containerName string) error
+
+	// RemoveContainer stops and removes a container and its anonymous volumes, if it exists
+	RemoveContainer(ctx context.Context, logger logrus.FieldLogger, containerName string) error
+
+	// GetContainerInfo returns nil if the container does not exist
+	GetContainerInfo(ctx context.Context, containerName string) (*ContainerInfos, error)
+	InspectContainer(ctx context.Context, containerName string) (*ContainerState, error)
+
+	ContainerLogs(ctx context.Context, containerName string, options LogsOptions, stdout, stderr io.Writer) error
+
+	// Exec runs a command in a running container and returns its exit code
+	Exec(ctx context.Context, containerName string, options ExecOptions) (int, error)
+
+	CreateNetwork(ctx context.Context, logger logrus.FieldLogger, name string, labels map[string]string) error
+
+	// RemoveNetwork removes a network, if it exists
+	RemoveNetwork(ctx context.Context, logger logrus.FieldLogger, name string) error
+
+	// GetNetworkId returns an empty string if the network does not exist
+	GetNetworkId(ctx context.Context, name string) (string, error)
+
+	CreateVolume(ctx context.Context, logger logrus.FieldLogger, name string, labels map[string]string) error
+
+	// RemoveVolume removes a volume, if it exists
+	RemoveVolume(ctx context.Context, logger logrus.FieldLogger, name string) error
+	VolumeExists(ctx context.Context, name string) (bool, error)
+
+	// ExportVolume writes the contents of a volume as tar archive to w
+	ExportVolume(ctx context.Context, logger logrus.FieldLogger, name string, w io.Writer) error
+
+	// ImportVolume replaces the contents of a volume with the tar archive read from r
+	ImportVolume(ctx context.Context, logger logrus.FieldLogger, name string, r io.Reader) error
+
+	// FindResources returns all containers, networks, and volumes matching filter
+	FindResources(ctx context.Context, filter ResourceFilter) (*LabeledResources, error)
+}
+
+// ResourceFilter selects resources by labels and name. Empty fields match all resources.
+type ResourceFilter struct {
+	// Labels must all be set to the given values
+	Labels map[string]string
+
+	// Name must be contained in the resource name
+	Name string
+}
+
+type ContainerState struct {
+	Running  bool
+	Status   string
+	ExitCode int
+	TTY      bool
+
+	// Healthcheck is true if the container was created with a healthcheck, either configured by Atlas or the image
+	Healthcheck bool
+
+	// Health is the health status, empty if the container has no healthcheck or was never started
+	Health string
+}
+
+type BuildOptions struct {
+	// Name is shown as prefix of build output
+	Name string
+
+	Image      string
+	ContextDir string
+	Dockerfile string
+	BuildArgs  map[string]string
+	Target     string
+
+	// Labels are set on the built image
+	Labels map[string]string
+
+	// The remaining options require BuildKit. Paths are absolute, CacheFrom lists images to import the build cache
+	// from, and InlineCache embeds the build cache in the built image.
+	Secrets     []BuildSecret
+	SSH         []string
+	CacheFrom   []string
+	InlineCache bool
+	Platform    string
+}
+
+// BuildSecret is read from the file at Src or the environment variable Env of Atlas
+type BuildSecret struct {
+	Id  string
+	Src string
+	Env string
+}
+
+// usesBuildKit returns true if any option is set that the legacy builder does not support
+func (o BuildOptions) usesBuildKit() bool {
+	return len(o.Secrets) > 0 || len(o.SSH) > 0 || len(o.CacheFrom) > 0 || o.InlineCache || o.Platform != ""
+}
+
+var (
+	runtimeMu      sync.Mutex
+	currentRuntime Runtime
+)
+
+// SetRuntime replaces the runtime used by this package, e.g. with a fake runtime in tests
+func SetRuntime(runtime Runtime) {
+	runtimeMu.Lock()
+	defer runtimeMu.Unlock()
+
+	currentRuntime = runtime
+}
+
+// SelectRuntime selects the runtime by name. If name is empty, the runtime is detected when IsRunning is called.
+func SelectRuntime(name string) error {
+	switch name {
+	case "":
+		SetRuntime(nil)
+	case RuntimeDocker:
+		SetRuntime(newDockerRuntime())
+	case RuntimePodman:
+		SetRuntime(newPodmanRuntime())
+	default:
+		return fmt.Errorf("unknown runtime %q, expected %s or %s", name, RuntimeDocker, RuntimePodman)
+	}
+
+	return nil
+}
+
+// getRuntime returns the selected runtime, falling back to Docker if no runtime was selected or detected
+func getRuntime() Runtime {
+	runtimeMu.Lock()
+	defer runtimeMu.Unlock()
+
+	if currentRuntime == nil {
+		currentRuntime = newDockerRuntime()
+	}
+
+	return currentRuntime
+}
+
+// RuntimeName returns the name of the selected runtime
+func RuntimeName() string {
+	return getRuntime().Name()
+}
+
+// IsRunning returns true if the selected runtime can be reached. If no runtime was selected, Docker is preferred
+// and Podman is used if only Podman is available.
+func IsRunning(ctx context.Context) bool {
+	runtimeMu.Lock()
+	defer runtimeMu.Unlock()
+
+	if currentRuntime != nil {
+		return currentRuntime.Ping(ctx) == nil
+	}
+
+	for _, candidate := range []Runtime{newDockerRuntime(), newPodmanRuntime()} {
+		if candidate.Ping(ctx) == nil {
+			currentRuntime = candidate
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,45 @@
+package docker
+
+import (
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSelectRuntime(t *testing.T) {
+	defer SetRuntime(nil)
+
+	assert.NoError(t, SelectRuntime(RuntimePodman))
+	assert.Equal(t, RuntimePodman, RuntimeName())
+
+	assert.NoError(t, SelectRuntime(RuntimeDocker))
+	assert.Equal(t, RuntimeDocker, RuntimeName())
+
+	assert.ErrorContains(t, SelectRuntime("containerd"), "unknown runtime")
+
+	// Docker is used until a runtime is detected
+	assert.NoError(t, SelectRuntime(""))
+	assert.Equal(t, RuntimeDocker, RuntimeName())
+}
+
+func TestGetPodmanHost(t *testing.T) {
+	runtimeDir := t.TempDir()
+	t.Setenv("XDG_RUNTIME_DIR", runtimeDir)
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("CONTAINER_HOST", "")
+
+	socketPath := filepath.Join(runtimeDir, "podman", "podman.sock")
+	assert.Equal(t, "unix://"+socketPath, getPodmanHost())
+
+	assert.NoError(t, os.MkdirAll(filepath.Dir(socketPath), 0755))
+	assert.NoError(t, os.WriteFile(socketPath, nil, 0644))
+	assert.Equal(t, "unix://"+socketPath, getPodmanHost())
+
+	t.Setenv("CONTAINER_HOST", "unix:///tmp/podman.sock")
+	assert.Equal(t, "unix:///tmp/podman.sock", getPodmanHost())
+
+	// Remote connections are not supported
+	t.Setenv("CONTAINER_HOST", "ssh://core@localhost:2222/run/podman/podman.sock")
+	assert.Equal(t, "unix://"+socketPath, getPodmanHost())
+}
@@ -0,0 +1,61 @@
+package docker
+
+import (
+	"context"
+	"fmt"
+	"github.com/sirupsen/logrus"
+	"os"
+	"path/filepath"
+)
+
+// volumeHelperImage is used for throwaway containers that read or write volume contents
+const volumeHelperImage = "busybox:1.36"
+
+// ExportVolume writes the contents of a volume as tar archive to path. The archive is written
+// to a temporary file first so that a failed export does not leave a partial archive behind.
+func ExportVolume(ctx context.Context, logger logrus.FieldLogger, volumeName, path string) error {
+	err := os.MkdirAll(filepath.Dir(path), 0755)
+	if err != nil {
+		return fmt.Errorf("could not create directory: %w", err)
+	}
+
+	tmpPath := path + ".tmp"
+
+	file, err := os.Create(tmpPath)
+	if err != nil {
+		return fmt.Errorf("could not create archive: %w", err)
+	}
+
+	err = getRuntime().ExportVolume(ctx, logger, volumeName, file)
+	closeErr := file.Close()
+	if err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(tmpPath)
+		return fmt.Errorf("could not export volume %s: %w", volumeName, err)
+	}
+
+	err = os.Rename(tmpPath, path)
+	if err != nil {
+		return fmt.Errorf("could not move archive: %w", err)
+	}
+
+	return nil
+}
+
+// ImportVolume replaces the contents of a volume with the tar archive at path
+func ImportVolume(ctx context.Context, logger logrus.FieldLogger, volumeName, path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("could not open archive: %w", err)
+	}
+	defer file.Close()
+
+	err = getRuntime().ImportVolume(ctx, logger, volumeName, file)
+	if err != nil {
+		return fmt.Errorf("could not import volume %s: %w", volumeName, err)
+	}
+
+	return nil
+}
@@ -2,20 +2,30 @@ package docker
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"github.com/brunoscheufler/atlas/atlasfile"
-	"github.com/brunoscheufler/atlas/exec"
 	"github.com/brunoscheufler/atlas/helper"
 	"github.com/sirupsen/logrus"
+	"strings"
 )
 
-func CreateVolume(ctx context.Context, logger logrus.FieldLogger, name string) error {
-	err := exec.RunCommand(ctx, logger, fmt.Sprintf("docker volume create %s", name), exec.RunCommandOptions{})
-	if err != nil {
-		return fmt.Errorf("could not create volume %s: %w", name, err)
-	}
+func CreateVolume(ctx context.Context, logger logrus.FieldLogger, name string, labels map[string]string) error {
+	return getRuntime().CreateVolume(ctx, logger, name, labels)
+}
 
-	return nil
+// VolumeExists returns true if a volume with the name exists
+func VolumeExists(ctx context.Context, name string) (bool, error) {
+	return getRuntime().VolumeExists(ctx, name)
+}
+
+// PersistentVolumeName returns the name of a persistent volume, which is the same for every atlas up in a workspace
+func PersistentVolumeName(rootDir, stackName, serviceName, volumeName string) string {
+	h := sha256.New()
+	h.Write([]byte(strings.Join([]string{rootDir, stackName, serviceName, volumeName}, "\x00")))
+
+	return fmt.Sprintf("atlas-%s-%s-%s-%s", stackName, serviceName, volumeName, hex.EncodeToString(h.Sum(nil))[:12])
 }
 
 type EnsuredVolume struct {
@@ -23,44 +33,97 @@ type EnsuredVolume struct {
 	Service      string
 	VolumeName   string
 	PhysicalName string
+	Persistent   bool
 }
 
 type EnsuredVolumes []EnsuredVolume
 
 func (e *EnsuredVolumes) Get(stackName, serviceName, volumeName string) string {
+	if vol := e.find(stackName, serviceName, volumeName); vol != nil {
+		return vol.PhysicalName
+	}
+	return ""
+}
+
+func (e *EnsuredVolumes) find(stackName, serviceName, volumeName string) *EnsuredVolume {
 	for _, vol := range *e {
 		if vol.Stack == stackName && vol.Service == serviceName && vol.VolumeName == volumeName {
-			return vol.PhysicalName
+			return &vol
 		}
 	}
-	return ""
+	return nil
 }
 
-// EnsureVolumes creates volumes where needed and returns a list of volumes that were created.
-func EnsureVolumes(ctx context.Context, logger logrus.FieldLogger, stacks []atlasfile.StackConfig, a *atlasfile.Atlasfile) (EnsuredVolumes, error) {
+// EnsureVolumes returns the volumes of all services in stacks, reusing existing volumes and creating missing ones.
+// On error, the volumes ensured before the error are returned with it.
+func EnsureVolumes(ctx context.Context, logger logrus.FieldLogger, labels Labels, stacks []atlasfile.StackConfig, a *atlasfile.Atlasfile, existing EnsuredVolumes) (EnsuredVolumes, error) {
 	ensuredVolumes := make([]EnsuredVolume, 0)
 
 	for _, stack := range stacks {
 		for _, stackService := range stack.Services {
 			service := a.GetService(stackService.Name)
-			for _, volume := range service.Volumes {
-				if volume.IsVolume {
-					// Create volume *per stack*
-					volName := helper.RandomizedName(fmt.Sprintf("atlas-%s-%s-%s", stack.Name, service.Name, volume.HostPathOrVolumeName))
-					err := CreateVolume(ctx, logger, volName)
-					if err != nil {
-						return nil, fmt.Errorf("could not create volume: %w", err)
-					}
-
-					ensuredVolumes = append(ensuredVolumes, EnsuredVolume{
-						Stack:        stack.Name,
-						Service:      service.Name,
-						VolumeName:   volume.HostPathOrVolumeName,
-						PhysicalName: volName,
-					})
+
+			serviceVolumes, err := EnsureServiceVolumes(ctx, logger, labels, stack.Name, service, existing)
+			ensuredVolumes = append(ensuredVolumes, serviceVolumes...)
+			if err != nil {
+				return ensuredVolumes, err
+			}
+		}
+	}
+
+	return ensuredVolumes, nil
+}
+
+// EnsureServiceVolumes returns the volumes of a stack service. Ephemeral volumes are reused if they exist
+// and created otherwise, persistent volumes are created once and found by their deterministic name afterwards.
+// On error, the volumes ensured before the error are returned with it.
+func EnsureServiceVolumes(ctx context.Context, logger logrus.FieldLogger, labels Labels, stackName string, service *atlasfile.ServiceConfig, existing EnsuredVolumes) (EnsuredVolumes, error) {
+	ensuredVolumes := make([]EnsuredVolume, 0)
+
+	for _, volume := range service.Volumes {
+		if !volume.IsVolume {
+			continue
+		}
+
+		ensured := EnsuredVolume{
+			Stack:      stackName,
+			Service:    service.Name,
+			VolumeName: volume.HostPathOrVolumeName,
+			Persistent: volume.IsPersistent(),
+		}
+
+		// Volumes are recreated when their lifecycle changed
+		if prev := existing.find(stackName, service.Name, volume.HostPathOrVolumeName); prev != nil && prev.Persistent == ensured.Persistent {
+			ensured.PhysicalName = prev.PhysicalName
+		}
+
+		if ensured.PhysicalName == "" && ensured.Persistent {
+			ensured.PhysicalName = PersistentVolumeName(labels.Root, stackName, service.Name, volume.HostPathOrVolumeName)
+
+			exists, err := VolumeExists(ctx, ensured.PhysicalName)
+			if err != nil {
+				return ensuredVolumes, err
+			}
+
+			if !exists {
+				err = CreateVolume(ctx, logger, ensured.PhysicalName, labels.Volume(stackName, service.Name, volume.HostPathOrVolumeName, true))
+				if err != nil {
+					return ensuredVolumes, fmt.Errorf("could not create volume: %w", err)
 				}
 			}
 		}
+
+		if ensured.PhysicalName == "" {
+			// Create volume *per stack*
+			ensured.PhysicalName = helper.RandomizedName(fmt.Sprintf("atlas-%s-%s-%s", stackName, service.Name, volume.HostPathOrVolumeName))
+
+			err := CreateVolume(ctx, logger, ensured.PhysicalName, labels.Volume(stackName, service.Name, volume.HostPathOrVolumeName, false))
+			if err != nil {
+				return ensuredVolumes, fmt.Errorf("could not create volume: %w", err)
+			}
+		}
+
+		ensuredVolumes = append(ensuredVolumes, ensured)
 	}
 
 	return ensuredVolumes, nil
@@ -0,0 +1,17 @@
+package docker
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestPersistentVolumeName(t *testing.T) {
+	name := PersistentVolumeName("/home/atlas/project", "local", "db", "data")
+
+	assert.Regexp(t, `^atlas-local-db-data-[0-9a-f]{12}$`, name)
+	assert.Equal(t, name, PersistentVolumeName("/home/atlas/project", "local", "db", "data"))
+
+	// Workspaces with the same stacks must not share volumes
+	assert.NotEqual(t, name, PersistentVolumeName("/home/atlas/other", "local", "db", "data"))
+	assert.NotEqual(t, PersistentVolumeName("/", "a-b", "c", "d"), PersistentVolumeName("/", "a", "b-c", "d"))
+}
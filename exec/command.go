@@ -10,6 +10,7 @@ import (
 	"math/rand"
 	"os"
 	"os/exec"
+	"strings"
 	"time"
 )
 
@@ -42,6 +43,11 @@ func NewPrefixWriter(w io.Writer, prefix string) *PrefixWriter {
 	return &PrefixWriter{w: w, prefix: prefix}
 }
 
+// WithWriter returns a writer to w using the same prefix and color, so that output streams of the same source look alike
+func (pw *PrefixWriter) WithWriter(w io.Writer) *PrefixWriter {
+	return &PrefixWriter{w: w, prefix: pw.prefix}
+}
+
 func (pw PrefixWriter) Write(p []byte) (n int, err error) {
 	lines := bytes.Split(p, []byte{'\n'})
 	for _, line := range lines {
@@ -64,20 +70,21 @@ type RunCommandOptions struct {
 	LogPrefix  string
 }
 
-func RunCommand(ctx context.Context, logger logrus.FieldLogger, command string, options RunCommandOptions) error {
-	cmd := exec.CommandContext(ctx, "bash", "-c", command)
+// RunProgram runs a program with arguments passed verbatim, without a shell
+func RunProgram(ctx context.Context, logger logrus.FieldLogger, name string, args []string, options RunCommandOptions) error {
+	return run(logger, exec.CommandContext(ctx, name, args...), strings.Join(append([]string{name}, args...), " "), options)
+}
 
+func run(logger logrus.FieldLogger, cmd *exec.Cmd, command string, options RunCommandOptions) error {
 	outBuf := &bytes.Buffer{}
 	errBuf := &bytes.Buffer{}
 
 	var wo io.Writer = outBuf
-	if options.LogVisible {
-		wo = io.MultiWriter(outBuf, NewPrefixWriter(os.Stdout, options.LogPrefix))
-	}
-
 	var we io.Writer = errBuf
 	if options.LogVisible {
-		we = io.MultiWriter(errBuf, NewPrefixWriter(os.Stderr, options.LogPrefix))
+		stdout := NewPrefixWriter(os.Stdout, options.LogPrefix)
+		wo = io.MultiWriter(outBuf, stdout)
+		we = io.MultiWriter(errBuf, stdout.WithWriter(os.Stderr))
 	}
 
 	cmd.Stdout = wo
@@ -123,8 +130,10 @@ func limitString(str string, to int) string {
 	return str
 }
 
-func StartCommand(ctx context.Context, logger logrus.FieldLogger, command string, cwd string, env []string) (*exec.Cmd, error) {
-	cmd := exec.CommandContext(ctx, "bash", "-c", command)
+// StartProgram starts a program with arguments passed verbatim, without a shell, and returns without waiting for it
+func StartProgram(ctx context.Context, logger logrus.FieldLogger, name string, args []string, cwd string, env []string) (*exec.Cmd, error) {
+	cmd := exec.CommandContext(ctx, name, args...)
+	command := strings.Join(append([]string{name}, args...), " ")
 
 	outBuf := &bytes.Buffer{}
 	errBuf := &bytes.Buffer{}
@@ -1,14 +1,39 @@
 package exec
 
 import (
+	"bytes"
 	"context"
 	"github.com/sirupsen/logrus"
+	"strings"
 	"testing"
 )
 
-func TestRunCommand(t *testing.T) {
-	err := RunCommand(context.Background(), logrus.New(), "[ -n \"\"] && echo hello world", RunCommandOptions{})
+func TestRunProgram(t *testing.T) {
+	// Arguments are not interpreted by a shell
+	err := RunProgram(context.Background(), logrus.New(), "test", []string{"$HOME `x`\n", "=", "$HOME `x`\n"}, RunCommandOptions{})
 	if err != nil {
 		t.Error(err)
 	}
 }
+
+func TestPrefixWriterWithWriter(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+
+	pw := NewPrefixWriter(&stdout, "api")
+	_, err := pw.Write([]byte("out\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = pw.WithWriter(&stderr).Write([]byte("err\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Both streams use the same colored prefix
+	outPrefix := strings.TrimSuffix(stdout.String(), "out\n")
+	errPrefix := strings.TrimSuffix(stderr.String(), "err\n")
+	if outPrefix != errPrefix {
+		t.Errorf("expected prefix %q, got %q", outPrefix, errPrefix)
+	}
+}
@@ -213,6 +213,57 @@ func (g *Graph[T]) HasCycles() bool {
 	return false
 }
 
+// FindCycle returns the nodes of a cycle starting and ending with the same node, or nil if the graph is acyclic
+func (g *Graph[T]) FindCycle() []T {
+	const (
+		unvisited = iota
+		inProgress
+		done
+	)
+
+	state := make(map[T]int)
+	var path []T
+
+	var visit func(n T) []T
+	visit = func(n T) []T {
+		state[n] = inProgress
+		path = append(path, n)
+
+		for _, m := range g.NodesWithEdgeFromN(n) {
+			switch state[m] {
+			case inProgress:
+				// Cycle starts at the first occurrence of m in the current path
+				for i, p := range path {
+					if p == m {
+						return append(append([]T{}, path[i:]...), m)
+					}
+				}
+			case unvisited:
+				if cycle := visit(m); cycle != nil {
+					return cycle
+				}
+			}
+		}
+
+		path = path[:len(path)-1]
+		state[n] = done
+
+		return nil
+	}
+
+	for _, node := range g.Nodes() {
+		if state[node] != unvisited {
+			continue
+		}
+
+		if cycle := visit(node); cycle != nil {
+			return cycle
+		}
+	}
+
+	return nil
+}
+
 func (g *Graph[T]) TransitiveReduction() (*Graph[T], error) {
 	input := g
 
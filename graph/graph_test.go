@@ -119,3 +119,22 @@ func TestTransitiveReduction(t *testing.T) {
 		{"d", "e"},
 	}, reduced.Edges())
 }
+
+func TestFindCycle(t *testing.T) {
+	g := New[string]()
+
+	g.AddNode("a")
+	g.AddNode("b")
+	g.AddNode("c")
+	g.AddNode("d")
+
+	g.AddEdge("a", "b")
+	g.AddEdge("b", "c")
+	g.AddEdge("c", "d")
+
+	assert.Nil(t, g.FindCycle())
+
+	g.AddEdge("d", "b")
+
+	assert.Equal(t, []string{"b", "c", "d", "b"}, g.FindCycle())
+}
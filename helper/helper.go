@@ -1,12 +1,13 @@
 package helper
 
 import (
+	"crypto/rand"
+	"encoding/hex"
 	"fmt"
 	"github.com/joho/godotenv"
-	"math/rand"
 	"net"
 	"os"
-	"time"
+	"strings"
 )
 
 func ReadEnvFile(path string) (map[string]string, error) {
@@ -33,13 +34,19 @@ func FileExists(path string) bool {
 	return !os.IsNotExist(err)
 }
 
+// RandomizedName appends a random suffix to name. Resources are identified by their labels, the suffix
+// only has to prevent collisions of resources created in parallel.
 func RandomizedName(name string) string {
-	rnd := rand.New(rand.NewSource(time.Now().UnixNano()))
-	digits := 4
-	var suffix string
-	for i := 0; i < digits; i++ {
-		suffix += string(rune(rnd.Intn(10) + 48))
+	suffix := make([]byte, 4)
+	_, err := rand.Read(suffix)
+	if err != nil {
+		panic(fmt.Errorf("could not read random bytes: %w", err))
 	}
 
-	return fmt.Sprintf("%s-%s", name, suffix)
+	return fmt.Sprintf("%s-%s", name, hex.EncodeToString(suffix))
+}
+
+// ShellQuote quotes s as a single word for POSIX shells
+func ShellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'"'"'`) + "'"
 }
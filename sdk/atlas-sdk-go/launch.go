@@ -12,7 +12,19 @@ import (
 	"syscall"
 )
 
-func Start(atlasfile *atlasfile.Atlasfile) error {
+// EvalFunc returns the Atlasfile for the invocation described by EvalContext
+type EvalFunc func(evalContext atlasfile.EvalContext) *atlasfile.Atlasfile
+
+// Start serves a static Atlasfile
+func Start(file *atlasfile.Atlasfile) error {
+	return StartFunc(func(atlasfile.EvalContext) *atlasfile.Atlasfile {
+		return file
+	})
+}
+
+// StartFunc serves the Atlasfile returned by eval, which is invoked for every evaluation
+// with the root directory, requested stacks, profile, and variables passed by the CLI.
+func StartFunc(eval EvalFunc) error {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
@@ -58,7 +70,7 @@ func Start(atlasfile *atlasfile.Atlasfile) error {
 
 	baseLogger.WithField("logLevel", logLevel).Traceln("starting atlasfile provider")
 
-	err = serve(ctx, baseLogger, atlasfile, *port)
+	err = serve(ctx, baseLogger, eval, *port)
 	if err != nil {
 		return err
 	}
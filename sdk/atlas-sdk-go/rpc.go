@@ -8,33 +8,44 @@ import (
 	"github.com/brunoscheufler/atlas/protobuf"
 	"github.com/sirupsen/logrus"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"net"
 )
 
 type server struct {
 	protobuf.UnimplementedAtlasfileServer
-	atlasfile *atlasfile.Atlasfile
+	eval EvalFunc
 }
 
 func (s server) Eval(ctx context.Context, request *protobuf.EvalRequest) (*protobuf.EvalReply, error) {
-	marshaled, err := json.Marshal(s.atlasfile)
+	file := s.eval(atlasfile.EvalContextFromRequest(request))
+	if file == nil {
+		return nil, status.Error(codes.Internal, "eval returned no atlasfile")
+	}
+
+	// Output is kept for CLIs predating protocol version 1
+	marshaled, err := json.Marshal(file)
 	if err != nil {
 		return nil, fmt.Errorf("could not marshal atlasfile: %w", err)
 	}
 
 	return &protobuf.EvalReply{
-		Output: string(marshaled),
+		Output:    string(marshaled),
+		Atlasfile: file.ToProto(),
 	}, nil
 }
 
 func (s server) Ping(ctx context.Context, request *protobuf.PingRequest) (*protobuf.PingReply, error) {
-	return &protobuf.PingReply{}, nil
+	return &protobuf.PingReply{
+		ProtocolVersion: atlasfile.ProtocolVersion,
+	}, nil
 }
 
-func serve(ctx context.Context, logger *logrus.Entry, atlasfile *atlasfile.Atlasfile, port int) error {
+func serve(ctx context.Context, logger *logrus.Entry, eval EvalFunc, port int) error {
 	grpcServer := grpc.NewServer()
 	atlasFileServer := &server{
-		atlasfile: atlasfile,
+		eval: eval,
 	}
 
 	protobuf.RegisterAtlasfileServer(grpcServer, atlasFileServer)
@@ -0,0 +1,115 @@
+// Types mirror the JSON representation of the Go types in the atlasfile package
+
+export interface BuildOptions {
+  dockerfile?: string;
+  context?: string;
+  build_args?: Record<string, string>;
+  target?: string;
+  secrets?: BuildSecret[];
+  ssh?: string[];
+  cache_from?: string[];
+  cache_to?: string[];
+  platform?: string;
+  labels?: Record<string, string>;
+  imageName?: string;
+  tagName?: string;
+}
+
+export interface BuildSecret {
+  id: string;
+  file?: string;
+  env?: string;
+}
+
+export interface ArtifactRef {
+  name?: string;
+  artifact?: ArtifactConfig;
+}
+
+export type VolumeLifecycle = "persistent" | "ephemeral";
+
+export interface VolumeConfig {
+  isVolume?: boolean;
+  hostPath: string;
+  containerPath: string;
+  // Volumes are ephemeral unless set to persistent
+  lifecycle?: VolumeLifecycle;
+}
+
+export interface PortRequest {
+  containerPort: number;
+  protocol: string;
+}
+
+export interface PortExpose {
+  hostPort: number;
+  containerPort: number;
+}
+
+export type ContainerRestarts = "always" | "on-failure" | "unless-stopped" | "no";
+
+export type ServiceDependencyCondition = "started" | "healthy" | "completed";
+
+export interface ServiceDependency {
+  service: string;
+  condition?: ServiceDependencyCondition;
+}
+
+export interface Healthcheck {
+  command?: string[];
+  http_get?: string;
+  tcp_port?: number;
+  interval?: string;
+  timeout?: string;
+  retries?: number;
+  start_period?: string;
+}
+
+export interface ServiceConfig {
+  name: string;
+  artifact?: ArtifactRef;
+  image?: string;
+  entrypoint?: string[];
+  command?: string[];
+  port_requests?: PortRequest[];
+  environment?: Record<string, string>;
+  environment_files?: string[];
+  volumes?: VolumeConfig[];
+  restart?: ContainerRestarts;
+  interactive?: boolean;
+  tty?: boolean;
+  depends_on?: ServiceDependency[];
+  healthcheck?: Healthcheck;
+}
+
+export interface StackService {
+  name: string;
+  serviceName?: string;
+  environment?: Record<string, string>;
+  joinStackNetworks?: string[];
+  exposePorts?: PortExpose[];
+  localEnvironment?: Record<string, string>;
+  dependsOn?: ServiceDependency[];
+}
+
+export interface StackConfig {
+  name: string;
+  services: StackService[];
+}
+
+export interface ArtifactDependsOn {
+  services?: string[];
+  artifacts?: string[];
+}
+
+export interface ArtifactConfig {
+  name: string;
+  build?: BuildOptions;
+  depends_on?: ArtifactDependsOn;
+}
+
+export interface Atlasfile {
+  artifacts?: ArtifactConfig[];
+  services?: ServiceConfig[];
+  stacks?: StackConfig[];
+}
@@ -0,0 +1,66 @@
+import * as grpc from "@grpc/grpc-js";
+import { sdk } from "./sdk";
+import { Atlasfile } from "./atlasfile";
+import { atlasfileToSpec, PROTOCOL_VERSION } from "./spec";
+
+export * from "./atlasfile";
+
+/**
+ * Describes the invocation an Atlasfile is evaluated for.
+ */
+export interface EvalContext {
+  rootDir: string;
+  stacks: string[];
+  profile: string;
+  vars: Record<string, string>;
+  version: string;
+}
+
+function evalContextFromRequest(request: sdk.EvalRequest): EvalContext {
+  return {
+    rootDir: request.root_dir,
+    stacks: request.stacks,
+    profile: request.profile,
+    vars: Object.fromEntries(request.vars),
+    version: request.version,
+  };
+}
+
+/**
+ * Serves the Atlasfile to the Atlas CLI on the port passed in the PORT environment variable.
+ * When passing a function, it is invoked for every evaluation with the context passed by the CLI.
+ * Resolves once the provider was shut down by the CLI.
+ */
+export function start(atlasfile: Atlasfile | ((evalContext: EvalContext) => Atlasfile)): Promise<void> {
+  const port = parseInt(process.env.PORT ?? "", 10);
+  if (!port) {
+    return Promise.reject(new Error("PORT must be provided with non-zero value"));
+  }
+
+  const server = new grpc.Server();
+  server.addService(sdk.UnimplementedAtlasfileService.definition, {
+    Eval: (call: grpc.ServerUnaryCall<sdk.EvalRequest, sdk.EvalReply>, callback: grpc.sendUnaryData<sdk.EvalReply>) => {
+      const file = typeof atlasfile === "function" ? atlasfile(evalContextFromRequest(call.request)) : atlasfile;
+      // output is kept for CLIs predating protocol version 1
+      callback(null, new sdk.EvalReply({ output: JSON.stringify(file), atlasfile: atlasfileToSpec(file) }));
+    },
+    Ping: (_call: grpc.ServerUnaryCall<sdk.PingRequest, sdk.PingReply>, callback: grpc.sendUnaryData<sdk.PingReply>) => {
+      callback(null, new sdk.PingReply({ protocol_version: PROTOCOL_VERSION }));
+    },
+  });
+
+  return new Promise((resolve, reject) => {
+    server.bindAsync(`localhost:${port}`, grpc.ServerCredentials.createInsecure(), (err) => {
+      if (err) {
+        reject(err);
+        return;
+      }
+
+      server.start();
+
+      const shutdown = () => server.tryShutdown(() => resolve());
+      process.once("SIGTERM", shutdown);
+      process.once("SIGINT", shutdown);
+    });
+  });
+}
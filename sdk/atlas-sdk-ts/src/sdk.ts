@@ -8,23 +8,137 @@ import * as grpc_1 from "@grpc/grpc-js";
 export namespace sdk {
     export class EvalRequest extends pb_1.Message {
         #one_of_decls: number[][] = [];
-        constructor(data?: any[] | {}) {
+        constructor(data?: any[] | {
+            root_dir?: string;
+            stacks?: string[];
+            profile?: string;
+            vars?: Map<string, string>;
+            version?: string;
+        }) {
             super();
-            pb_1.Message.initialize(this, Array.isArray(data) ? data : [], 0, -1, [], this.#one_of_decls);
-            if (!Array.isArray(data) && typeof data == "object") { }
+            pb_1.Message.initialize(this, Array.isArray(data) ? data : [], 0, -1, [2], this.#one_of_decls);
+            if (!Array.isArray(data) && typeof data == "object") {
+                if ("root_dir" in data && data.root_dir != undefined) {
+                    this.root_dir = data.root_dir;
+                }
+                if ("stacks" in data && data.stacks != undefined) {
+                    this.stacks = data.stacks;
+                }
+                if ("profile" in data && data.profile != undefined) {
+                    this.profile = data.profile;
+                }
+                if ("vars" in data && data.vars != undefined) {
+                    this.vars = data.vars;
+                }
+                if ("version" in data && data.version != undefined) {
+                    this.version = data.version;
+                }
+            }
+            if (!this.vars)
+                this.vars = new Map();
+        }
+        get root_dir() {
+            return pb_1.Message.getFieldWithDefault(this, 1, "") as string;
+        }
+        set root_dir(value: string) {
+            pb_1.Message.setField(this, 1, value);
         }
-        static fromObject(data: {}): EvalRequest {
+        get stacks() {
+            return pb_1.Message.getFieldWithDefault(this, 2, []) as string[];
+        }
+        set stacks(value: string[]) {
+            pb_1.Message.setField(this, 2, value);
+        }
+        get profile() {
+            return pb_1.Message.getFieldWithDefault(this, 3, "") as string;
+        }
+        set profile(value: string) {
+            pb_1.Message.setField(this, 3, value);
+        }
+        get vars() {
+            return pb_1.Message.getField(this, 4) as any as Map<string, string>;
+        }
+        set vars(value: Map<string, string>) {
+            pb_1.Message.setField(this, 4, value as any);
+        }
+        get version() {
+            return pb_1.Message.getFieldWithDefault(this, 5, "") as string;
+        }
+        set version(value: string) {
+            pb_1.Message.setField(this, 5, value);
+        }
+        static fromObject(data: {
+            root_dir?: string;
+            stacks?: string[];
+            profile?: string;
+            vars?: {
+                [key: string]: string;
+            };
+            version?: string;
+        }): EvalRequest {
             const message = new EvalRequest({});
+            if (data.root_dir != null) {
+                message.root_dir = data.root_dir;
+            }
+            if (data.stacks != null) {
+                message.stacks = data.stacks;
+            }
+            if (data.profile != null) {
+                message.profile = data.profile;
+            }
+            if (typeof data.vars == "object") {
+                message.vars = new Map(Object.entries(data.vars));
+            }
+            if (data.version != null) {
+                message.version = data.version;
+            }
             return message;
         }
         toObject() {
-            const data: {} = {};
+            const data: {
+                root_dir?: string;
+                stacks?: string[];
+                profile?: string;
+                vars?: {
+                    [key: string]: string;
+                };
+                version?: string;
+            } = {};
+            if (this.root_dir != null) {
+                data.root_dir = this.root_dir;
+            }
+            if (this.stacks != null) {
+                data.stacks = this.stacks;
+            }
+            if (this.profile != null) {
+                data.profile = this.profile;
+            }
+            if (this.vars != null) {
+                data.vars = (Object.fromEntries)(this.vars);
+            }
+            if (this.version != null) {
+                data.version = this.version;
+            }
             return data;
         }
         serialize(): Uint8Array;
         serialize(w: pb_1.BinaryWriter): void;
         serialize(w?: pb_1.BinaryWriter): Uint8Array | void {
             const writer = w || new pb_1.BinaryWriter();
+            if (this.root_dir.length)
+                writer.writeString(1, this.root_dir);
+            if (this.stacks.length)
+                writer.writeRepeatedString(2, this.stacks);
+            if (this.profile.length)
+                writer.writeString(3, this.profile);
+            for (const [key, value] of this.vars) {
+                writer.writeMessage(4, this.vars, () => {
+                    writer.writeString(1, key);
+                    writer.writeString(2, value);
+                });
+            }
+            if (this.version.length)
+                writer.writeString(5, this.version);
             if (!w)
                 return writer.getResultBuffer();
         }
@@ -34,6 +148,21 @@ export namespace sdk {
                 if (reader.isEndGroup())
                     break;
                 switch (reader.getFieldNumber()) {
+                    case 1:
+                        message.root_dir = reader.readString();
+                        break;
+                    case 2:
+                        pb_1.Message.addToRepeatedField(message, 2, reader.readString());
+                        break;
+                    case 3:
+                        message.profile = reader.readString();
+                        break;
+                    case 4:
+                        reader.readMessage(message, () => pb_1.Map.deserializeBinary(message.vars as any, reader, reader.readString, reader.readString));
+                        break;
+                    case 5:
+                        message.version = reader.readString();
+                        break;
                     default: reader.skipField();
                 }
             }
@@ -50,6 +179,7 @@ export namespace sdk {
         #one_of_decls: number[][] = [];
         constructor(data?: any[] | {
             output?: string;
+            atlasfile?: AtlasfileSpec;
         }) {
             super();
             pb_1.Message.initialize(this, Array.isArray(data) ? data : [], 0, -1, [], this.#one_of_decls);
@@ -57,6 +187,9 @@ export namespace sdk {
                 if ("output" in data && data.output != undefined) {
                     this.output = data.output;
                 }
+                if ("atlasfile" in data && data.atlasfile != undefined) {
+                    this.atlasfile = data.atlasfile;
+                }
             }
         }
         get output() {
@@ -65,22 +198,39 @@ export namespace sdk {
         set output(value: string) {
             pb_1.Message.setField(this, 1, value);
         }
+        get atlasfile() {
+            return pb_1.Message.getWrapperField(this, AtlasfileSpec, 2) as AtlasfileSpec;
+        }
+        set atlasfile(value: AtlasfileSpec) {
+            pb_1.Message.setWrapperField(this, 2, value);
+        }
+        get has_atlasfile() {
+            return pb_1.Message.getField(this, 2) != null;
+        }
         static fromObject(data: {
             output?: string;
+            atlasfile?: ReturnType<typeof AtlasfileSpec.prototype.toObject>;
         }): EvalReply {
             const message = new EvalReply({});
             if (data.output != null) {
                 message.output = data.output;
             }
+            if (data.atlasfile != null) {
+                message.atlasfile = AtlasfileSpec.fromObject(data.atlasfile);
+            }
             return message;
         }
         toObject() {
             const data: {
                 output?: string;
+                atlasfile?: ReturnType<typeof AtlasfileSpec.prototype.toObject>;
             } = {};
             if (this.output != null) {
                 data.output = this.output;
             }
+            if (this.atlasfile != null) {
+                data.atlasfile = this.atlasfile.toObject();
+            }
             return data;
         }
         serialize(): Uint8Array;
@@ -89,6 +239,8 @@ export namespace sdk {
             const writer = w || new pb_1.BinaryWriter();
             if (this.output.length)
                 writer.writeString(1, this.output);
+            if (this.has_atlasfile)
+                writer.writeMessage(2, this.atlasfile, () => this.atlasfile.serialize(writer));
             if (!w)
                 return writer.getResultBuffer();
         }
@@ -101,6 +253,9 @@ export namespace sdk {
                     case 1:
                         message.output = reader.readString();
                         break;
+                    case 2:
+                        reader.readMessage(message.atlasfile, () => message.atlasfile = AtlasfileSpec.deserialize(reader));
+                        break;
                     default: reader.skipField();
                 }
             }
@@ -155,23 +310,47 @@ export namespace sdk {
     }
     export class PingReply extends pb_1.Message {
         #one_of_decls: number[][] = [];
-        constructor(data?: any[] | {}) {
+        constructor(data?: any[] | {
+            protocol_version?: number;
+        }) {
             super();
             pb_1.Message.initialize(this, Array.isArray(data) ? data : [], 0, -1, [], this.#one_of_decls);
-            if (!Array.isArray(data) && typeof data == "object") { }
+            if (!Array.isArray(data) && typeof data == "object") {
+                if ("protocol_version" in data && data.protocol_version != undefined) {
+                    this.protocol_version = data.protocol_version;
+                }
+            }
+        }
+        get protocol_version() {
+            return pb_1.Message.getFieldWithDefault(this, 1, 0) as number;
         }
-        static fromObject(data: {}): PingReply {
+        set protocol_version(value: number) {
+            pb_1.Message.setField(this, 1, value);
+        }
+        static fromObject(data: {
+            protocol_version?: number;
+        }): PingReply {
             const message = new PingReply({});
+            if (data.protocol_version != null) {
+                message.protocol_version = data.protocol_version;
+            }
             return message;
         }
         toObject() {
-            const data: {} = {};
+            const data: {
+                protocol_version?: number;
+            } = {};
+            if (this.protocol_version != null) {
+                data.protocol_version = this.protocol_version;
+            }
             return data;
         }
         serialize(): Uint8Array;
         serialize(w: pb_1.BinaryWriter): void;
         serialize(w?: pb_1.BinaryWriter): Uint8Array | void {
             const writer = w || new pb_1.BinaryWriter();
+            if (this.protocol_version != 0)
+                writer.writeInt32(1, this.protocol_version);
             if (!w)
                 return writer.getResultBuffer();
         }
@@ -181,6 +360,9 @@ export namespace sdk {
                 if (reader.isEndGroup())
                     break;
                 switch (reader.getFieldNumber()) {
+                    case 1:
+                        message.protocol_version = reader.readInt32();
+                        break;
                     default: reader.skipField();
                 }
             }
@@ -193,6 +375,2182 @@ export namespace sdk {
             return PingReply.deserialize(bytes);
         }
     }
+    export class AtlasfileSpec extends pb_1.Message {
+        #one_of_decls: number[][] = [];
+        constructor(data?: any[] | {
+            artifacts?: ArtifactConfig[];
+            services?: ServiceConfig[];
+            stacks?: StackConfig[];
+        }) {
+            super();
+            pb_1.Message.initialize(this, Array.isArray(data) ? data : [], 0, -1, [1, 2, 3], this.#one_of_decls);
+            if (!Array.isArray(data) && typeof data == "object") {
+                if ("artifacts" in data && data.artifacts != undefined) {
+                    this.artifacts = data.artifacts;
+                }
+                if ("services" in data && data.services != undefined) {
+                    this.services = data.services;
+                }
+                if ("stacks" in data && data.stacks != undefined) {
+                    this.stacks = data.stacks;
+                }
+            }
+        }
+        get artifacts() {
+            return pb_1.Message.getRepeatedWrapperField(this, ArtifactConfig, 1) as ArtifactConfig[];
+        }
+        set artifacts(value: ArtifactConfig[]) {
+            pb_1.Message.setRepeatedWrapperField(this, 1, value);
+        }
+        get services() {
+            return pb_1.Message.getRepeatedWrapperField(this, ServiceConfig, 2) as ServiceConfig[];
+        }
+        set services(value: ServiceConfig[]) {
+            pb_1.Message.setRepeatedWrapperField(this, 2, value);
+        }
+        get stacks() {
+            return pb_1.Message.getRepeatedWrapperField(this, StackConfig, 3) as StackConfig[];
+        }
+        set stacks(value: StackConfig[]) {
+            pb_1.Message.setRepeatedWrapperField(this, 3, value);
+        }
+        static fromObject(data: {
+            artifacts?: ReturnType<typeof ArtifactConfig.prototype.toObject>[];
+            services?: ReturnType<typeof ServiceConfig.prototype.toObject>[];
+            stacks?: ReturnType<typeof StackConfig.prototype.toObject>[];
+        }): AtlasfileSpec {
+            const message = new AtlasfileSpec({});
+            if (data.artifacts != null) {
+                message.artifacts = data.artifacts.map(item => ArtifactConfig.fromObject(item));
+            }
+            if (data.services != null) {
+                message.services = data.services.map(item => ServiceConfig.fromObject(item));
+            }
+            if (data.stacks != null) {
+                message.stacks = data.stacks.map(item => StackConfig.fromObject(item));
+            }
+            return message;
+        }
+        toObject() {
+            const data: {
+                artifacts?: ReturnType<typeof ArtifactConfig.prototype.toObject>[];
+                services?: ReturnType<typeof ServiceConfig.prototype.toObject>[];
+                stacks?: ReturnType<typeof StackConfig.prototype.toObject>[];
+            } = {};
+            if (this.artifacts != null) {
+                data.artifacts = this.artifacts.map((item: ArtifactConfig) => item.toObject());
+            }
+            if (this.services != null) {
+                data.services = this.services.map((item: ServiceConfig) => item.toObject());
+            }
+            if (this.stacks != null) {
+                data.stacks = this.stacks.map((item: StackConfig) => item.toObject());
+            }
+            return data;
+        }
+        serialize(): Uint8Array;
+        serialize(w: pb_1.BinaryWriter): void;
+        serialize(w?: pb_1.BinaryWriter): Uint8Array | void {
+            const writer = w || new pb_1.BinaryWriter();
+            if (this.artifacts.length)
+                writer.writeRepeatedMessage(1, this.artifacts, (item: ArtifactConfig) => item.serialize(writer));
+            if (this.services.length)
+                writer.writeRepeatedMessage(2, this.services, (item: ServiceConfig) => item.serialize(writer));
+            if (this.stacks.length)
+                writer.writeRepeatedMessage(3, this.stacks, (item: StackConfig) => item.serialize(writer));
+            if (!w)
+                return writer.getResultBuffer();
+        }
+        static deserialize(bytes: Uint8Array | pb_1.BinaryReader): AtlasfileSpec {
+            const reader = bytes instanceof pb_1.BinaryReader ? bytes : new pb_1.BinaryReader(bytes), message = new AtlasfileSpec();
+            while (reader.nextField()) {
+                if (reader.isEndGroup())
+                    break;
+                switch (reader.getFieldNumber()) {
+                    case 1:
+                        reader.readMessage(message.artifacts, () => pb_1.Message.addToRepeatedWrapperField(message, 1, ArtifactConfig.deserialize(reader), ArtifactConfig));
+                        break;
+                    case 2:
+                        reader.readMessage(message.services, () => pb_1.Message.addToRepeatedWrapperField(message, 2, ServiceConfig.deserialize(reader), ServiceConfig));
+                        break;
+                    case 3:
+                        reader.readMessage(message.stacks, () => pb_1.Message.addToRepeatedWrapperField(message, 3, StackConfig.deserialize(reader), StackConfig));
+                        break;
+                    default: reader.skipField();
+                }
+            }
+            return message;
+        }
+        serializeBinary(): Uint8Array {
+            return this.serialize();
+        }
+        static deserializeBinary(bytes: Uint8Array): AtlasfileSpec {
+            return AtlasfileSpec.deserialize(bytes);
+        }
+    }
+    export class BuildOptions extends pb_1.Message {
+        #one_of_decls: number[][] = [];
+        constructor(data?: any[] | {
+            dockerfile?: string;
+            context?: string;
+            build_args?: Map<string, string>;
+            target?: string;
+            image_name?: string;
+            tag_name?: string;
+            secrets?: BuildSecret[];
+            ssh?: string[];
+            cache_from?: string[];
+            cache_to?: string[];
+            platform?: string;
+            labels?: Map<string, string>;
+        }) {
+            super();
+            pb_1.Message.initialize(this, Array.isArray(data) ? data : [], 0, -1, [7, 8, 9, 10], this.#one_of_decls);
+            if (!Array.isArray(data) && typeof data == "object") {
+                if ("dockerfile" in data && data.dockerfile != undefined) {
+                    this.dockerfile = data.dockerfile;
+                }
+                if ("context" in data && data.context != undefined) {
+                    this.context = data.context;
+                }
+                if ("build_args" in data && data.build_args != undefined) {
+                    this.build_args = data.build_args;
+                }
+                if ("target" in data && data.target != undefined) {
+                    this.target = data.target;
+                }
+                if ("image_name" in data && data.image_name != undefined) {
+                    this.image_name = data.image_name;
+                }
+                if ("tag_name" in data && data.tag_name != undefined) {
+                    this.tag_name = data.tag_name;
+                }
+                if ("secrets" in data && data.secrets != undefined) {
+                    this.secrets = data.secrets;
+                }
+                if ("ssh" in data && data.ssh != undefined) {
+                    this.ssh = data.ssh;
+                }
+                if ("cache_from" in data && data.cache_from != undefined) {
+                    this.cache_from = data.cache_from;
+                }
+                if ("cache_to" in data && data.cache_to != undefined) {
+                    this.cache_to = data.cache_to;
+                }
+                if ("platform" in data && data.platform != undefined) {
+                    this.platform = data.platform;
+                }
+                if ("labels" in data && data.labels != undefined) {
+                    this.labels = data.labels;
+                }
+            }
+            if (!this.build_args)
+                this.build_args = new Map();
+            if (!this.labels)
+                this.labels = new Map();
+        }
+        get dockerfile() {
+            return pb_1.Message.getFieldWithDefault(this, 1, "") as string;
+        }
+        set dockerfile(value: string) {
+            pb_1.Message.setField(this, 1, value);
+        }
+        get context() {
+            return pb_1.Message.getFieldWithDefault(this, 2, "") as string;
+        }
+        set context(value: string) {
+            pb_1.Message.setField(this, 2, value);
+        }
+        get build_args() {
+            return pb_1.Message.getField(this, 3) as any as Map<string, string>;
+        }
+        set build_args(value: Map<string, string>) {
+            pb_1.Message.setField(this, 3, value as any);
+        }
+        get target() {
+            return pb_1.Message.getFieldWithDefault(this, 4, "") as string;
+        }
+        set target(value: string) {
+            pb_1.Message.setField(this, 4, value);
+        }
+        get image_name() {
+            return pb_1.Message.getFieldWithDefault(this, 5, "") as string;
+        }
+        set image_name(value: string) {
+            pb_1.Message.setField(this, 5, value);
+        }
+        get tag_name() {
+            return pb_1.Message.getFieldWithDefault(this, 6, "") as string;
+        }
+        set tag_name(value: string) {
+            pb_1.Message.setField(this, 6, value);
+        }
+        get secrets() {
+            return pb_1.Message.getRepeatedWrapperField(this, BuildSecret, 7) as BuildSecret[];
+        }
+        set secrets(value: BuildSecret[]) {
+            pb_1.Message.setRepeatedWrapperField(this, 7, value);
+        }
+        get ssh() {
+            return pb_1.Message.getFieldWithDefault(this, 8, []) as string[];
+        }
+        set ssh(value: string[]) {
+            pb_1.Message.setField(this, 8, value);
+        }
+        get cache_from() {
+            return pb_1.Message.getFieldWithDefault(this, 9, []) as string[];
+        }
+        set cache_from(value: string[]) {
+            pb_1.Message.setField(this, 9, value);
+        }
+        get cache_to() {
+            return pb_1.Message.getFieldWithDefault(this, 10, []) as string[];
+        }
+        set cache_to(value: string[]) {
+            pb_1.Message.setField(this, 10, value);
+        }
+        get platform() {
+            return pb_1.Message.getFieldWithDefault(this, 11, "") as string;
+        }
+        set platform(value: string) {
+            pb_1.Message.setField(this, 11, value);
+        }
+        get labels() {
+            return pb_1.Message.getField(this, 12) as any as Map<string, string>;
+        }
+        set labels(value: Map<string, string>) {
+            pb_1.Message.setField(this, 12, value as any);
+        }
+        static fromObject(data: {
+            dockerfile?: string;
+            context?: string;
+            build_args?: {
+                [key: string]: string;
+            };
+            target?: string;
+            image_name?: string;
+            tag_name?: string;
+            secrets?: ReturnType<typeof BuildSecret.prototype.toObject>[];
+            ssh?: string[];
+            cache_from?: string[];
+            cache_to?: string[];
+            platform?: string;
+            labels?: {
+                [key: string]: string;
+            };
+        }): BuildOptions {
+            const message = new BuildOptions({});
+            if (data.dockerfile != null) {
+                message.dockerfile = data.dockerfile;
+            }
+            if (data.context != null) {
+                message.context = data.context;
+            }
+            if (typeof data.build_args == "object") {
+                message.build_args = new Map(Object.entries(data.build_args));
+            }
+            if (data.target != null) {
+                message.target = data.target;
+            }
+            if (data.image_name != null) {
+                message.image_name = data.image_name;
+            }
+            if (data.tag_name != null) {
+                message.tag_name = data.tag_name;
+            }
+            if (data.secrets != null) {
+                message.secrets = data.secrets.map(item => BuildSecret.fromObject(item));
+            }
+            if (data.ssh != null) {
+                message.ssh = data.ssh;
+            }
+            if (data.cache_from != null) {
+                message.cache_from = data.cache_from;
+            }
+            if (data.cache_to != null) {
+                message.cache_to = data.cache_to;
+            }
+            if (data.platform != null) {
+                message.platform = data.platform;
+            }
+            if (typeof data.labels == "object") {
+                message.labels = new Map(Object.entries(data.labels));
+            }
+            return message;
+        }
+        toObject() {
+            const data: {
+                dockerfile?: string;
+                context?: string;
+                build_args?: {
+                    [key: string]: string;
+                };
+                target?: string;
+                image_name?: string;
+                tag_name?: string;
+                secrets?: ReturnType<typeof BuildSecret.prototype.toObject>[];
+                ssh?: string[];
+                cache_from?: string[];
+                cache_to?: string[];
+                platform?: string;
+                labels?: {
+                    [key: string]: string;
+                };
+            } = {};
+            if (this.dockerfile != null) {
+                data.dockerfile = this.dockerfile;
+            }
+            if (this.context != null) {
+                data.context = this.context;
+            }
+            if (this.build_args != null) {
+                data.build_args = (Object.fromEntries)(this.build_args);
+            }
+            if (this.target != null) {
+                data.target = this.target;
+            }
+            if (this.image_name != null) {
+                data.image_name = this.image_name;
+            }
+            if (this.tag_name != null) {
+                data.tag_name = this.tag_name;
+            }
+            if (this.secrets != null) {
+                data.secrets = this.secrets.map((item: BuildSecret) => item.toObject());
+            }
+            if (this.ssh != null) {
+                data.ssh = this.ssh;
+            }
+            if (this.cache_from != null) {
+                data.cache_from = this.cache_from;
+            }
+            if (this.cache_to != null) {
+                data.cache_to = this.cache_to;
+            }
+            if (this.platform != null) {
+                data.platform = this.platform;
+            }
+            if (this.labels != null) {
+                data.labels = (Object.fromEntries)(this.labels);
+            }
+            return data;
+        }
+        serialize(): Uint8Array;
+        serialize(w: pb_1.BinaryWriter): void;
+        serialize(w?: pb_1.BinaryWriter): Uint8Array | void {
+            const writer = w || new pb_1.BinaryWriter();
+            if (this.dockerfile.length)
+                writer.writeString(1, this.dockerfile);
+            if (this.context.length)
+                writer.writeString(2, this.context);
+            for (const [key, value] of this.build_args) {
+                writer.writeMessage(3, this.build_args, () => {
+                    writer.writeString(1, key);
+                    writer.writeString(2, value);
+                });
+            }
+            if (this.target.length)
+                writer.writeString(4, this.target);
+            if (this.image_name.length)
+                writer.writeString(5, this.image_name);
+            if (this.tag_name.length)
+                writer.writeString(6, this.tag_name);
+            if (this.secrets.length)
+                writer.writeRepeatedMessage(7, this.secrets, (item: BuildSecret) => item.serialize(writer));
+            if (this.ssh.length)
+                writer.writeRepeatedString(8, this.ssh);
+            if (this.cache_from.length)
+                writer.writeRepeatedString(9, this.cache_from);
+            if (this.cache_to.length)
+                writer.writeRepeatedString(10, this.cache_to);
+            if (this.platform.length)
+                writer.writeString(11, this.platform);
+            for (const [key, value] of this.labels) {
+                writer.writeMessage(12, this.labels, () => {
+                    writer.writeString(1, key);
+                    writer.writeString(2, value);
+                });
+            }
+            if (!w)
+                return writer.getResultBuffer();
+        }
+        static deserialize(bytes: Uint8Array | pb_1.BinaryReader): BuildOptions {
+            const reader = bytes instanceof pb_1.BinaryReader ? bytes : new pb_1.BinaryReader(bytes), message = new BuildOptions();
+            while (reader.nextField()) {
+                if (reader.isEndGroup())
+                    break;
+                switch (reader.getFieldNumber()) {
+                    case 1:
+                        message.dockerfile = reader.readString();
+                        break;
+                    case 2:
+                        message.context = reader.readString();
+                        break;
+                    case 3:
+                        reader.readMessage(message, () => pb_1.Map.deserializeBinary(message.build_args as any, reader, reader.readString, reader.readString));
+                        break;
+                    case 4:
+                        message.target = reader.readString();
+                        break;
+                    case 5:
+                        message.image_name = reader.readString();
+                        break;
+                    case 6:
+                        message.tag_name = reader.readString();
+                        break;
+                    case 7:
+                        reader.readMessage(message.secrets, () => pb_1.Message.addToRepeatedWrapperField(message, 7, BuildSecret.deserialize(reader), BuildSecret));
+                        break;
+                    case 8:
+                        pb_1.Message.addToRepeatedField(message, 8, reader.readString());
+                        break;
+                    case 9:
+                        pb_1.Message.addToRepeatedField(message, 9, reader.readString());
+                        break;
+                    case 10:
+                        pb_1.Message.addToRepeatedField(message, 10, reader.readString());
+                        break;
+                    case 11:
+                        message.platform = reader.readString();
+                        break;
+                    case 12:
+                        reader.readMessage(message, () => pb_1.Map.deserializeBinary(message.labels as any, reader, reader.readString, reader.readString));
+                        break;
+                    default: reader.skipField();
+                }
+            }
+            return message;
+        }
+        serializeBinary(): Uint8Array {
+            return this.serialize();
+        }
+        static deserializeBinary(bytes: Uint8Array): BuildOptions {
+            return BuildOptions.deserialize(bytes);
+        }
+    }
+    export class BuildSecret extends pb_1.Message {
+        #one_of_decls: number[][] = [];
+        constructor(data?: any[] | {
+            id?: string;
+            file?: string;
+            env?: string;
+        }) {
+            super();
+            pb_1.Message.initialize(this, Array.isArray(data) ? data : [], 0, -1, [], this.#one_of_decls);
+            if (!Array.isArray(data) && typeof data == "object") {
+                if ("id" in data && data.id != undefined) {
+                    this.id = data.id;
+                }
+                if ("file" in data && data.file != undefined) {
+                    this.file = data.file;
+                }
+                if ("env" in data && data.env != undefined) {
+                    this.env = data.env;
+                }
+            }
+        }
+        get id() {
+            return pb_1.Message.getFieldWithDefault(this, 1, "") as string;
+        }
+        set id(value: string) {
+            pb_1.Message.setField(this, 1, value);
+        }
+        get file() {
+            return pb_1.Message.getFieldWithDefault(this, 2, "") as string;
+        }
+        set file(value: string) {
+            pb_1.Message.setField(this, 2, value);
+        }
+        get env() {
+            return pb_1.Message.getFieldWithDefault(this, 3, "") as string;
+        }
+        set env(value: string) {
+            pb_1.Message.setField(this, 3, value);
+        }
+        static fromObject(data: {
+            id?: string;
+            file?: string;
+            env?: string;
+        }): BuildSecret {
+            const message = new BuildSecret({});
+            if (data.id != null) {
+                message.id = data.id;
+            }
+            if (data.file != null) {
+                message.file = data.file;
+            }
+            if (data.env != null) {
+                message.env = data.env;
+            }
+            return message;
+        }
+        toObject() {
+            const data: {
+                id?: string;
+                file?: string;
+                env?: string;
+            } = {};
+            if (this.id != null) {
+                data.id = this.id;
+            }
+            if (this.file != null) {
+                data.file = this.file;
+            }
+            if (this.env != null) {
+                data.env = this.env;
+            }
+            return data;
+        }
+        serialize(): Uint8Array;
+        serialize(w: pb_1.BinaryWriter): void;
+        serialize(w?: pb_1.BinaryWriter): Uint8Array | void {
+            const writer = w || new pb_1.BinaryWriter();
+            if (this.id.length)
+                writer.writeString(1, this.id);
+            if (this.file.length)
+                writer.writeString(2, this.file);
+            if (this.env.length)
+                writer.writeString(3, this.env);
+            if (!w)
+                return writer.getResultBuffer();
+        }
+        static deserialize(bytes: Uint8Array | pb_1.BinaryReader): BuildSecret {
+            const reader = bytes instanceof pb_1.BinaryReader ? bytes : new pb_1.BinaryReader(bytes), message = new BuildSecret();
+            while (reader.nextField()) {
+                if (reader.isEndGroup())
+                    break;
+                switch (reader.getFieldNumber()) {
+                    case 1:
+                        message.id = reader.readString();
+                        break;
+                    case 2:
+                        message.file = reader.readString();
+                        break;
+                    case 3:
+                        message.env = reader.readString();
+                        break;
+                    default: reader.skipField();
+                }
+            }
+            return message;
+        }
+        serializeBinary(): Uint8Array {
+            return this.serialize();
+        }
+        static deserializeBinary(bytes: Uint8Array): BuildSecret {
+            return BuildSecret.deserialize(bytes);
+        }
+    }
+    export class ArtifactDependsOn extends pb_1.Message {
+        #one_of_decls: number[][] = [];
+        constructor(data?: any[] | {
+            services?: string[];
+            artifacts?: string[];
+        }) {
+            super();
+            pb_1.Message.initialize(this, Array.isArray(data) ? data : [], 0, -1, [1, 2], this.#one_of_decls);
+            if (!Array.isArray(data) && typeof data == "object") {
+                if ("services" in data && data.services != undefined) {
+                    this.services = data.services;
+                }
+                if ("artifacts" in data && data.artifacts != undefined) {
+                    this.artifacts = data.artifacts;
+                }
+            }
+        }
+        get services() {
+            return pb_1.Message.getFieldWithDefault(this, 1, []) as string[];
+        }
+        set services(value: string[]) {
+            pb_1.Message.setField(this, 1, value);
+        }
+        get artifacts() {
+            return pb_1.Message.getFieldWithDefault(this, 2, []) as string[];
+        }
+        set artifacts(value: string[]) {
+            pb_1.Message.setField(this, 2, value);
+        }
+        static fromObject(data: {
+            services?: string[];
+            artifacts?: string[];
+        }): ArtifactDependsOn {
+            const message = new ArtifactDependsOn({});
+            if (data.services != null) {
+                message.services = data.services;
+            }
+            if (data.artifacts != null) {
+                message.artifacts = data.artifacts;
+            }
+            return message;
+        }
+        toObject() {
+            const data: {
+                services?: string[];
+                artifacts?: string[];
+            } = {};
+            if (this.services != null) {
+                data.services = this.services;
+            }
+            if (this.artifacts != null) {
+                data.artifacts = this.artifacts;
+            }
+            return data;
+        }
+        serialize(): Uint8Array;
+        serialize(w: pb_1.BinaryWriter): void;
+        serialize(w?: pb_1.BinaryWriter): Uint8Array | void {
+            const writer = w || new pb_1.BinaryWriter();
+            if (this.services.length)
+                writer.writeRepeatedString(1, this.services);
+            if (this.artifacts.length)
+                writer.writeRepeatedString(2, this.artifacts);
+            if (!w)
+                return writer.getResultBuffer();
+        }
+        static deserialize(bytes: Uint8Array | pb_1.BinaryReader): ArtifactDependsOn {
+            const reader = bytes instanceof pb_1.BinaryReader ? bytes : new pb_1.BinaryReader(bytes), message = new ArtifactDependsOn();
+            while (reader.nextField()) {
+                if (reader.isEndGroup())
+                    break;
+                switch (reader.getFieldNumber()) {
+                    case 1:
+                        pb_1.Message.addToRepeatedField(message, 1, reader.readString());
+                        break;
+                    case 2:
+                        pb_1.Message.addToRepeatedField(message, 2, reader.readString());
+                        break;
+                    default: reader.skipField();
+                }
+            }
+            return message;
+        }
+        serializeBinary(): Uint8Array {
+            return this.serialize();
+        }
+        static deserializeBinary(bytes: Uint8Array): ArtifactDependsOn {
+            return ArtifactDependsOn.deserialize(bytes);
+        }
+    }
+    export class ArtifactConfig extends pb_1.Message {
+        #one_of_decls: number[][] = [];
+        constructor(data?: any[] | {
+            name?: string;
+            build?: BuildOptions;
+            depends_on?: ArtifactDependsOn;
+        }) {
+            super();
+            pb_1.Message.initialize(this, Array.isArray(data) ? data : [], 0, -1, [], this.#one_of_decls);
+            if (!Array.isArray(data) && typeof data == "object") {
+                if ("name" in data && data.name != undefined) {
+                    this.name = data.name;
+                }
+                if ("build" in data && data.build != undefined) {
+                    this.build = data.build;
+                }
+                if ("depends_on" in data && data.depends_on != undefined) {
+                    this.depends_on = data.depends_on;
+                }
+            }
+        }
+        get name() {
+            return pb_1.Message.getFieldWithDefault(this, 1, "") as string;
+        }
+        set name(value: string) {
+            pb_1.Message.setField(this, 1, value);
+        }
+        get build() {
+            return pb_1.Message.getWrapperField(this, BuildOptions, 2) as BuildOptions;
+        }
+        set build(value: BuildOptions) {
+            pb_1.Message.setWrapperField(this, 2, value);
+        }
+        get has_build() {
+            return pb_1.Message.getField(this, 2) != null;
+        }
+        get depends_on() {
+            return pb_1.Message.getWrapperField(this, ArtifactDependsOn, 3) as ArtifactDependsOn;
+        }
+        set depends_on(value: ArtifactDependsOn) {
+            pb_1.Message.setWrapperField(this, 3, value);
+        }
+        get has_depends_on() {
+            return pb_1.Message.getField(this, 3) != null;
+        }
+        static fromObject(data: {
+            name?: string;
+            build?: ReturnType<typeof BuildOptions.prototype.toObject>;
+            depends_on?: ReturnType<typeof ArtifactDependsOn.prototype.toObject>;
+        }): ArtifactConfig {
+            const message = new ArtifactConfig({});
+            if (data.name != null) {
+                message.name = data.name;
+            }
+            if (data.build != null) {
+                message.build = BuildOptions.fromObject(data.build);
+            }
+            if (data.depends_on != null) {
+                message.depends_on = ArtifactDependsOn.fromObject(data.depends_on);
+            }
+            return message;
+        }
+        toObject() {
+            const data: {
+                name?: string;
+                build?: ReturnType<typeof BuildOptions.prototype.toObject>;
+                depends_on?: ReturnType<typeof ArtifactDependsOn.prototype.toObject>;
+            } = {};
+            if (this.name != null) {
+                data.name = this.name;
+            }
+            if (this.build != null) {
+                data.build = this.build.toObject();
+            }
+            if (this.depends_on != null) {
+                data.depends_on = this.depends_on.toObject();
+            }
+            return data;
+        }
+        serialize(): Uint8Array;
+        serialize(w: pb_1.BinaryWriter): void;
+        serialize(w?: pb_1.BinaryWriter): Uint8Array | void {
+            const writer = w || new pb_1.BinaryWriter();
+            if (this.name.length)
+                writer.writeString(1, this.name);
+            if (this.has_build)
+                writer.writeMessage(2, this.build, () => this.build.serialize(writer));
+            if (this.has_depends_on)
+                writer.writeMessage(3, this.depends_on, () => this.depends_on.serialize(writer));
+            if (!w)
+                return writer.getResultBuffer();
+        }
+        static deserialize(bytes: Uint8Array | pb_1.BinaryReader): ArtifactConfig {
+            const reader = bytes instanceof pb_1.BinaryReader ? bytes : new pb_1.BinaryReader(bytes), message = new ArtifactConfig();
+            while (reader.nextField()) {
+                if (reader.isEndGroup())
+                    break;
+                switch (reader.getFieldNumber()) {
+                    case 1:
+                        message.name = reader.readString();
+                        break;
+                    case 2:
+                        reader.readMessage(message.build, () => message.build = BuildOptions.deserialize(reader));
+                        break;
+                    case 3:
+                        reader.readMessage(message.depends_on, () => message.depends_on = ArtifactDependsOn.deserialize(reader));
+                        break;
+                    default: reader.skipField();
+                }
+            }
+            return message;
+        }
+        serializeBinary(): Uint8Array {
+            return this.serialize();
+        }
+        static deserializeBinary(bytes: Uint8Array): ArtifactConfig {
+            return ArtifactConfig.deserialize(bytes);
+        }
+    }
+    export class ArtifactRef extends pb_1.Message {
+        #one_of_decls: number[][] = [];
+        constructor(data?: any[] | {
+            name?: string;
+            artifact?: ArtifactConfig;
+        }) {
+            super();
+            pb_1.Message.initialize(this, Array.isArray(data) ? data : [], 0, -1, [], this.#one_of_decls);
+            if (!Array.isArray(data) && typeof data == "object") {
+                if ("name" in data && data.name != undefined) {
+                    this.name = data.name;
+                }
+                if ("artifact" in data && data.artifact != undefined) {
+                    this.artifact = data.artifact;
+                }
+            }
+        }
+        get name() {
+            return pb_1.Message.getFieldWithDefault(this, 1, "") as string;
+        }
+        set name(value: string) {
+            pb_1.Message.setField(this, 1, value);
+        }
+        get artifact() {
+            return pb_1.Message.getWrapperField(this, ArtifactConfig, 2) as ArtifactConfig;
+        }
+        set artifact(value: ArtifactConfig) {
+            pb_1.Message.setWrapperField(this, 2, value);
+        }
+        get has_artifact() {
+            return pb_1.Message.getField(this, 2) != null;
+        }
+        static fromObject(data: {
+            name?: string;
+            artifact?: ReturnType<typeof ArtifactConfig.prototype.toObject>;
+        }): ArtifactRef {
+            const message = new ArtifactRef({});
+            if (data.name != null) {
+                message.name = data.name;
+            }
+            if (data.artifact != null) {
+                message.artifact = ArtifactConfig.fromObject(data.artifact);
+            }
+            return message;
+        }
+        toObject() {
+            const data: {
+                name?: string;
+                artifact?: ReturnType<typeof ArtifactConfig.prototype.toObject>;
+            } = {};
+            if (this.name != null) {
+                data.name = this.name;
+            }
+            if (this.artifact != null) {
+                data.artifact = this.artifact.toObject();
+            }
+            return data;
+        }
+        serialize(): Uint8Array;
+        serialize(w: pb_1.BinaryWriter): void;
+        serialize(w?: pb_1.BinaryWriter): Uint8Array | void {
+            const writer = w || new pb_1.BinaryWriter();
+            if (this.name.length)
+                writer.writeString(1, this.name);
+            if (this.has_artifact)
+                writer.writeMessage(2, this.artifact, () => this.artifact.serialize(writer));
+            if (!w)
+                return writer.getResultBuffer();
+        }
+        static deserialize(bytes: Uint8Array | pb_1.BinaryReader): ArtifactRef {
+            const reader = bytes instanceof pb_1.BinaryReader ? bytes : new pb_1.BinaryReader(bytes), message = new ArtifactRef();
+            while (reader.nextField()) {
+                if (reader.isEndGroup())
+                    break;
+                switch (reader.getFieldNumber()) {
+                    case 1:
+                        message.name = reader.readString();
+                        break;
+                    case 2:
+                        reader.readMessage(message.artifact, () => message.artifact = ArtifactConfig.deserialize(reader));
+                        break;
+                    default: reader.skipField();
+                }
+            }
+            return message;
+        }
+        serializeBinary(): Uint8Array {
+            return this.serialize();
+        }
+        static deserializeBinary(bytes: Uint8Array): ArtifactRef {
+            return ArtifactRef.deserialize(bytes);
+        }
+    }
+    export class VolumeConfig extends pb_1.Message {
+        #one_of_decls: number[][] = [];
+        constructor(data?: any[] | {
+            is_volume?: boolean;
+            host_path?: string;
+            container_path?: string;
+            lifecycle?: string;
+        }) {
+            super();
+            pb_1.Message.initialize(this, Array.isArray(data) ? data : [], 0, -1, [], this.#one_of_decls);
+            if (!Array.isArray(data) && typeof data == "object") {
+                if ("is_volume" in data && data.is_volume != undefined) {
+                    this.is_volume = data.is_volume;
+                }
+                if ("host_path" in data && data.host_path != undefined) {
+                    this.host_path = data.host_path;
+                }
+                if ("container_path" in data && data.container_path != undefined) {
+                    this.container_path = data.container_path;
+                }
+                if ("lifecycle" in data && data.lifecycle != undefined) {
+                    this.lifecycle = data.lifecycle;
+                }
+            }
+        }
+        get is_volume() {
+            return pb_1.Message.getFieldWithDefault(this, 1, false) as boolean;
+        }
+        set is_volume(value: boolean) {
+            pb_1.Message.setField(this, 1, value);
+        }
+        get host_path() {
+            return pb_1.Message.getFieldWithDefault(this, 2, "") as string;
+        }
+        set host_path(value: string) {
+            pb_1.Message.setField(this, 2, value);
+        }
+        get container_path() {
+            return pb_1.Message.getFieldWithDefault(this, 3, "") as string;
+        }
+        set container_path(value: string) {
+            pb_1.Message.setField(this, 3, value);
+        }
+        get lifecycle() {
+            return pb_1.Message.getFieldWithDefault(this, 4, "") as string;
+        }
+        set lifecycle(value: string) {
+            pb_1.Message.setField(this, 4, value);
+        }
+        static fromObject(data: {
+            is_volume?: boolean;
+            host_path?: string;
+            container_path?: string;
+            lifecycle?: string;
+        }): VolumeConfig {
+            const message = new VolumeConfig({});
+            if (data.is_volume != null) {
+                message.is_volume = data.is_volume;
+            }
+            if (data.host_path != null) {
+                message.host_path = data.host_path;
+            }
+            if (data.container_path != null) {
+                message.container_path = data.container_path;
+            }
+            if (data.lifecycle != null) {
+                message.lifecycle = data.lifecycle;
+            }
+            return message;
+        }
+        toObject() {
+            const data: {
+                is_volume?: boolean;
+                host_path?: string;
+                container_path?: string;
+                lifecycle?: string;
+            } = {};
+            if (this.is_volume != null) {
+                data.is_volume = this.is_volume;
+            }
+            if (this.host_path != null) {
+                data.host_path = this.host_path;
+            }
+            if (this.container_path != null) {
+                data.container_path = this.container_path;
+            }
+            if (this.lifecycle != null) {
+                data.lifecycle = this.lifecycle;
+            }
+            return data;
+        }
+        serialize(): Uint8Array;
+        serialize(w: pb_1.BinaryWriter): void;
+        serialize(w?: pb_1.BinaryWriter): Uint8Array | void {
+            const writer = w || new pb_1.BinaryWriter();
+            if (this.is_volume != false)
+                writer.writeBool(1, this.is_volume);
+            if (this.host_path.length)
+                writer.writeString(2, this.host_path);
+            if (this.container_path.length)
+                writer.writeString(3, this.container_path);
+            if (this.lifecycle.length)
+                writer.writeString(4, this.lifecycle);
+            if (!w)
+                return writer.getResultBuffer();
+        }
+        static deserialize(bytes: Uint8Array | pb_1.BinaryReader): VolumeConfig {
+            const reader = bytes instanceof pb_1.BinaryReader ? bytes : new pb_1.BinaryReader(bytes), message = new VolumeConfig();
+            while (reader.nextField()) {
+                if (reader.isEndGroup())
+                    break;
+                switch (reader.getFieldNumber()) {
+                    case 1:
+                        message.is_volume = reader.readBool();
+                        break;
+                    case 2:
+                        message.host_path = reader.readString();
+                        break;
+                    case 3:
+                        message.container_path = reader.readString();
+                        break;
+                    case 4:
+                        message.lifecycle = reader.readString();
+                        break;
+                    default: reader.skipField();
+                }
+            }
+            return message;
+        }
+        serializeBinary(): Uint8Array {
+            return this.serialize();
+        }
+        static deserializeBinary(bytes: Uint8Array): VolumeConfig {
+            return VolumeConfig.deserialize(bytes);
+        }
+    }
+    export class PortRequest extends pb_1.Message {
+        #one_of_decls: number[][] = [];
+        constructor(data?: any[] | {
+            container_port?: number;
+            protocol?: string;
+        }) {
+            super();
+            pb_1.Message.initialize(this, Array.isArray(data) ? data : [], 0, -1, [], this.#one_of_decls);
+            if (!Array.isArray(data) && typeof data == "object") {
+                if ("container_port" in data && data.container_port != undefined) {
+                    this.container_port = data.container_port;
+                }
+                if ("protocol" in data && data.protocol != undefined) {
+                    this.protocol = data.protocol;
+                }
+            }
+        }
+        get container_port() {
+            return pb_1.Message.getFieldWithDefault(this, 1, 0) as number;
+        }
+        set container_port(value: number) {
+            pb_1.Message.setField(this, 1, value);
+        }
+        get protocol() {
+            return pb_1.Message.getFieldWithDefault(this, 2, "") as string;
+        }
+        set protocol(value: string) {
+            pb_1.Message.setField(this, 2, value);
+        }
+        static fromObject(data: {
+            container_port?: number;
+            protocol?: string;
+        }): PortRequest {
+            const message = new PortRequest({});
+            if (data.container_port != null) {
+                message.container_port = data.container_port;
+            }
+            if (data.protocol != null) {
+                message.protocol = data.protocol;
+            }
+            return message;
+        }
+        toObject() {
+            const data: {
+                container_port?: number;
+                protocol?: string;
+            } = {};
+            if (this.container_port != null) {
+                data.container_port = this.container_port;
+            }
+            if (this.protocol != null) {
+                data.protocol = this.protocol;
+            }
+            return data;
+        }
+        serialize(): Uint8Array;
+        serialize(w: pb_1.BinaryWriter): void;
+        serialize(w?: pb_1.BinaryWriter): Uint8Array | void {
+            const writer = w || new pb_1.BinaryWriter();
+            if (this.container_port != 0)
+                writer.writeInt32(1, this.container_port);
+            if (this.protocol.length)
+                writer.writeString(2, this.protocol);
+            if (!w)
+                return writer.getResultBuffer();
+        }
+        static deserialize(bytes: Uint8Array | pb_1.BinaryReader): PortRequest {
+            const reader = bytes instanceof pb_1.BinaryReader ? bytes : new pb_1.BinaryReader(bytes), message = new PortRequest();
+            while (reader.nextField()) {
+                if (reader.isEndGroup())
+                    break;
+                switch (reader.getFieldNumber()) {
+                    case 1:
+                        message.container_port = reader.readInt32();
+                        break;
+                    case 2:
+                        message.protocol = reader.readString();
+                        break;
+                    default: reader.skipField();
+                }
+            }
+            return message;
+        }
+        serializeBinary(): Uint8Array {
+            return this.serialize();
+        }
+        static deserializeBinary(bytes: Uint8Array): PortRequest {
+            return PortRequest.deserialize(bytes);
+        }
+    }
+    export class PortExpose extends pb_1.Message {
+        #one_of_decls: number[][] = [];
+        constructor(data?: any[] | {
+            host_port?: number;
+            container_port?: number;
+        }) {
+            super();
+            pb_1.Message.initialize(this, Array.isArray(data) ? data : [], 0, -1, [], this.#one_of_decls);
+            if (!Array.isArray(data) && typeof data == "object") {
+                if ("host_port" in data && data.host_port != undefined) {
+                    this.host_port = data.host_port;
+                }
+                if ("container_port" in data && data.container_port != undefined) {
+                    this.container_port = data.container_port;
+                }
+            }
+        }
+        get host_port() {
+            return pb_1.Message.getFieldWithDefault(this, 1, 0) as number;
+        }
+        set host_port(value: number) {
+            pb_1.Message.setField(this, 1, value);
+        }
+        get container_port() {
+            return pb_1.Message.getFieldWithDefault(this, 2, 0) as number;
+        }
+        set container_port(value: number) {
+            pb_1.Message.setField(this, 2, value);
+        }
+        static fromObject(data: {
+            host_port?: number;
+            container_port?: number;
+        }): PortExpose {
+            const message = new PortExpose({});
+            if (data.host_port != null) {
+                message.host_port = data.host_port;
+            }
+            if (data.container_port != null) {
+                message.container_port = data.container_port;
+            }
+            return message;
+        }
+        toObject() {
+            const data: {
+                host_port?: number;
+                container_port?: number;
+            } = {};
+            if (this.host_port != null) {
+                data.host_port = this.host_port;
+            }
+            if (this.container_port != null) {
+                data.container_port = this.container_port;
+            }
+            return data;
+        }
+        serialize(): Uint8Array;
+        serialize(w: pb_1.BinaryWriter): void;
+        serialize(w?: pb_1.BinaryWriter): Uint8Array | void {
+            const writer = w || new pb_1.BinaryWriter();
+            if (this.host_port != 0)
+                writer.writeInt32(1, this.host_port);
+            if (this.container_port != 0)
+                writer.writeInt32(2, this.container_port);
+            if (!w)
+                return writer.getResultBuffer();
+        }
+        static deserialize(bytes: Uint8Array | pb_1.BinaryReader): PortExpose {
+            const reader = bytes instanceof pb_1.BinaryReader ? bytes : new pb_1.BinaryReader(bytes), message = new PortExpose();
+            while (reader.nextField()) {
+                if (reader.isEndGroup())
+                    break;
+                switch (reader.getFieldNumber()) {
+                    case 1:
+                        message.host_port = reader.readInt32();
+                        break;
+                    case 2:
+                        message.container_port = reader.readInt32();
+                        break;
+                    default: reader.skipField();
+                }
+            }
+            return message;
+        }
+        serializeBinary(): Uint8Array {
+            return this.serialize();
+        }
+        static deserializeBinary(bytes: Uint8Array): PortExpose {
+            return PortExpose.deserialize(bytes);
+        }
+    }
+    export class ServiceConfig extends pb_1.Message {
+        #one_of_decls: number[][] = [];
+        constructor(data?: any[] | {
+            name?: string;
+            artifact?: ArtifactRef;
+            image?: string;
+            entrypoint?: string[];
+            command?: string[];
+            ports?: PortRequest[];
+            environment?: Map<string, string>;
+            environment_files?: string[];
+            volumes?: VolumeConfig[];
+            restart?: string;
+            interactive?: boolean;
+            tty?: boolean;
+            depends_on?: ServiceDependency[];
+            healthcheck?: Healthcheck;
+        }) {
+            super();
+            pb_1.Message.initialize(this, Array.isArray(data) ? data : [], 0, -1, [4, 5, 6, 8, 9, 13], this.#one_of_decls);
+            if (!Array.isArray(data) && typeof data == "object") {
+                if ("name" in data && data.name != undefined) {
+                    this.name = data.name;
+                }
+                if ("artifact" in data && data.artifact != undefined) {
+                    this.artifact = data.artifact;
+                }
+                if ("image" in data && data.image != undefined) {
+                    this.image = data.image;
+                }
+                if ("entrypoint" in data && data.entrypoint != undefined) {
+                    this.entrypoint = data.entrypoint;
+                }
+                if ("command" in data && data.command != undefined) {
+                    this.command = data.command;
+                }
+                if ("ports" in data && data.ports != undefined) {
+                    this.ports = data.ports;
+                }
+                if ("environment" in data && data.environment != undefined) {
+                    this.environment = data.environment;
+                }
+                if ("environment_files" in data && data.environment_files != undefined) {
+                    this.environment_files = data.environment_files;
+                }
+                if ("volumes" in data && data.volumes != undefined) {
+                    this.volumes = data.volumes;
+                }
+                if ("restart" in data && data.restart != undefined) {
+                    this.restart = data.restart;
+                }
+                if ("interactive" in data && data.interactive != undefined) {
+                    this.interactive = data.interactive;
+                }
+                if ("tty" in data && data.tty != undefined) {
+                    this.tty = data.tty;
+                }
+                if ("depends_on" in data && data.depends_on != undefined) {
+                    this.depends_on = data.depends_on;
+                }
+                if ("healthcheck" in data && data.healthcheck != undefined) {
+                    this.healthcheck = data.healthcheck;
+                }
+            }
+            if (!this.environment)
+                this.environment = new Map();
+        }
+        get name() {
+            return pb_1.Message.getFieldWithDefault(this, 1, "") as string;
+        }
+        set name(value: string) {
+            pb_1.Message.setField(this, 1, value);
+        }
+        get artifact() {
+            return pb_1.Message.getWrapperField(this, ArtifactRef, 2) as ArtifactRef;
+        }
+        set artifact(value: ArtifactRef) {
+            pb_1.Message.setWrapperField(this, 2, value);
+        }
+        get has_artifact() {
+            return pb_1.Message.getField(this, 2) != null;
+        }
+        get image() {
+            return pb_1.Message.getFieldWithDefault(this, 3, "") as string;
+        }
+        set image(value: string) {
+            pb_1.Message.setField(this, 3, value);
+        }
+        get entrypoint() {
+            return pb_1.Message.getFieldWithDefault(this, 4, []) as string[];
+        }
+        set entrypoint(value: string[]) {
+            pb_1.Message.setField(this, 4, value);
+        }
+        get command() {
+            return pb_1.Message.getFieldWithDefault(this, 5, []) as string[];
+        }
+        set command(value: string[]) {
+            pb_1.Message.setField(this, 5, value);
+        }
+        get ports() {
+            return pb_1.Message.getRepeatedWrapperField(this, PortRequest, 6) as PortRequest[];
+        }
+        set ports(value: PortRequest[]) {
+            pb_1.Message.setRepeatedWrapperField(this, 6, value);
+        }
+        get environment() {
+            return pb_1.Message.getField(this, 7) as any as Map<string, string>;
+        }
+        set environment(value: Map<string, string>) {
+            pb_1.Message.setField(this, 7, value as any);
+        }
+        get environment_files() {
+            return pb_1.Message.getFieldWithDefault(this, 8, []) as string[];
+        }
+        set environment_files(value: string[]) {
+            pb_1.Message.setField(this, 8, value);
+        }
+        get volumes() {
+            return pb_1.Message.getRepeatedWrapperField(this, VolumeConfig, 9) as VolumeConfig[];
+        }
+        set volumes(value: VolumeConfig[]) {
+            pb_1.Message.setRepeatedWrapperField(this, 9, value);
+        }
+        get restart() {
+            return pb_1.Message.getFieldWithDefault(this, 10, "") as string;
+        }
+        set restart(value: string) {
+            pb_1.Message.setField(this, 10, value);
+        }
+        get interactive() {
+            return pb_1.Message.getFieldWithDefault(this, 11, false) as boolean;
+        }
+        set interactive(value: boolean) {
+            pb_1.Message.setField(this, 11, value);
+        }
+        get tty() {
+            return pb_1.Message.getFieldWithDefault(this, 12, false) as boolean;
+        }
+        set tty(value: boolean) {
+            pb_1.Message.setField(this, 12, value);
+        }
+        get depends_on() {
+            return pb_1.Message.getRepeatedWrapperField(this, ServiceDependency, 13) as ServiceDependency[];
+        }
+        set depends_on(value: ServiceDependency[]) {
+            pb_1.Message.setRepeatedWrapperField(this, 13, value);
+        }
+        get healthcheck() {
+            return pb_1.Message.getWrapperField(this, Healthcheck, 14) as Healthcheck;
+        }
+        set healthcheck(value: Healthcheck) {
+            pb_1.Message.setWrapperField(this, 14, value);
+        }
+        get has_healthcheck() {
+            return pb_1.Message.getField(this, 14) != null;
+        }
+        static fromObject(data: {
+            name?: string;
+            artifact?: ReturnType<typeof ArtifactRef.prototype.toObject>;
+            image?: string;
+            entrypoint?: string[];
+            command?: string[];
+            ports?: ReturnType<typeof PortRequest.prototype.toObject>[];
+            environment?: {
+                [key: string]: string;
+            };
+            environment_files?: string[];
+            volumes?: ReturnType<typeof VolumeConfig.prototype.toObject>[];
+            restart?: string;
+            interactive?: boolean;
+            tty?: boolean;
+            depends_on?: ReturnType<typeof ServiceDependency.prototype.toObject>[];
+            healthcheck?: ReturnType<typeof Healthcheck.prototype.toObject>;
+        }): ServiceConfig {
+            const message = new ServiceConfig({});
+            if (data.name != null) {
+                message.name = data.name;
+            }
+            if (data.artifact != null) {
+                message.artifact = ArtifactRef.fromObject(data.artifact);
+            }
+            if (data.image != null) {
+                message.image = data.image;
+            }
+            if (data.entrypoint != null) {
+                message.entrypoint = data.entrypoint;
+            }
+            if (data.command != null) {
+                message.command = data.command;
+            }
+            if (data.ports != null) {
+                message.ports = data.ports.map(item => PortRequest.fromObject(item));
+            }
+            if (typeof data.environment == "object") {
+                message.environment = new Map(Object.entries(data.environment));
+            }
+            if (data.environment_files != null) {
+                message.environment_files = data.environment_files;
+            }
+            if (data.volumes != null) {
+                message.volumes = data.volumes.map(item => VolumeConfig.fromObject(item));
+            }
+            if (data.restart != null) {
+                message.restart = data.restart;
+            }
+            if (data.interactive != null) {
+                message.interactive = data.interactive;
+            }
+            if (data.tty != null) {
+                message.tty = data.tty;
+            }
+            if (data.depends_on != null) {
+                message.depends_on = data.depends_on.map(item => ServiceDependency.fromObject(item));
+            }
+            if (data.healthcheck != null) {
+                message.healthcheck = Healthcheck.fromObject(data.healthcheck);
+            }
+            return message;
+        }
+        toObject() {
+            const data: {
+                name?: string;
+                artifact?: ReturnType<typeof ArtifactRef.prototype.toObject>;
+                image?: string;
+                entrypoint?: string[];
+                command?: string[];
+                ports?: ReturnType<typeof PortRequest.prototype.toObject>[];
+                environment?: {
+                    [key: string]: string;
+                };
+                environment_files?: string[];
+                volumes?: ReturnType<typeof VolumeConfig.prototype.toObject>[];
+                restart?: string;
+                interactive?: boolean;
+                tty?: boolean;
+                depends_on?: ReturnType<typeof ServiceDependency.prototype.toObject>[];
+                healthcheck?: ReturnType<typeof Healthcheck.prototype.toObject>;
+            } = {};
+            if (this.name != null) {
+                data.name = this.name;
+            }
+            if (this.artifact != null) {
+                data.artifact = this.artifact.toObject();
+            }
+            if (this.image != null) {
+                data.image = this.image;
+            }
+            if (this.entrypoint != null) {
+                data.entrypoint = this.entrypoint;
+            }
+            if (this.command != null) {
+                data.command = this.command;
+            }
+            if (this.ports != null) {
+                data.ports = this.ports.map((item: PortRequest) => item.toObject());
+            }
+            if (this.environment != null) {
+                data.environment = (Object.fromEntries)(this.environment);
+            }
+            if (this.environment_files != null) {
+                data.environment_files = this.environment_files;
+            }
+            if (this.volumes != null) {
+                data.volumes = this.volumes.map((item: VolumeConfig) => item.toObject());
+            }
+            if (this.restart != null) {
+                data.restart = this.restart;
+            }
+            if (this.interactive != null) {
+                data.interactive = this.interactive;
+            }
+            if (this.tty != null) {
+                data.tty = this.tty;
+            }
+            if (this.depends_on != null) {
+                data.depends_on = this.depends_on.map((item: ServiceDependency) => item.toObject());
+            }
+            if (this.healthcheck != null) {
+                data.healthcheck = this.healthcheck.toObject();
+            }
+            return data;
+        }
+        serialize(): Uint8Array;
+        serialize(w: pb_1.BinaryWriter): void;
+        serialize(w?: pb_1.BinaryWriter): Uint8Array | void {
+            const writer = w || new pb_1.BinaryWriter();
+            if (this.name.length)
+                writer.writeString(1, this.name);
+            if (this.has_artifact)
+                writer.writeMessage(2, this.artifact, () => this.artifact.serialize(writer));
+            if (this.image.length)
+                writer.writeString(3, this.image);
+            if (this.entrypoint.length)
+                writer.writeRepeatedString(4, this.entrypoint);
+            if (this.command.length)
+                writer.writeRepeatedString(5, this.command);
+            if (this.ports.length)
+                writer.writeRepeatedMessage(6, this.ports, (item: PortRequest) => item.serialize(writer));
+            for (const [key, value] of this.environment) {
+                writer.writeMessage(7, this.environment, () => {
+                    writer.writeString(1, key);
+                    writer.writeString(2, value);
+                });
+            }
+            if (this.environment_files.length)
+                writer.writeRepeatedString(8, this.environment_files);
+            if (this.volumes.length)
+                writer.writeRepeatedMessage(9, this.volumes, (item: VolumeConfig) => item.serialize(writer));
+            if (this.restart.length)
+                writer.writeString(10, this.restart);
+            if (this.interactive != false)
+                writer.writeBool(11, this.interactive);
+            if (this.tty != false)
+                writer.writeBool(12, this.tty);
+            if (this.depends_on.length)
+                writer.writeRepeatedMessage(13, this.depends_on, (item: ServiceDependency) => item.serialize(writer));
+            if (this.has_healthcheck)
+                writer.writeMessage(14, this.healthcheck, () => this.healthcheck.serialize(writer));
+            if (!w)
+                return writer.getResultBuffer();
+        }
+        static deserialize(bytes: Uint8Array | pb_1.BinaryReader): ServiceConfig {
+            const reader = bytes instanceof pb_1.BinaryReader ? bytes : new pb_1.BinaryReader(bytes), message = new ServiceConfig();
+            while (reader.nextField()) {
+                if (reader.isEndGroup())
+                    break;
+                switch (reader.getFieldNumber()) {
+                    case 1:
+                        message.name = reader.readString();
+                        break;
+                    case 2:
+                        reader.readMessage(message.artifact, () => message.artifact = ArtifactRef.deserialize(reader));
+                        break;
+                    case 3:
+                        message.image = reader.readString();
+                        break;
+                    case 4:
+                        pb_1.Message.addToRepeatedField(message, 4, reader.readString());
+                        break;
+                    case 5:
+                        pb_1.Message.addToRepeatedField(message, 5, reader.readString());
+                        break;
+                    case 6:
+                        reader.readMessage(message.ports, () => pb_1.Message.addToRepeatedWrapperField(message, 6, PortRequest.deserialize(reader), PortRequest));
+                        break;
+                    case 7:
+                        reader.readMessage(message, () => pb_1.Map.deserializeBinary(message.environment as any, reader, reader.readString, reader.readString));
+                        break;
+                    case 8:
+                        pb_1.Message.addToRepeatedField(message, 8, reader.readString());
+                        break;
+                    case 9:
+                        reader.readMessage(message.volumes, () => pb_1.Message.addToRepeatedWrapperField(message, 9, VolumeConfig.deserialize(reader), VolumeConfig));
+                        break;
+                    case 10:
+                        message.restart = reader.readString();
+                        break;
+                    case 11:
+                        message.interactive = reader.readBool();
+                        break;
+                    case 12:
+                        message.tty = reader.readBool();
+                        break;
+                    case 13:
+                        reader.readMessage(message.depends_on, () => pb_1.Message.addToRepeatedWrapperField(message, 13, ServiceDependency.deserialize(reader), ServiceDependency));
+                        break;
+                    case 14:
+                        reader.readMessage(message.healthcheck, () => message.healthcheck = Healthcheck.deserialize(reader));
+                        break;
+                    default: reader.skipField();
+                }
+            }
+            return message;
+        }
+        serializeBinary(): Uint8Array {
+            return this.serialize();
+        }
+        static deserializeBinary(bytes: Uint8Array): ServiceConfig {
+            return ServiceConfig.deserialize(bytes);
+        }
+    }
+    export class Healthcheck extends pb_1.Message {
+        #one_of_decls: number[][] = [];
+        constructor(data?: any[] | {
+            command?: string[];
+            http_get?: string;
+            tcp_port?: number;
+            interval?: string;
+            timeout?: string;
+            retries?: number;
+            start_period?: string;
+        }) {
+            super();
+            pb_1.Message.initialize(this, Array.isArray(data) ? data : [], 0, -1, [1], this.#one_of_decls);
+            if (!Array.isArray(data) && typeof data == "object") {
+                if ("command" in data && data.command != undefined) {
+                    this.command = data.command;
+                }
+                if ("http_get" in data && data.http_get != undefined) {
+                    this.http_get = data.http_get;
+                }
+                if ("tcp_port" in data && data.tcp_port != undefined) {
+                    this.tcp_port = data.tcp_port;
+                }
+                if ("interval" in data && data.interval != undefined) {
+                    this.interval = data.interval;
+                }
+                if ("timeout" in data && data.timeout != undefined) {
+                    this.timeout = data.timeout;
+                }
+                if ("retries" in data && data.retries != undefined) {
+                    this.retries = data.retries;
+                }
+                if ("start_period" in data && data.start_period != undefined) {
+                    this.start_period = data.start_period;
+                }
+            }
+        }
+        get command() {
+            return pb_1.Message.getFieldWithDefault(this, 1, []) as string[];
+        }
+        set command(value: string[]) {
+            pb_1.Message.setField(this, 1, value);
+        }
+        get http_get() {
+            return pb_1.Message.getFieldWithDefault(this, 2, "") as string;
+        }
+        set http_get(value: string) {
+            pb_1.Message.setField(this, 2, value);
+        }
+        get tcp_port() {
+            return pb_1.Message.getFieldWithDefault(this, 3, 0) as number;
+        }
+        set tcp_port(value: number) {
+            pb_1.Message.setField(this, 3, value);
+        }
+        get interval() {
+            return pb_1.Message.getFieldWithDefault(this, 4, "") as string;
+        }
+        set interval(value: string) {
+            pb_1.Message.setField(this, 4, value);
+        }
+        get timeout() {
+            return pb_1.Message.getFieldWithDefault(this, 5, "") as string;
+        }
+        set timeout(value: string) {
+            pb_1.Message.setField(this, 5, value);
+        }
+        get retries() {
+            return pb_1.Message.getFieldWithDefault(this, 6, 0) as number;
+        }
+        set retries(value: number) {
+            pb_1.Message.setField(this, 6, value);
+        }
+        get start_period() {
+            return pb_1.Message.getFieldWithDefault(this, 7, "") as string;
+        }
+        set start_period(value: string) {
+            pb_1.Message.setField(this, 7, value);
+        }
+        static fromObject(data: {
+            command?: string[];
+            http_get?: string;
+            tcp_port?: number;
+            interval?: string;
+            timeout?: string;
+            retries?: number;
+            start_period?: string;
+        }): Healthcheck {
+            const message = new Healthcheck({});
+            if (data.command != null) {
+                message.command = data.command;
+            }
+            if (data.http_get != null) {
+                message.http_get = data.http_get;
+            }
+            if (data.tcp_port != null) {
+                message.tcp_port = data.tcp_port;
+            }
+            if (data.interval != null) {
+                message.interval = data.interval;
+            }
+            if (data.timeout != null) {
+                message.timeout = data.timeout;
+            }
+            if (data.retries != null) {
+                message.retries = data.retries;
+            }
+            if (data.start_period != null) {
+                message.start_period = data.start_period;
+            }
+            return message;
+        }
+        toObject() {
+            const data: {
+                command?: string[];
+                http_get?: string;
+                tcp_port?: number;
+                interval?: string;
+                timeout?: string;
+                retries?: number;
+                start_period?: string;
+            } = {};
+            if (this.command != null) {
+                data.command = this.command;
+            }
+            if (this.http_get != null) {
+                data.http_get = this.http_get;
+            }
+            if (this.tcp_port != null) {
+                data.tcp_port = this.tcp_port;
+            }
+            if (this.interval != null) {
+                data.interval = this.interval;
+            }
+            if (this.timeout != null) {
+                data.timeout = this.timeout;
+            }
+            if (this.retries != null) {
+                data.retries = this.retries;
+            }
+            if (this.start_period != null) {
+                data.start_period = this.start_period;
+            }
+            return data;
+        }
+        serialize(): Uint8Array;
+        serialize(w: pb_1.BinaryWriter): void;
+        serialize(w?: pb_1.BinaryWriter): Uint8Array | void {
+            const writer = w || new pb_1.BinaryWriter();
+            if (this.command.length)
+                writer.writeRepeatedString(1, this.command);
+            if (this.http_get.length)
+                writer.writeString(2, this.http_get);
+            if (this.tcp_port != 0)
+                writer.writeInt32(3, this.tcp_port);
+            if (this.interval.length)
+                writer.writeString(4, this.interval);
+            if (this.timeout.length)
+                writer.writeString(5, this.timeout);
+            if (this.retries != 0)
+                writer.writeInt32(6, this.retries);
+            if (this.start_period.length)
+                writer.writeString(7, this.start_period);
+            if (!w)
+                return writer.getResultBuffer();
+        }
+        static deserialize(bytes: Uint8Array | pb_1.BinaryReader): Healthcheck {
+            const reader = bytes instanceof pb_1.BinaryReader ? bytes : new pb_1.BinaryReader(bytes), message = new Healthcheck();
+            while (reader.nextField()) {
+                if (reader.isEndGroup())
+                    break;
+                switch (reader.getFieldNumber()) {
+                    case 1:
+                        pb_1.Message.addToRepeatedField(message, 1, reader.readString());
+                        break;
+                    case 2:
+                        message.http_get = reader.readString();
+                        break;
+                    case 3:
+                        message.tcp_port = reader.readInt32();
+                        break;
+                    case 4:
+                        message.interval = reader.readString();
+                        break;
+                    case 5:
+                        message.timeout = reader.readString();
+                        break;
+                    case 6:
+                        message.retries = reader.readInt32();
+                        break;
+                    case 7:
+                        message.start_period = reader.readString();
+                        break;
+                    default: reader.skipField();
+                }
+            }
+            return message;
+        }
+        serializeBinary(): Uint8Array {
+            return this.serialize();
+        }
+        static deserializeBinary(bytes: Uint8Array): Healthcheck {
+            return Healthcheck.deserialize(bytes);
+        }
+    }
+    export class ServiceDependency extends pb_1.Message {
+        #one_of_decls: number[][] = [];
+        constructor(data?: any[] | {
+            service?: string;
+            condition?: string;
+        }) {
+            super();
+            pb_1.Message.initialize(this, Array.isArray(data) ? data : [], 0, -1, [], this.#one_of_decls);
+            if (!Array.isArray(data) && typeof data == "object") {
+                if ("service" in data && data.service != undefined) {
+                    this.service = data.service;
+                }
+                if ("condition" in data && data.condition != undefined) {
+                    this.condition = data.condition;
+                }
+            }
+        }
+        get service() {
+            return pb_1.Message.getFieldWithDefault(this, 1, "") as string;
+        }
+        set service(value: string) {
+            pb_1.Message.setField(this, 1, value);
+        }
+        get condition() {
+            return pb_1.Message.getFieldWithDefault(this, 2, "") as string;
+        }
+        set condition(value: string) {
+            pb_1.Message.setField(this, 2, value);
+        }
+        static fromObject(data: {
+            service?: string;
+            condition?: string;
+        }): ServiceDependency {
+            const message = new ServiceDependency({});
+            if (data.service != null) {
+                message.service = data.service;
+            }
+            if (data.condition != null) {
+                message.condition = data.condition;
+            }
+            return message;
+        }
+        toObject() {
+            const data: {
+                service?: string;
+                condition?: string;
+            } = {};
+            if (this.service != null) {
+                data.service = this.service;
+            }
+            if (this.condition != null) {
+                data.condition = this.condition;
+            }
+            return data;
+        }
+        serialize(): Uint8Array;
+        serialize(w: pb_1.BinaryWriter): void;
+        serialize(w?: pb_1.BinaryWriter): Uint8Array | void {
+            const writer = w || new pb_1.BinaryWriter();
+            if (this.service.length)
+                writer.writeString(1, this.service);
+            if (this.condition.length)
+                writer.writeString(2, this.condition);
+            if (!w)
+                return writer.getResultBuffer();
+        }
+        static deserialize(bytes: Uint8Array | pb_1.BinaryReader): ServiceDependency {
+            const reader = bytes instanceof pb_1.BinaryReader ? bytes : new pb_1.BinaryReader(bytes), message = new ServiceDependency();
+            while (reader.nextField()) {
+                if (reader.isEndGroup())
+                    break;
+                switch (reader.getFieldNumber()) {
+                    case 1:
+                        message.service = reader.readString();
+                        break;
+                    case 2:
+                        message.condition = reader.readString();
+                        break;
+                    default: reader.skipField();
+                }
+            }
+            return message;
+        }
+        serializeBinary(): Uint8Array {
+            return this.serialize();
+        }
+        static deserializeBinary(bytes: Uint8Array): ServiceDependency {
+            return ServiceDependency.deserialize(bytes);
+        }
+    }
+    export class StackService extends pb_1.Message {
+        #one_of_decls: number[][] = [];
+        constructor(data?: any[] | {
+            name?: string;
+            service_name?: string;
+            environment?: Map<string, string>;
+            join_stack_networks?: string[];
+            expose_ports?: PortExpose[];
+            local_environment?: Map<string, string>;
+            depends_on?: ServiceDependency[];
+        }) {
+            super();
+            pb_1.Message.initialize(this, Array.isArray(data) ? data : [], 0, -1, [4, 5, 7], this.#one_of_decls);
+            if (!Array.isArray(data) && typeof data == "object") {
+                if ("name" in data && data.name != undefined) {
+                    this.name = data.name;
+                }
+                if ("service_name" in data && data.service_name != undefined) {
+                    this.service_name = data.service_name;
+                }
+                if ("environment" in data && data.environment != undefined) {
+                    this.environment = data.environment;
+                }
+                if ("join_stack_networks" in data && data.join_stack_networks != undefined) {
+                    this.join_stack_networks = data.join_stack_networks;
+                }
+                if ("expose_ports" in data && data.expose_ports != undefined) {
+                    this.expose_ports = data.expose_ports;
+                }
+                if ("local_environment" in data && data.local_environment != undefined) {
+                    this.local_environment = data.local_environment;
+                }
+                if ("depends_on" in data && data.depends_on != undefined) {
+                    this.depends_on = data.depends_on;
+                }
+            }
+            if (!this.environment)
+                this.environment = new Map();
+            if (!this.local_environment)
+                this.local_environment = new Map();
+        }
+        get name() {
+            return pb_1.Message.getFieldWithDefault(this, 1, "") as string;
+        }
+        set name(value: string) {
+            pb_1.Message.setField(this, 1, value);
+        }
+        get service_name() {
+            return pb_1.Message.getFieldWithDefault(this, 2, "") as string;
+        }
+        set service_name(value: string) {
+            pb_1.Message.setField(this, 2, value);
+        }
+        get environment() {
+            return pb_1.Message.getField(this, 3) as any as Map<string, string>;
+        }
+        set environment(value: Map<string, string>) {
+            pb_1.Message.setField(this, 3, value as any);
+        }
+        get join_stack_networks() {
+            return pb_1.Message.getFieldWithDefault(this, 4, []) as string[];
+        }
+        set join_stack_networks(value: string[]) {
+            pb_1.Message.setField(this, 4, value);
+        }
+        get expose_ports() {
+            return pb_1.Message.getRepeatedWrapperField(this, PortExpose, 5) as PortExpose[];
+        }
+        set expose_ports(value: PortExpose[]) {
+            pb_1.Message.setRepeatedWrapperField(this, 5, value);
+        }
+        get local_environment() {
+            return pb_1.Message.getField(this, 6) as any as Map<string, string>;
+        }
+        set local_environment(value: Map<string, string>) {
+            pb_1.Message.setField(this, 6, value as any);
+        }
+        get depends_on() {
+            return pb_1.Message.getRepeatedWrapperField(this, ServiceDependency, 7) as ServiceDependency[];
+        }
+        set depends_on(value: ServiceDependency[]) {
+            pb_1.Message.setRepeatedWrapperField(this, 7, value);
+        }
+        static fromObject(data: {
+            name?: string;
+            service_name?: string;
+            environment?: {
+                [key: string]: string;
+            };
+            join_stack_networks?: string[];
+            expose_ports?: ReturnType<typeof PortExpose.prototype.toObject>[];
+            local_environment?: {
+                [key: string]: string;
+            };
+            depends_on?: ReturnType<typeof ServiceDependency.prototype.toObject>[];
+        }): StackService {
+            const message = new StackService({});
+            if (data.name != null) {
+                message.name = data.name;
+            }
+            if (data.service_name != null) {
+                message.service_name = data.service_name;
+            }
+            if (typeof data.environment == "object") {
+                message.environment = new Map(Object.entries(data.environment));
+            }
+            if (data.join_stack_networks != null) {
+                message.join_stack_networks = data.join_stack_networks;
+            }
+            if (data.expose_ports != null) {
+                message.expose_ports = data.expose_ports.map(item => PortExpose.fromObject(item));
+            }
+            if (typeof data.local_environment == "object") {
+                message.local_environment = new Map(Object.entries(data.local_environment));
+            }
+            if (data.depends_on != null) {
+                message.depends_on = data.depends_on.map(item => ServiceDependency.fromObject(item));
+            }
+            return message;
+        }
+        toObject() {
+            const data: {
+                name?: string;
+                service_name?: string;
+                environment?: {
+                    [key: string]: string;
+                };
+                join_stack_networks?: string[];
+                expose_ports?: ReturnType<typeof PortExpose.prototype.toObject>[];
+                local_environment?: {
+                    [key: string]: string;
+                };
+                depends_on?: ReturnType<typeof ServiceDependency.prototype.toObject>[];
+            } = {};
+            if (this.name != null) {
+                data.name = this.name;
+            }
+            if (this.service_name != null) {
+                data.service_name = this.service_name;
+            }
+            if (this.environment != null) {
+                data.environment = (Object.fromEntries)(this.environment);
+            }
+            if (this.join_stack_networks != null) {
+                data.join_stack_networks = this.join_stack_networks;
+            }
+            if (this.expose_ports != null) {
+                data.expose_ports = this.expose_ports.map((item: PortExpose) => item.toObject());
+            }
+            if (this.local_environment != null) {
+                data.local_environment = (Object.fromEntries)(this.local_environment);
+            }
+            if (this.depends_on != null) {
+                data.depends_on = this.depends_on.map((item: ServiceDependency) => item.toObject());
+            }
+            return data;
+        }
+        serialize(): Uint8Array;
+        serialize(w: pb_1.BinaryWriter): void;
+        serialize(w?: pb_1.BinaryWriter): Uint8Array | void {
+            const writer = w || new pb_1.BinaryWriter();
+            if (this.name.length)
+                writer.writeString(1, this.name);
+            if (this.service_name.length)
+                writer.writeString(2, this.service_name);
+            for (const [key, value] of this.environment) {
+                writer.writeMessage(3, this.environment, () => {
+                    writer.writeString(1, key);
+                    writer.writeString(2, value);
+                });
+            }
+            if (this.join_stack_networks.length)
+                writer.writeRepeatedString(4, this.join_stack_networks);
+            if (this.expose_ports.length)
+                writer.writeRepeatedMessage(5, this.expose_ports, (item: PortExpose) => item.serialize(writer));
+            for (const [key, value] of this.local_environment) {
+                writer.writeMessage(6, this.local_environment, () => {
+                    writer.writeString(1, key);
+                    writer.writeString(2, value);
+                });
+            }
+            if (this.depends_on.length)
+                writer.writeRepeatedMessage(7, this.depends_on, (item: ServiceDependency) => item.serialize(writer));
+            if (!w)
+                return writer.getResultBuffer();
+        }
+        static deserialize(bytes: Uint8Array | pb_1.BinaryReader): StackService {
+            const reader = bytes instanceof pb_1.BinaryReader ? bytes : new pb_1.BinaryReader(bytes), message = new StackService();
+            while (reader.nextField()) {
+                if (reader.isEndGroup())
+                    break;
+                switch (reader.getFieldNumber()) {
+                    case 1:
+                        message.name = reader.readString();
+                        break;
+                    case 2:
+                        message.service_name = reader.readString();
+                        break;
+                    case 3:
+                        reader.readMessage(message, () => pb_1.Map.deserializeBinary(message.environment as any, reader, reader.readString, reader.readString));
+                        break;
+                    case 4:
+                        pb_1.Message.addToRepeatedField(message, 4, reader.readString());
+                        break;
+                    case 5:
+                        reader.readMessage(message.expose_ports, () => pb_1.Message.addToRepeatedWrapperField(message, 5, PortExpose.deserialize(reader), PortExpose));
+                        break;
+                    case 6:
+                        reader.readMessage(message, () => pb_1.Map.deserializeBinary(message.local_environment as any, reader, reader.readString, reader.readString));
+                        break;
+                    case 7:
+                        reader.readMessage(message.depends_on, () => pb_1.Message.addToRepeatedWrapperField(message, 7, ServiceDependency.deserialize(reader), ServiceDependency));
+                        break;
+                    default: reader.skipField();
+                }
+            }
+            return message;
+        }
+        serializeBinary(): Uint8Array {
+            return this.serialize();
+        }
+        static deserializeBinary(bytes: Uint8Array): StackService {
+            return StackService.deserialize(bytes);
+        }
+    }
+    export class StackConfig extends pb_1.Message {
+        #one_of_decls: number[][] = [];
+        constructor(data?: any[] | {
+            name?: string;
+            services?: StackService[];
+        }) {
+            super();
+            pb_1.Message.initialize(this, Array.isArray(data) ? data : [], 0, -1, [2], this.#one_of_decls);
+            if (!Array.isArray(data) && typeof data == "object") {
+                if ("name" in data && data.name != undefined) {
+                    this.name = data.name;
+                }
+                if ("services" in data && data.services != undefined) {
+                    this.services = data.services;
+                }
+            }
+        }
+        get name() {
+            return pb_1.Message.getFieldWithDefault(this, 1, "") as string;
+        }
+        set name(value: string) {
+            pb_1.Message.setField(this, 1, value);
+        }
+        get services() {
+            return pb_1.Message.getRepeatedWrapperField(this, StackService, 2) as StackService[];
+        }
+        set services(value: StackService[]) {
+            pb_1.Message.setRepeatedWrapperField(this, 2, value);
+        }
+        static fromObject(data: {
+            name?: string;
+            services?: ReturnType<typeof StackService.prototype.toObject>[];
+        }): StackConfig {
+            const message = new StackConfig({});
+            if (data.name != null) {
+                message.name = data.name;
+            }
+            if (data.services != null) {
+                message.services = data.services.map(item => StackService.fromObject(item));
+            }
+            return message;
+        }
+        toObject() {
+            const data: {
+                name?: string;
+                services?: ReturnType<typeof StackService.prototype.toObject>[];
+            } = {};
+            if (this.name != null) {
+                data.name = this.name;
+            }
+            if (this.services != null) {
+                data.services = this.services.map((item: StackService) => item.toObject());
+            }
+            return data;
+        }
+        serialize(): Uint8Array;
+        serialize(w: pb_1.BinaryWriter): void;
+        serialize(w?: pb_1.BinaryWriter): Uint8Array | void {
+            const writer = w || new pb_1.BinaryWriter();
+            if (this.name.length)
+                writer.writeString(1, this.name);
+            if (this.services.length)
+                writer.writeRepeatedMessage(2, this.services, (item: StackService) => item.serialize(writer));
+            if (!w)
+                return writer.getResultBuffer();
+        }
+        static deserialize(bytes: Uint8Array | pb_1.BinaryReader): StackConfig {
+            const reader = bytes instanceof pb_1.BinaryReader ? bytes : new pb_1.BinaryReader(bytes), message = new StackConfig();
+            while (reader.nextField()) {
+                if (reader.isEndGroup())
+                    break;
+                switch (reader.getFieldNumber()) {
+                    case 1:
+                        message.name = reader.readString();
+                        break;
+                    case 2:
+                        reader.readMessage(message.services, () => pb_1.Message.addToRepeatedWrapperField(message, 2, StackService.deserialize(reader), StackService));
+                        break;
+                    default: reader.skipField();
+                }
+            }
+            return message;
+        }
+        serializeBinary(): Uint8Array {
+            return this.serialize();
+        }
+        static deserializeBinary(bytes: Uint8Array): StackConfig {
+            return StackConfig.deserialize(bytes);
+        }
+    }
     interface GrpcUnaryServiceInterface<P, R> {
         (message: P, metadata: grpc_1.Metadata, options: grpc_1.CallOptions, callback: grpc_1.requestCallback<R>): grpc_1.ClientUnaryCall;
         (message: P, metadata: grpc_1.Metadata, callback: grpc_1.requestCallback<R>): grpc_1.ClientUnaryCall;
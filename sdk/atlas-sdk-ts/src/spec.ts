@@ -0,0 +1,106 @@
+import { sdk } from "./sdk";
+import { ArtifactConfig, Atlasfile, ServiceConfig, ServiceDependency, StackConfig } from "./atlasfile";
+
+/**
+ * Version of the provider protocol implemented by this SDK, reported to the CLI on Ping.
+ */
+export const PROTOCOL_VERSION = 1;
+
+function toMap(record?: Record<string, string>): Map<string, string> {
+  return new Map(Object.entries(record ?? {}));
+}
+
+function artifactToSpec(artifact: ArtifactConfig): sdk.ArtifactConfig {
+  return new sdk.ArtifactConfig({
+    name: artifact.name,
+    build: new sdk.BuildOptions({
+      dockerfile: artifact.build?.dockerfile,
+      context: artifact.build?.context,
+      build_args: toMap(artifact.build?.build_args),
+      target: artifact.build?.target,
+      secrets: artifact.build?.secrets?.map((secret) => new sdk.BuildSecret(secret)),
+      ssh: artifact.build?.ssh,
+      cache_from: artifact.build?.cache_from,
+      cache_to: artifact.build?.cache_to,
+      platform: artifact.build?.platform,
+      labels: toMap(artifact.build?.labels),
+      image_name: artifact.build?.imageName,
+      tag_name: artifact.build?.tagName,
+    }),
+    depends_on: new sdk.ArtifactDependsOn({
+      services: artifact.depends_on?.services,
+      artifacts: artifact.depends_on?.artifacts,
+    }),
+  });
+}
+
+function dependenciesToSpec(dependencies?: ServiceDependency[]): sdk.ServiceDependency[] | undefined {
+  return dependencies?.map(
+    (dependency) => new sdk.ServiceDependency({ service: dependency.service, condition: dependency.condition })
+  );
+}
+
+function serviceToSpec(service: ServiceConfig): sdk.ServiceConfig {
+  return new sdk.ServiceConfig({
+    name: service.name,
+    artifact: service.artifact
+      ? new sdk.ArtifactRef({
+          name: service.artifact.name,
+          artifact: service.artifact.artifact ? artifactToSpec(service.artifact.artifact) : undefined,
+        })
+      : undefined,
+    image: service.image,
+    entrypoint: service.entrypoint,
+    command: service.command,
+    ports: service.port_requests?.map(
+      (port) => new sdk.PortRequest({ container_port: port.containerPort, protocol: port.protocol })
+    ),
+    environment: toMap(service.environment),
+    environment_files: service.environment_files,
+    volumes: service.volumes?.map(
+      (volume) =>
+        new sdk.VolumeConfig({
+          is_volume: volume.isVolume,
+          host_path: volume.hostPath,
+          container_path: volume.containerPath,
+          lifecycle: volume.lifecycle,
+        })
+    ),
+    restart: service.restart,
+    interactive: service.interactive,
+    tty: service.tty,
+    depends_on: dependenciesToSpec(service.depends_on),
+    healthcheck: service.healthcheck ? new sdk.Healthcheck(service.healthcheck) : undefined,
+  });
+}
+
+function stackToSpec(stack: StackConfig): sdk.StackConfig {
+  return new sdk.StackConfig({
+    name: stack.name,
+    services: stack.services.map(
+      (service) =>
+        new sdk.StackService({
+          name: service.name,
+          service_name: service.serviceName,
+          environment: toMap(service.environment),
+          join_stack_networks: service.joinStackNetworks,
+          expose_ports: service.exposePorts?.map(
+            (expose) => new sdk.PortExpose({ host_port: expose.hostPort, container_port: expose.containerPort })
+          ),
+          local_environment: toMap(service.localEnvironment),
+          depends_on: dependenciesToSpec(service.dependsOn),
+        })
+    ),
+  });
+}
+
+/**
+ * Converts an Atlasfile into the typed representation sent to the CLI.
+ */
+export function atlasfileToSpec(atlasfile: Atlasfile): sdk.AtlasfileSpec {
+  return new sdk.AtlasfileSpec({
+    artifacts: atlasfile.artifacts?.map(artifactToSpec),
+    services: atlasfile.services?.map(serviceToSpec),
+    stacks: atlasfile.stacks?.map(stackToSpec),
+  });
+}